@@ -96,6 +96,10 @@ func setupRoutes(app *fiber.App) {
 	handlers.SetupWilayahRoutes(app)
 	handlers.SetupRBACRoutes(app)
 	handlers.SetupReviewRoutes(app)
+	handlers.SetupFavoriteRoutes(app)
+	handlers.SetupItineraryRoutes(app)
+	handlers.SetupAdviceRoutes(app)
+	handlers.SetupPreviewRoutes(app)
 	handlers.SetupAdminRBACRoutes(app)
 	handlers.SetupPropertyRoutes(app)
 	handlers.SetupUserManagementRoutes(app)
@@ -107,6 +111,9 @@ func setupRoutes(app *fiber.App) {
 	handlers.SetupDishRoutes(app)
 	handlers.SetupListRoutes(app)
 	handlers.SetupWilayahImageRoutes(app)
+	handlers.SetupOpeningHoursRoutes(app)
+	handlers.SetupPlaceRevisionRoutes(app)
+	handlers.SetupNotificationRoutes(app)
 }
 
 // Handler is the Vercel serverless function entry point
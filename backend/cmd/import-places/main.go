@@ -0,0 +1,76 @@
+// cmd/import-places/main.go
+//
+// Imports places from a CSV or GeoJSON file, the same way POST /api/v1/places/import does.
+//
+//	go run ./cmd/import-places -file muscat.csv -user admin@example.com -dry-run
+package main
+
+import (
+	"almlah/config"
+	"almlah/internals/domain"
+	"almlah/internals/services"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+)
+
+func main() {
+	filePath := flag.String("file", "", "CSV or GeoJSON file to import")
+	format := flag.String("format", "", "file format (csv or geojson), detected from the extension by default")
+	userEmail := flag.String("user", "", "email of the user the imported places are created by")
+	dryRun := flag.Bool("dry-run", false, "validate the file and print the report without saving anything")
+	flag.Parse()
+
+	if *filePath == "" || *userEmail == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if *format == "" {
+		detected, err := services.DetectImportFormat(*filePath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		*format = detected
+	}
+
+	cfg, err := config.SetupEnv()
+	if err != nil {
+		log.Fatalf("config file is not loaded: %v", err)
+	}
+	config.ConnectDB(cfg.DatabaseURL)
+	config.MigrateDB()
+
+	var user domain.User
+	if err := config.DB.Where("email = ?", *userEmail).First(&user).Error; err != nil {
+		log.Fatalf("user %s not found: %v", *userEmail, err)
+	}
+
+	file, err := os.Open(*filePath)
+	if err != nil {
+		log.Fatalf("failed to open %s: %v", *filePath, err)
+	}
+	defer file.Close()
+
+	records, err := services.ParsePlaceImportFile(file, *format)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	report, err := services.ImportPlaces(records, user.ID, *dryRun)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	output, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(string(output))
+
+	if report.Failed > 0 {
+		os.Exit(1)
+	}
+}
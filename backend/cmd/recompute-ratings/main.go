@@ -0,0 +1,28 @@
+// cmd/recompute-ratings/main.go
+//
+// Rebuilds the rating aggregates of every place from its reviews. MigrateDB fills them when
+// the columns are added, run this whenever reviews were changed outside the API.
+//
+//	go run ./cmd/recompute-ratings
+package main
+
+import (
+	"almlah/config"
+	"almlah/internals/services"
+	"log"
+)
+
+func main() {
+	cfg, err := config.SetupEnv()
+	if err != nil {
+		log.Fatalf("config file is not loaded: %v", err)
+	}
+	config.ConnectDB(cfg.DatabaseURL)
+	config.MigrateDB()
+
+	updated, err := services.RecomputePlaceRatings()
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Recomputed ratings for %d places", updated)
+}
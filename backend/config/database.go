@@ -5,8 +5,10 @@ import (
 	"almlah/internals/domain"
 	"log"
 
+	"github.com/google/uuid"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var DB *gorm.DB
@@ -28,6 +30,13 @@ func ConnectDB(databaseURL string) {
 }
 
 func MigrateDB() {
+	dedupedPlaces := removeDuplicateReviews()
+	removeDuplicateFavorites()
+	migrateLegacyAdvice()
+
+	// Places from before the rating aggregates get them computed once the columns exist
+	backfillRatings := DB.Migrator().HasTable(&domain.Place{}) && !DB.Migrator().HasColumn(&domain.Place{}, "review_count")
+
 	err := DB.AutoMigrate(
 		&domain.User{},
 		&domain.Place{},
@@ -39,6 +48,8 @@ func MigrateDB() {
 		&domain.Recipe{},
 		&domain.Review{},
 		&domain.RecipeImage{},
+		&domain.RecipeIngredient{},
+		&domain.RecipeStep{},
 		&domain.ReviewImage{},
 		&domain.PlaceProperty{},
 		&domain.UserFavorite{},
@@ -54,14 +65,292 @@ func MigrateDB() {
 		&domain.GovernateImage{},
 		&domain.Dish{},
 		&domain.DishImage{},
+		&domain.DishTag{},
+		&domain.DishPlace{},
 		&domain.List{},
 		&domain.ListSection{},
 		&domain.ListSectionImage{},
 		&domain.ListItem{},
 		&domain.ListItemImage{},
+		&domain.ListTemplate{},
+		&domain.ListTemplateSection{},
+		&domain.PlaceSearchDocument{},
+		&domain.PlaceOpeningHours{},
+		&domain.PlaceHoursException{},
+		&domain.PlaceHoursExceptionInterval{},
+		&domain.PlaceModerationEvent{},
+		&domain.PlaceRevision{},
+		&domain.PlaceSlugHistory{},
+		&domain.ReviewRevision{},
+		&domain.ReviewVote{},
+		&domain.ReviewReport{},
+		&domain.ReviewModerationAction{},
+		&domain.ReviewReply{},
+		&domain.ReviewReplyRevision{},
+		&domain.PlacePermission{},
+		&domain.FavoriteCollection{},
+		&domain.FavoriteCollectionPlace{},
+		&domain.Itinerary{},
+		&domain.ItineraryDay{},
+		&domain.ItineraryStop{},
+		&domain.PreviewToken{},
+		&domain.Notification{},
 	)
 	if err != nil {
 		log.Fatal("Failed to migrate database:", err)
 	}
+	if backfillRatings {
+		recomputePlaceRatings(nil)
+	} else if len(dedupedPlaces) > 0 {
+		recomputePlaceRatings(dedupedPlaces)
+	}
+	createPlaceLocationIndex()
+	padOpeningHoursClocks()
+	migrateLegacyRecipeText()
+	seedDishTags()
 	log.Println("Database migration completed")
 }
+
+// removeDuplicateReviews keeps only the latest review of each user for a place, so the
+// one-review-per-place unique index can be created on databases from before the rule.
+// It returns the places that lost reviews, their ratings are recomputed after migrating.
+func removeDuplicateReviews() []uuid.UUID {
+	if !DB.Migrator().HasTable(&domain.Review{}) || DB.Migrator().HasIndex(&domain.Review{}, "idx_reviews_user_place") {
+		return nil
+	}
+
+	var removed []uuid.UUID
+	err := DB.Raw(`UPDATE reviews SET deleted_at = NOW() WHERE id IN (
+		SELECT id FROM (
+			SELECT id, ROW_NUMBER() OVER (PARTITION BY user_id, place_id ORDER BY created_at DESC) AS n
+			FROM reviews WHERE deleted_at IS NULL
+		) AS ranked WHERE n > 1
+	) RETURNING place_id`).Scan(&removed).Error
+	if err != nil {
+		log.Fatal("Failed to remove duplicate reviews:", err)
+	}
+
+	counts := make(map[uuid.UUID]int)
+	var placeIDs []uuid.UUID
+	for _, placeID := range removed {
+		if counts[placeID] == 0 {
+			placeIDs = append(placeIDs, placeID)
+		}
+		counts[placeID]++
+	}
+	for _, placeID := range placeIDs {
+		log.Printf("Removed %d duplicate reviews of place %s", counts[placeID], placeID)
+	}
+	if len(removed) > 0 {
+		log.Printf("Removed %d duplicate reviews of %d places", len(removed), len(placeIDs))
+	}
+	return placeIDs
+}
+
+// recomputePlaceRatings fills the rating aggregates of the given places from their reviews.
+// Without place IDs every place with reviews is updated, the others keep the column defaults.
+func recomputePlaceRatings(placeIDs []uuid.UUID) {
+	var result *gorm.DB
+	if placeIDs == nil {
+		result = DB.Exec(`UPDATE places SET ` + domain.PlaceRatingAssignments + `
+			FROM (
+				SELECT place_id, ` + domain.PlaceRatingAggregates + `
+				FROM reviews WHERE ` + domain.PlaceCountedReviews + ` GROUP BY place_id
+			) AS stats
+			WHERE places.id = stats.place_id`)
+	} else {
+		result = DB.Exec(`UPDATE places SET `+domain.PlaceRatingAssignments+`
+			FROM places AS p
+			LEFT JOIN (
+				SELECT place_id, `+domain.PlaceRatingAggregates+`
+				FROM reviews WHERE place_id IN ? AND `+domain.PlaceCountedReviews+` GROUP BY place_id
+			) AS stats ON stats.place_id = p.id
+			WHERE places.id = p.id AND p.id IN ?`, placeIDs, placeIDs)
+	}
+	if result.Error != nil {
+		log.Fatal("Failed to compute place ratings:", result.Error)
+	}
+	log.Printf("Computed ratings for %d places", result.RowsAffected)
+}
+
+// removeDuplicateFavorites keeps the first favorite of each user for a place, so the
+// unique index can be created on databases where a place was favorited twice
+func removeDuplicateFavorites() {
+	if !DB.Migrator().HasTable(&domain.UserFavorite{}) || DB.Migrator().HasIndex(&domain.UserFavorite{}, "idx_user_favorites_user_place") {
+		return
+	}
+
+	result := DB.Exec(`DELETE FROM user_favorites WHERE id IN (
+		SELECT id FROM (
+			SELECT id, ROW_NUMBER() OVER (PARTITION BY user_id, place_id ORDER BY added_at ASC, id) AS n
+			FROM user_favorites
+		) AS ranked WHERE n > 1
+	)`)
+	if result.Error != nil {
+		log.Fatal("Failed to remove duplicate favorites:", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		log.Printf("Removed %d duplicate favorites", result.RowsAffected)
+	}
+}
+
+// migrateLegacyAdvice moves advice from before bilingual fields to the new columns. The
+// Arabic columns are left empty for translators. The free-text destination is resolved to
+// a wilayah or governate by name; the old columns are only dropped when every destination
+// was resolved, otherwise they're kept so editors can link the rest by hand.
+func migrateLegacyAdvice() {
+	migrator := DB.Migrator()
+	if !migrator.HasTable(&domain.Advice{}) || !migrator.HasColumn(&domain.Advice{}, "title") {
+		return
+	}
+
+	// Legacy destination columns still on the table and the trimmed text of each
+	var legacyColumns []string
+	destination := func(column string) string {
+		if !migrator.HasColumn(&domain.Advice{}, column) {
+			return "''"
+		}
+		legacyColumns = append(legacyColumns, column)
+		return "btrim(COALESCE(advices." + column + ", ''))"
+	}
+	city, country := destination("destination_city"), destination("destination_country")
+
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		for _, statement := range []string{
+			"ALTER TABLE advices RENAME COLUMN title TO title_en",
+			"ALTER TABLE advices RENAME COLUMN content TO content_en",
+			"ALTER TABLE advices ADD COLUMN title_ar varchar(200) NOT NULL DEFAULT ''",
+			"ALTER TABLE advices ADD COLUMN content_ar text NOT NULL DEFAULT ''",
+			"UPDATE advices SET title_en = LEFT(title_en, 200)",
+			"ALTER TABLE advices ADD COLUMN IF NOT EXISTS governate_id uuid",
+			"ALTER TABLE advices ADD COLUMN IF NOT EXISTS wilayah_id uuid",
+			// A city naming a wilayah links the wilayah and its governate
+			`UPDATE advices SET wilayah_id = wilayahs.id, governate_id = wilayahs.governate_id
+			FROM wilayahs
+			WHERE advices.wilayah_id IS NULL AND wilayahs.deleted_at IS NULL AND ` + city + ` <> ''
+			AND (lower(wilayahs.name_en) = lower(` + city + `) OR wilayahs.name_ar = ` + city + `)`,
+			// Otherwise the city or the country may name a governate
+			`UPDATE advices SET governate_id = governates.id
+			FROM governates
+			WHERE advices.governate_id IS NULL AND governates.deleted_at IS NULL
+			AND ((` + city + ` <> '' AND (lower(governates.name_en) = lower(` + city + `) OR governates.name_ar = ` + city + `))
+			OR (` + country + ` <> '' AND (lower(governates.name_en) = lower(` + country + `) OR governates.name_ar = ` + country + `)))`,
+		} {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+
+		// Advice about the whole country has no region, anything else left unlinked
+		// still needs its destination
+		var unresolved int64
+		err := tx.Table("advices").
+			Where("governate_id IS NULL AND wilayah_id IS NULL").
+			Where("(" + city + " <> '' OR (" + country + " <> '' AND lower(" + country + ") NOT IN ('oman', 'sultanate of oman', 'عمان', 'عُمان', 'سلطنة عمان')))").
+			Count(&unresolved).Error
+		if err != nil {
+			return err
+		}
+		if unresolved > 0 {
+			log.Printf("⚠️ %d legacy advice destinations could not be linked to a region, keeping %v", unresolved, legacyColumns)
+			for _, column := range legacyColumns {
+				if err := tx.Exec("ALTER TABLE advices ALTER COLUMN " + column + " DROP NOT NULL").Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		for _, column := range legacyColumns {
+			if err := tx.Exec("ALTER TABLE advices DROP COLUMN " + column).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatal("Failed to migrate legacy advice:", err)
+	}
+}
+
+// createPlaceLocationIndex indexes place coordinates as points for bounding box searches.
+// A btree on (latitude, longitude) only narrows the latitude range, the GiST index narrows
+// both. It replaces idx_places_lat_lng.
+func createPlaceLocationIndex() {
+	for _, statement := range []string{
+		"DROP INDEX IF EXISTS idx_places_lat_lng",
+		"CREATE INDEX IF NOT EXISTS idx_places_location ON places USING gist (point(longitude, latitude))",
+	} {
+		if err := DB.Exec(statement).Error; err != nil {
+			log.Fatal("Failed to create place location index:", err)
+		}
+	}
+}
+
+// padOpeningHoursClocks zero-pads opening times saved as "H:MM". The open filters compare
+// the times as strings, so "9:00" would sort after "10:30".
+func padOpeningHoursClocks() {
+	for _, table := range []string{"place_opening_hours", "place_hours_exception_intervals"} {
+		for _, column := range []string{"opens_at", "closes_at"} {
+			statement := "UPDATE " + table + " SET " + column + " = '0' || " + column + " WHERE " + column + " ~ '^[0-9]:[0-9]{2}$'"
+			if err := DB.Exec(statement).Error; err != nil {
+				log.Fatal("Failed to pad opening hours:", err)
+			}
+		}
+	}
+}
+
+// migrateLegacyRecipeText turns the ingredients and instructions text of recipes from
+// before structured recipes into ingredient and step rows, one per non-empty line
+func migrateLegacyRecipeText() {
+	migrator := DB.Migrator()
+	if !migrator.HasColumn(&domain.Recipe{}, "ingredients") {
+		return
+	}
+
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		for _, statement := range []string{
+			`INSERT INTO recipe_ingredients (id, recipe_id, name_ar, name_en, unit, note, sort_order)
+			SELECT gen_random_uuid(), recipe_id, line, line, '', '', ROW_NUMBER() OVER (PARTITION BY recipe_id ORDER BY n)
+			FROM (
+				SELECT recipes.id AS recipe_id, LEFT(btrim(lines.line), 200) AS line, lines.n
+				FROM recipes CROSS JOIN LATERAL regexp_split_to_table(COALESCE(recipes.ingredients, ''), '\r?\n') WITH ORDINALITY AS lines(line, n)
+			) AS split WHERE line <> ''`,
+			`INSERT INTO recipe_steps (id, recipe_id, step_number, instruction)
+			SELECT gen_random_uuid(), recipe_id, ROW_NUMBER() OVER (PARTITION BY recipe_id ORDER BY n), line
+			FROM (
+				SELECT recipes.id AS recipe_id, btrim(lines.line) AS line, lines.n
+				FROM recipes CROSS JOIN LATERAL regexp_split_to_table(COALESCE(recipes.instructions, ''), '\r?\n') WITH ORDINALITY AS lines(line, n)
+			) AS split WHERE line <> ''`,
+			"ALTER TABLE recipes DROP COLUMN ingredients",
+			"ALTER TABLE recipes DROP COLUMN IF EXISTS instructions",
+		} {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatal("Failed to migrate legacy recipes:", err)
+	}
+}
+
+// seedDishTags creates the default dietary tags and allergens. Existing tags are kept as
+// editors left them.
+func seedDishTags() {
+	tags := []domain.DishTag{
+		{Type: domain.DishTagTypeDietary, Slug: "vegetarian", NameAr: "نباتي", NameEn: "Vegetarian", SortOrder: 1},
+		{Type: domain.DishTagTypeDietary, Slug: "vegan", NameAr: "نباتي صرف", NameEn: "Vegan", SortOrder: 2},
+		{Type: domain.DishTagTypeDietary, Slug: "halal", NameAr: "حلال", NameEn: "Halal", SortOrder: 3},
+		{Type: domain.DishTagTypeDietary, Slug: "gluten-free", NameAr: "خالٍ من الغلوتين", NameEn: "Gluten-free", SortOrder: 4},
+		{Type: domain.DishTagTypeAllergen, Slug: "nuts", NameAr: "مكسرات", NameEn: "Nuts", SortOrder: 1},
+		{Type: domain.DishTagTypeAllergen, Slug: "dairy", NameAr: "ألبان", NameEn: "Dairy", SortOrder: 2},
+		{Type: domain.DishTagTypeAllergen, Slug: "seafood", NameAr: "مأكولات بحرية", NameEn: "Seafood", SortOrder: 3},
+		{Type: domain.DishTagTypeAllergen, Slug: "sesame", NameAr: "سمسم", NameEn: "Sesame", SortOrder: 4},
+	}
+
+	if err := DB.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "slug"}}, DoNothing: true}).Create(&tags).Error; err != nil {
+		log.Printf("Failed to seed dish tags: %v", err)
+	}
+}
@@ -0,0 +1,276 @@
+// handlers/adviceHandler.go
+package handlers
+
+import (
+	"almlah/internals/dto"
+	"almlah/internals/middleware"
+	"almlah/internals/services"
+	"almlah/internals/utils"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type AdviceHandler struct{}
+
+func SetupAdviceRoutes(app *fiber.App) {
+	handler := AdviceHandler{}
+
+	advice := app.Group("/api/v1/advice")
+
+	// Editors see drafts through the manage routes
+	advice.Get("/manage",
+		middleware.AuthRequiredWithRBAC,
+		middleware.RequirePermission("can_view_advice"),
+		handler.GetAllAdvice)
+
+	advice.Get("/manage/:id",
+		middleware.AuthRequiredWithRBAC,
+		middleware.RequirePermission("can_view_advice"),
+		handler.GetAnyAdvice)
+
+	// Public routes, published advice only
+	advice.Get("/", handler.GetAdvice)
+	advice.Get("/:id", handler.GetAdviceByID)
+
+	// Protected routes with RBAC
+	advice.Post("/",
+		middleware.AuthRequiredWithRBAC,
+		middleware.RequirePermission("can_create_advice"),
+		handler.CreateAdvice)
+
+	advice.Put("/:id",
+		middleware.AuthRequiredWithRBAC,
+		middleware.RequirePermission("can_edit_advice"),
+		handler.UpdateAdvice)
+
+	advice.Delete("/:id",
+		middleware.AuthRequiredWithRBAC,
+		middleware.RequirePermission("can_delete_advice"),
+		handler.DeleteAdvice)
+
+	advice.Patch("/:id/featured",
+		middleware.AuthRequiredWithRBAC,
+		middleware.RequirePermission("can_manage_advice"),
+		handler.SetFeatured)
+
+	advice.Patch("/:id/publish",
+		middleware.AuthRequiredWithRBAC,
+		middleware.RequirePermission("can_manage_advice"),
+		handler.SetPublished)
+}
+
+// GetAdvice lists published advice
+func (h *AdviceHandler) GetAdvice(ctx *fiber.Ctx) error {
+	filters, err := parseAdviceFilters(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	result, err := services.GetAdvice(filters)
+	if err != nil {
+		return adviceError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Advice retrieved successfully", result))
+}
+
+// GetAllAdvice lists advice including drafts
+func (h *AdviceHandler) GetAllAdvice(ctx *fiber.Ctx) error {
+	filters, err := parseAdviceFilters(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+	filters.IncludeUnpublished = true
+
+	result, err := services.GetAdvice(filters)
+	if err != nil {
+		return adviceError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Advice retrieved successfully", result))
+}
+
+// GetAdviceByID returns published advice and counts the view
+func (h *AdviceHandler) GetAdviceByID(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid advice ID"))
+	}
+
+	advice, err := services.GetAdviceByID(id, false)
+	if err != nil {
+		return adviceError(ctx, err)
+	}
+
+	services.RecordAdviceView(id)
+
+	return ctx.JSON(utils.SuccessResponse("Advice retrieved successfully", advice))
+}
+
+// GetAnyAdvice returns advice whether or not it's published, without counting a view
+func (h *AdviceHandler) GetAnyAdvice(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid advice ID"))
+	}
+
+	advice, err := services.GetAdviceByID(id, true)
+	if err != nil {
+		return adviceError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Advice retrieved successfully", advice))
+}
+
+func (h *AdviceHandler) CreateAdvice(ctx *fiber.Ctx) error {
+	var req dto.CreateAdviceRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	advice, err := services.CreateAdvice(req, userID)
+	if err != nil {
+		return adviceError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(utils.SuccessResponse("Advice created successfully", advice))
+}
+
+func (h *AdviceHandler) UpdateAdvice(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid advice ID"))
+	}
+
+	var req dto.UpdateAdviceRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	advice, err := services.UpdateAdvice(id, req)
+	if err != nil {
+		return adviceError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Advice updated successfully", advice))
+}
+
+func (h *AdviceHandler) DeleteAdvice(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid advice ID"))
+	}
+
+	if err := services.DeleteAdvice(id); err != nil {
+		return adviceError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Advice deleted successfully", nil))
+}
+
+func (h *AdviceHandler) SetFeatured(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid advice ID"))
+	}
+
+	var req dto.SetAdviceFeaturedRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	advice, err := services.SetAdviceFeatured(id, req.IsFeatured)
+	if err != nil {
+		return adviceError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Advice updated successfully", advice))
+}
+
+func (h *AdviceHandler) SetPublished(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid advice ID"))
+	}
+
+	var req dto.SetAdvicePublishedRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	advice, err := services.SetAdvicePublished(id, req.IsPublished)
+	if err != nil {
+		return adviceError(ctx, err)
+	}
+
+	message := "Advice unpublished successfully"
+	if req.IsPublished {
+		message = "Advice published successfully"
+	}
+	return ctx.JSON(utils.SuccessResponse(message, advice))
+}
+
+func parseAdviceFilters(ctx *fiber.Ctx) (dto.AdviceFilters, error) {
+	filters := dto.AdviceFilters{
+		AdviceType: ctx.Query("advice_type"),
+		Search:     ctx.Query("search"),
+		Page:       ctx.QueryInt("page", 1),
+		Limit:      ctx.QueryInt("limit", 20),
+	}
+	if filters.Page < 1 {
+		filters.Page = 1
+	}
+	if filters.Limit < 1 || filters.Limit > 100 {
+		filters.Limit = 20
+	}
+
+	for param, target := range map[string]**uuid.UUID{
+		"governate_id": &filters.GovernateID,
+		"wilayah_id":   &filters.WilayahID,
+		"place_id":     &filters.PlaceID,
+	} {
+		value := ctx.Query(param)
+		if value == "" {
+			continue
+		}
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return filters, fiber.NewError(http.StatusBadRequest, "Invalid "+param)
+		}
+		*target = &id
+	}
+
+	if value := ctx.Query("is_featured"); value != "" {
+		if featured, err := strconv.ParseBool(value); err == nil {
+			filters.IsFeatured = &featured
+		}
+	}
+
+	return filters, nil
+}
+
+func adviceError(ctx *fiber.Ctx, err error) error {
+	message := err.Error()
+	switch {
+	case strings.HasSuffix(message, "not found") && !strings.HasPrefix(message, "invalid"):
+		return ctx.Status(http.StatusNotFound).JSON(utils.ErrorResponse(message))
+	case strings.HasPrefix(message, "invalid"):
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(message))
+	default:
+		return ctx.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse(message))
+	}
+}
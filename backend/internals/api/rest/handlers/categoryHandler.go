@@ -282,6 +282,7 @@ func (h *CategoryHandler) UpdateCategory(ctx *fiber.Ctx) error {
 		cache.DeletePattern("places_category_*")
 		cache.DeletePattern("properties_category_*") // Properties by category
 		cache.DeletePattern("subcategories_*")
+		cache.DeletePattern("places_search_*") // Search matches category names
 	}()
 
 	return ctx.JSON(utils.SuccessResponse("Category updated successfully", category))
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -25,6 +26,8 @@ func SetupDishRoutes(app *fiber.App) {
 	dishes.Get("/", handler.GetDishes)
 	dishes.Get("/:id", handler.GetDish)
 	dishes.Get("/governate/:governateId", handler.GetDishesByGovernate)
+	dishes.Get("/:id/recipes", handler.GetDishRecipes)
+	dishes.Get("/:id/places", handler.GetDishPlaces)
 
 	// Protected routes with RBAC
 	dishes.Post("/",
@@ -42,6 +45,37 @@ func SetupDishRoutes(app *fiber.App) {
 		middleware.RequirePermission("can_delete_dish"),
 		handler.DeleteDish)
 
+	// Places serving the dish
+	dishes.Put("/:id/places/:placeId",
+		middleware.AuthRequiredWithRBAC,
+		middleware.RequirePermission("can_update_dish"),
+		handler.SaveDishPlace)
+
+	dishes.Delete("/:id/places/:placeId",
+		middleware.AuthRequiredWithRBAC,
+		middleware.RequirePermission("can_update_dish"),
+		handler.DeleteDishPlace)
+
+	// Dietary tag, allergen and ingredient vocabulary
+	dishTags := app.Group("/api/v1/dish-tags")
+
+	dishTags.Get("/", handler.GetDishTags)
+
+	dishTags.Post("/",
+		middleware.AuthRequiredWithRBAC,
+		middleware.RequirePermission("can_manage_dish"),
+		handler.CreateDishTag)
+
+	dishTags.Put("/:id",
+		middleware.AuthRequiredWithRBAC,
+		middleware.RequirePermission("can_manage_dish"),
+		handler.UpdateDishTag)
+
+	dishTags.Delete("/:id",
+		middleware.AuthRequiredWithRBAC,
+		middleware.RequirePermission("can_manage_dish"),
+		handler.DeleteDishTag)
+
 	// Dish image routes
 	dishImages := app.Group("/api/v1/dishes/:dishId/images")
 
@@ -69,6 +103,8 @@ func (h *DishHandler) GetDishes(c *fiber.Ctx) error {
 		Search:        c.Query("search"),
 		SortBy:        c.Query("sort_by"),
 		SortOrder:     c.Query("sort_order"),
+		IncludeTags:   splitQueryList(c.Query("include_tags")),
+		ExcludeTags:   splitQueryList(c.Query("exclude_tags")),
 	}
 
 	// Parse boolean filters
@@ -116,7 +152,21 @@ func (h *DishHandler) GetDishes(c *fiber.Ctx) error {
 // GetDish handles GET /api/v1/dishes/:id
 func (h *DishHandler) GetDish(c *fiber.Ctx) error {
 	dishID := c.Params("id")
-	lang := c.Query("lang", "en")
+	lang := c.Query("lang")
+
+	// Return localized response, with where to eat it, if language is specified
+	if lang == "ar" || lang == "en" {
+		dish, err := services.GetDishByIDLocalized(dishID, lang)
+		if err != nil {
+			return c.Status(http.StatusNotFound).JSON(utils.ErrorResponse(
+				"Dish not found: " + err.Error(),
+			))
+		}
+		return c.Status(http.StatusOK).JSON(utils.SuccessResponse(
+			"Dish retrieved successfully",
+			dish,
+		))
+	}
 
 	dish, err := services.GetDishByID(dishID)
 	if err != nil {
@@ -125,16 +175,6 @@ func (h *DishHandler) GetDish(c *fiber.Ctx) error {
 		))
 	}
 
-	// Return localized response if language is specified
-	if lang != "" && lang != "both" {
-		// Convert to domain object first (this would require a helper function)
-		// For now, return the regular response
-		return c.Status(http.StatusOK).JSON(utils.SuccessResponse(
-			"Dish retrieved successfully",
-			dish,
-		))
-	}
-
 	return c.Status(http.StatusOK).JSON(utils.SuccessResponse(
 		"Dish retrieved successfully",
 		dish,
@@ -175,6 +215,213 @@ func (h *DishHandler) GetDishesByGovernate(c *fiber.Ctx) error {
 	))
 }
 
+// GetDishRecipes handles GET /api/v1/dishes/:id/recipes
+func (h *DishHandler) GetDishRecipes(c *fiber.Ctx) error {
+	dishID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid dish ID"))
+	}
+
+	recipes, err := services.GetDishRecipes(dishID)
+	if err != nil {
+		if err.Error() == "dish not found" {
+			return c.Status(http.StatusNotFound).JSON(utils.ErrorResponse("Dish not found"))
+		}
+		return c.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse(
+			"Failed to retrieve recipes: " + err.Error(),
+		))
+	}
+
+	return c.Status(http.StatusOK).JSON(utils.SuccessResponse(
+		"Recipes retrieved successfully",
+		recipes,
+	))
+}
+
+// GetDishTags handles GET /api/v1/dish-tags, optionally ?type=dietary|allergen|ingredient
+func (h *DishHandler) GetDishTags(c *fiber.Ctx) error {
+	tags, err := services.GetDishTags(c.Query("type"))
+	if err != nil {
+		return c.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	return c.Status(http.StatusOK).JSON(utils.SuccessResponse(
+		"Dish tags retrieved successfully",
+		tags,
+	))
+}
+
+// CreateDishTag handles POST /api/v1/dish-tags
+func (h *DishHandler) CreateDishTag(c *fiber.Ctx) error {
+	var req dto.CreateDishTagRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(
+			"Invalid request format: " + err.Error(),
+		))
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Validation failed: " + err.Error()))
+	}
+
+	tag, err := services.CreateDishTag(req)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(
+			"Failed to create dish tag: " + err.Error(),
+		))
+	}
+
+	return c.Status(http.StatusCreated).JSON(utils.SuccessResponse(
+		"Dish tag created successfully",
+		tag,
+	))
+}
+
+// UpdateDishTag handles PUT /api/v1/dish-tags/:id
+func (h *DishHandler) UpdateDishTag(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid dish tag ID"))
+	}
+
+	var req dto.UpdateDishTagRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(
+			"Invalid request format: " + err.Error(),
+		))
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Validation failed: " + err.Error()))
+	}
+
+	tag, err := services.UpdateDishTag(id, req)
+	if err != nil {
+		if err.Error() == "dish tag not found" {
+			return c.Status(http.StatusNotFound).JSON(utils.ErrorResponse("Dish tag not found"))
+		}
+		return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(
+			"Failed to update dish tag: " + err.Error(),
+		))
+	}
+
+	return c.Status(http.StatusOK).JSON(utils.SuccessResponse(
+		"Dish tag updated successfully",
+		tag,
+	))
+}
+
+// DeleteDishTag handles DELETE /api/v1/dish-tags/:id
+func (h *DishHandler) DeleteDishTag(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid dish tag ID"))
+	}
+
+	if err := services.DeleteDishTag(id); err != nil {
+		if err.Error() == "dish tag not found" {
+			return c.Status(http.StatusNotFound).JSON(utils.ErrorResponse("Dish tag not found"))
+		}
+		return c.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse(
+			"Failed to delete dish tag: " + err.Error(),
+		))
+	}
+
+	return c.Status(http.StatusOK).JSON(utils.SuccessResponse(
+		"Dish tag deleted successfully",
+		nil,
+	))
+}
+
+// GetDishPlaces handles GET /api/v1/dishes/:id/places, closest first when lat and lng are given
+func (h *DishHandler) GetDishPlaces(c *fiber.Ctx) error {
+	dishID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid dish ID"))
+	}
+
+	var lat, lng *float64
+	if c.Query("lat") != "" || c.Query("lng") != "" {
+		latValue, latErr := strconv.ParseFloat(c.Query("lat"), 64)
+		lngValue, lngErr := strconv.ParseFloat(c.Query("lng"), 64)
+		if latErr != nil || lngErr != nil {
+			return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("lat and lng must both be valid numbers"))
+		}
+		lat, lng = &latValue, &lngValue
+	}
+
+	places, err := services.GetDishPlaces(dishID, lat, lng)
+	if err != nil {
+		return dishPlaceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(utils.SuccessResponse(
+		"Dish places retrieved successfully",
+		places,
+	))
+}
+
+// SaveDishPlace handles PUT /api/v1/dishes/:id/places/:placeId
+func (h *DishHandler) SaveDishPlace(c *fiber.Ctx) error {
+	dishID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid dish ID"))
+	}
+	placeID, err := uuid.Parse(c.Params("placeId"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid place ID"))
+	}
+
+	var req dto.SaveDishPlaceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(
+			"Invalid request format: " + err.Error(),
+		))
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Validation failed: " + err.Error()))
+	}
+
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(utils.ErrorResponse(
+			"Unauthorized: User not found in context",
+		))
+	}
+
+	link, err := services.SaveDishPlace(dishID, placeID, req, userID)
+	if err != nil {
+		return dishPlaceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(utils.SuccessResponse(
+		"Dish place saved successfully",
+		link,
+	))
+}
+
+// DeleteDishPlace handles DELETE /api/v1/dishes/:id/places/:placeId
+func (h *DishHandler) DeleteDishPlace(c *fiber.Ctx) error {
+	dishID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid dish ID"))
+	}
+	placeID, err := uuid.Parse(c.Params("placeId"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid place ID"))
+	}
+
+	if err := services.DeleteDishPlace(dishID, placeID); err != nil {
+		return dishPlaceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(utils.SuccessResponse(
+		"Dish place deleted successfully",
+		nil,
+	))
+}
+
 // CreateDish handles POST /api/v1/dishes
 func (h *DishHandler) CreateDish(c *fiber.Ctx) error {
 	var req dto.CreateDishRequest
@@ -390,4 +637,23 @@ func (h *DishHandler) DeleteDishImage(c *fiber.Ctx) error {
 		"Dish image deleted successfully",
 		nil,
 	))
-}
\ No newline at end of file
+}
+func dishPlaceError(c *fiber.Ctx, err error) error {
+	message := err.Error()
+	switch {
+	case strings.HasSuffix(message, "not found"):
+		return c.Status(http.StatusNotFound).JSON(utils.ErrorResponse(message))
+	case strings.HasPrefix(message, "invalid"):
+		return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(message))
+	default:
+		return c.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse(message))
+	}
+}
+
+// splitQueryList reads a comma separated query value such as ?exclude_tags=nuts,dairy
+func splitQueryList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
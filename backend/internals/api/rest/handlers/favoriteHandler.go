@@ -0,0 +1,256 @@
+// handlers/favoriteHandler.go
+package handlers
+
+import (
+	"almlah/internals/dto"
+	"almlah/internals/middleware"
+	"almlah/internals/services"
+	"almlah/internals/utils"
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type FavoriteHandler struct{}
+
+func SetupFavoriteRoutes(app *fiber.App) {
+	handler := FavoriteHandler{}
+
+	// Favorites are personal, every route needs a signed-in user
+	favorites := app.Group("/api/v1/favorites", middleware.AuthRequiredWithRBAC)
+
+	favorites.Get("/", handler.GetFavorites)
+	favorites.Post("/", handler.AddFavorite)
+	favorites.Post("/status", handler.GetFavoriteStatus)
+	favorites.Delete("/:placeId", handler.RemoveFavorite)
+
+	// Collections
+	favorites.Get("/collections", handler.GetCollections)
+	favorites.Post("/collections", handler.CreateCollection)
+	favorites.Put("/collections/:id", handler.UpdateCollection)
+	favorites.Delete("/collections/:id", handler.DeleteCollection)
+	favorites.Post("/collections/:id/places", handler.AddPlaceToCollection)
+	favorites.Delete("/collections/:id/places/:placeId", handler.RemovePlaceFromCollection)
+}
+
+// GetFavorites lists the caller's favorite places, optionally only those in ?collection_id=
+func (h *FavoriteHandler) GetFavorites(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	var collectionID *uuid.UUID
+	if value := ctx.Query("collection_id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid collection ID"))
+		}
+		collectionID = &id
+	}
+
+	page := ctx.QueryInt("page", 1)
+	if page < 1 {
+		page = 1
+	}
+	limit := ctx.QueryInt("limit", 20)
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	favorites, err := services.GetUserFavorites(userID, collectionID, page, limit)
+	if err != nil {
+		return favoriteError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Favorites retrieved successfully", favorites))
+}
+
+func (h *FavoriteHandler) AddFavorite(ctx *fiber.Ctx) error {
+	var req dto.AddFavoriteRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	favorite, err := services.AddFavorite(userID, req.PlaceID)
+	if err != nil {
+		return favoriteError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(utils.SuccessResponse("Place added to favorites", favorite))
+}
+
+func (h *FavoriteHandler) RemoveFavorite(ctx *fiber.Ctx) error {
+	placeID, err := uuid.Parse(ctx.Params("placeId"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid place ID"))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	if err := services.RemoveFavorite(userID, placeID); err != nil {
+		return favoriteError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Place removed from favorites", nil))
+}
+
+// GetFavoriteStatus tells which of a page of places the caller favorited
+func (h *FavoriteHandler) GetFavoriteStatus(ctx *fiber.Ctx) error {
+	var req dto.FavoriteStatusRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	status, err := services.GetFavoriteStatus(userID, req.PlaceIDs)
+	if err != nil {
+		return favoriteError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Favorite status retrieved successfully", status))
+}
+
+// Collection Handlers
+
+func (h *FavoriteHandler) GetCollections(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	collections, err := services.GetFavoriteCollections(userID)
+	if err != nil {
+		return favoriteError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Collections retrieved successfully", collections))
+}
+
+func (h *FavoriteHandler) CreateCollection(ctx *fiber.Ctx) error {
+	var req dto.CreateFavoriteCollectionRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	collection, err := services.CreateFavoriteCollection(userID, req)
+	if err != nil {
+		return favoriteError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(utils.SuccessResponse("Collection created successfully", collection))
+}
+
+func (h *FavoriteHandler) UpdateCollection(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid collection ID"))
+	}
+
+	var req dto.UpdateFavoriteCollectionRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	collection, err := services.UpdateFavoriteCollection(userID, id, req)
+	if err != nil {
+		return favoriteError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Collection updated successfully", collection))
+}
+
+// DeleteCollection removes a collection, its places stay in the caller's favorites
+func (h *FavoriteHandler) DeleteCollection(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid collection ID"))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	if err := services.DeleteFavoriteCollection(userID, id); err != nil {
+		return favoriteError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Collection deleted successfully", nil))
+}
+
+// AddPlaceToCollection adds a place to a collection, favoriting it if it wasn't yet
+func (h *FavoriteHandler) AddPlaceToCollection(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid collection ID"))
+	}
+
+	var req dto.AddCollectionPlaceRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	favorite, err := services.AddPlaceToCollection(userID, id, req.PlaceID)
+	if err != nil {
+		return favoriteError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(utils.SuccessResponse("Place added to collection", favorite))
+}
+
+// RemovePlaceFromCollection takes a place out of a collection without unfavoriting it
+func (h *FavoriteHandler) RemovePlaceFromCollection(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid collection ID"))
+	}
+	placeID, err := uuid.Parse(ctx.Params("placeId"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid place ID"))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	if err := services.RemovePlaceFromCollection(userID, id, placeID); err != nil {
+		return favoriteError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Place removed from collection", nil))
+}
+
+func favoriteError(ctx *fiber.Ctx, err error) error {
+	message := err.Error()
+	switch {
+	case strings.HasSuffix(message, "not found"), strings.HasSuffix(message, "not found in collection"):
+		return ctx.Status(http.StatusNotFound).JSON(utils.ErrorResponse(message))
+	case strings.HasPrefix(message, "you have already"):
+		return ctx.Status(http.StatusConflict).JSON(utils.ErrorResponse(message))
+	case strings.HasPrefix(message, "invalid"):
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(message))
+	default:
+		return ctx.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse(message))
+	}
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"almlah/internals/cache"
 	"almlah/internals/dto"
 	"almlah/internals/middleware"
 	"almlah/internals/services"
@@ -110,7 +111,8 @@ func (h *GovernateHandler) UpdateGovernate(ctx *fiber.Ctx) error {
 		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
 	}
 
-	// NO CACHING - Direct database access only
+	// NO CACHING - Direct database access only. Search results match governate names.
+	go cache.DeletePattern("places_search_*")
 
 	return ctx.JSON(utils.SuccessResponse("Governate updated successfully", governate))
 }
@@ -0,0 +1,429 @@
+// handlers/itineraryHandler.go
+package handlers
+
+import (
+	"almlah/internals/dto"
+	"almlah/internals/middleware"
+	"almlah/internals/services"
+	"almlah/internals/utils"
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ItineraryHandler struct{}
+
+func SetupItineraryRoutes(app *fiber.App) {
+	handler := ItineraryHandler{}
+
+	itineraries := app.Group("/api/v1/itineraries")
+
+	// Shared links are read-only and open to everyone
+	itineraries.Get("/shared/:token", middleware.OptionalAuth, handler.GetSharedItinerary)
+	itineraries.Post("/shared/:token/duplicate", middleware.AuthRequiredWithRBAC, handler.DuplicateSharedItinerary)
+
+	// Everything else is owner-only
+	itineraries.Get("/", middleware.AuthRequiredWithRBAC, handler.GetItineraries)
+	itineraries.Post("/", middleware.AuthRequiredWithRBAC, handler.CreateItinerary)
+	itineraries.Get("/:id", middleware.AuthRequiredWithRBAC, handler.GetItinerary)
+	itineraries.Put("/:id", middleware.AuthRequiredWithRBAC, handler.UpdateItinerary)
+	itineraries.Delete("/:id", middleware.AuthRequiredWithRBAC, handler.DeleteItinerary)
+	itineraries.Post("/:id/duplicate", middleware.AuthRequiredWithRBAC, handler.DuplicateItinerary)
+	itineraries.Post("/:id/share", middleware.AuthRequiredWithRBAC, handler.ShareItinerary)
+	itineraries.Delete("/:id/share", middleware.AuthRequiredWithRBAC, handler.UnshareItinerary)
+
+	// Days
+	itineraries.Post("/:id/days", middleware.AuthRequiredWithRBAC, handler.AddDay)
+	itineraries.Put("/:id/days/:dayId", middleware.AuthRequiredWithRBAC, handler.UpdateDay)
+	itineraries.Delete("/:id/days/:dayId", middleware.AuthRequiredWithRBAC, handler.DeleteDay)
+	itineraries.Put("/:id/days/:dayId/order", middleware.AuthRequiredWithRBAC, handler.ReorderStops)
+	itineraries.Post("/:id/days/:dayId/optimize", middleware.AuthRequiredWithRBAC, handler.OptimizeDay)
+
+	// Stops
+	itineraries.Post("/:id/days/:dayId/stops", middleware.AuthRequiredWithRBAC, handler.AddStop)
+	itineraries.Put("/:id/stops/:stopId", middleware.AuthRequiredWithRBAC, handler.UpdateStop)
+	itineraries.Delete("/:id/stops/:stopId", middleware.AuthRequiredWithRBAC, handler.DeleteStop)
+}
+
+func (h *ItineraryHandler) GetItineraries(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	page := ctx.QueryInt("page", 1)
+	if page < 1 {
+		page = 1
+	}
+	limit := ctx.QueryInt("limit", 20)
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	itineraries, err := services.GetUserItineraries(userID, page, limit)
+	if err != nil {
+		return itineraryError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Itineraries retrieved successfully", itineraries))
+}
+
+func (h *ItineraryHandler) CreateItinerary(ctx *fiber.Ctx) error {
+	var req dto.CreateItineraryRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	itinerary, err := services.CreateItinerary(userID, req)
+	if err != nil {
+		return itineraryError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(utils.SuccessResponse("Itinerary created successfully", itinerary))
+}
+
+func (h *ItineraryHandler) GetItinerary(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid itinerary ID"))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	itinerary, err := services.GetItinerary(id, userID)
+	if err != nil {
+		return itineraryError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Itinerary retrieved successfully", itinerary))
+}
+
+// GetSharedItinerary shows an itinerary through its share link, without editing rights
+func (h *ItineraryHandler) GetSharedItinerary(ctx *fiber.Ctx) error {
+	viewerID, _ := ctx.Locals("userID").(uuid.UUID)
+
+	itinerary, err := services.GetSharedItinerary(ctx.Params("token"), viewerID)
+	if err != nil {
+		return itineraryError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Itinerary retrieved successfully", itinerary))
+}
+
+func (h *ItineraryHandler) UpdateItinerary(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid itinerary ID"))
+	}
+
+	var req dto.UpdateItineraryRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	itinerary, err := services.UpdateItinerary(id, userID, req)
+	if err != nil {
+		return itineraryError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Itinerary updated successfully", itinerary))
+}
+
+func (h *ItineraryHandler) DeleteItinerary(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid itinerary ID"))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	if err := services.DeleteItinerary(id, userID); err != nil {
+		return itineraryError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Itinerary deleted successfully", nil))
+}
+
+func (h *ItineraryHandler) DuplicateItinerary(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid itinerary ID"))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	itinerary, err := services.DuplicateItinerary(id, userID)
+	if err != nil {
+		return itineraryError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(utils.SuccessResponse("Itinerary duplicated successfully", itinerary))
+}
+
+// DuplicateSharedItinerary copies an itinerary shared with the caller into their own
+func (h *ItineraryHandler) DuplicateSharedItinerary(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	itinerary, err := services.DuplicateSharedItinerary(ctx.Params("token"), userID)
+	if err != nil {
+		return itineraryError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(utils.SuccessResponse("Itinerary duplicated successfully", itinerary))
+}
+
+func (h *ItineraryHandler) ShareItinerary(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid itinerary ID"))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	itinerary, err := services.ShareItinerary(id, userID)
+	if err != nil {
+		return itineraryError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Share link created successfully", itinerary))
+}
+
+func (h *ItineraryHandler) UnshareItinerary(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid itinerary ID"))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	itinerary, err := services.UnshareItinerary(id, userID)
+	if err != nil {
+		return itineraryError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Share link revoked successfully", itinerary))
+}
+
+// Day Handlers
+
+func (h *ItineraryHandler) AddDay(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid itinerary ID"))
+	}
+
+	var req dto.ItineraryDayRequest
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(&req); err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+		}
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	itinerary, err := services.AddItineraryDay(id, userID, req)
+	if err != nil {
+		return itineraryError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(utils.SuccessResponse("Day added successfully", itinerary))
+}
+
+func (h *ItineraryHandler) UpdateDay(ctx *fiber.Ctx) error {
+	id, dayID, err := parseItineraryDayParams(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	var req dto.ItineraryDayRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	itinerary, err := services.UpdateItineraryDay(id, dayID, userID, req)
+	if err != nil {
+		return itineraryError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Day updated successfully", itinerary))
+}
+
+func (h *ItineraryHandler) DeleteDay(ctx *fiber.Ctx) error {
+	id, dayID, err := parseItineraryDayParams(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	itinerary, err := services.DeleteItineraryDay(id, dayID, userID)
+	if err != nil {
+		return itineraryError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Day deleted successfully", itinerary))
+}
+
+// ReorderStops sets the visiting order of a day's stops
+func (h *ItineraryHandler) ReorderStops(ctx *fiber.Ctx) error {
+	id, dayID, err := parseItineraryDayParams(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	var req dto.ReorderItineraryStopsRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	itinerary, err := services.ReorderItineraryStops(id, dayID, userID, req)
+	if err != nil {
+		return itineraryError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Stops reordered successfully", itinerary))
+}
+
+// OptimizeDay reorders a day's places for the shortest drive
+func (h *ItineraryHandler) OptimizeDay(ctx *fiber.Ctx) error {
+	id, dayID, err := parseItineraryDayParams(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	itinerary, err := services.OptimizeItineraryDay(id, dayID, userID)
+	if err != nil {
+		return itineraryError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Day optimized successfully", itinerary))
+}
+
+// Stop Handlers
+
+func (h *ItineraryHandler) AddStop(ctx *fiber.Ctx) error {
+	id, dayID, err := parseItineraryDayParams(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	var req dto.CreateItineraryStopRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	itinerary, err := services.AddItineraryStop(id, dayID, userID, req)
+	if err != nil {
+		return itineraryError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(utils.SuccessResponse("Stop added successfully", itinerary))
+}
+
+func (h *ItineraryHandler) UpdateStop(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid itinerary ID"))
+	}
+	stopID, err := uuid.Parse(ctx.Params("stopId"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid stop ID"))
+	}
+
+	var req dto.UpdateItineraryStopRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	itinerary, err := services.UpdateItineraryStop(id, stopID, userID, req)
+	if err != nil {
+		return itineraryError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Stop updated successfully", itinerary))
+}
+
+func (h *ItineraryHandler) DeleteStop(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid itinerary ID"))
+	}
+	stopID, err := uuid.Parse(ctx.Params("stopId"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid stop ID"))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	itinerary, err := services.DeleteItineraryStop(id, stopID, userID)
+	if err != nil {
+		return itineraryError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Stop deleted successfully", itinerary))
+}
+
+func parseItineraryDayParams(ctx *fiber.Ctx) (uuid.UUID, uuid.UUID, error) {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fiber.NewError(http.StatusBadRequest, "Invalid itinerary ID")
+	}
+	dayID, err := uuid.Parse(ctx.Params("dayId"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fiber.NewError(http.StatusBadRequest, "Invalid day ID")
+	}
+	return id, dayID, nil
+}
+
+func itineraryError(ctx *fiber.Ctx, err error) error {
+	message := err.Error()
+	switch {
+	case strings.HasSuffix(message, "not found"):
+		return ctx.Status(http.StatusNotFound).JSON(utils.ErrorResponse(message))
+	case strings.HasPrefix(message, "invalid"):
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(message))
+	default:
+		return ctx.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse(message))
+	}
+}
@@ -1,12 +1,15 @@
 package handlers
 
 import (
+	"almlah/internals/cache"
+	"almlah/internals/domain"
 	"almlah/internals/dto"
 	"almlah/internals/middleware"
 	"almlah/internals/services"
 	"almlah/internals/utils"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -23,13 +26,66 @@ func SetupListRoutes(app *fiber.App) {
 		listSectionService: services.NewListSectionService(),
 	}
 
+	// Public list responses are cached, any successful change drops them. Editors and
+	// preview links see drafts, their responses are never cached.
+	listCache := middleware.CacheMiddleware(middleware.CacheConfig{
+		TTL: cache.ShortTTL,
+		KeyGenerator: func(c *fiber.Ctx) string {
+			return services.ListCacheKeyPrefix + c.OriginalURL()
+		},
+		Skip: func(c *fiber.Ctx) bool {
+			return canSeeDraftLists(c) || c.Query("preview_token") != ""
+		},
+	})
+	loadUser := middleware.LoadUserWithPermissions()
+
 	// List routes
-	lists := app.Group("/api/v1/lists")
+	lists := app.Group("/api/v1/lists", invalidateListCachesOnChange)
+
+	// Upcoming scheduled publish/unpublish changes
+	lists.Get("/scheduled",
+		middleware.AuthRequiredWithRBAC,
+		middleware.RequirePermission("can_update_list"),
+		handler.GetScheduledListChanges)
+
+	// List templates, registered before /:id so "templates" isn't taken for a list ID
+	templates := lists.Group("/templates")
+
+	templates.Get("/",
+		middleware.AuthRequiredWithRBAC,
+		middleware.RequirePermission("can_create_list"),
+		handler.GetListTemplates)
+
+	templates.Get("/:templateId",
+		middleware.AuthRequiredWithRBAC,
+		middleware.RequirePermission("can_create_list"),
+		handler.GetListTemplate)
+
+	templates.Post("/",
+		middleware.AuthRequiredWithRBAC,
+		middleware.RequirePermission("can_create_list"),
+		handler.CreateListTemplate)
+
+	templates.Put("/:templateId",
+		middleware.AuthRequiredWithRBAC,
+		middleware.RequirePermission("can_update_list"),
+		handler.UpdateListTemplate)
+
+	templates.Delete("/:templateId",
+		middleware.AuthRequiredWithRBAC,
+		middleware.RequirePermission("can_delete_list"),
+		handler.DeleteListTemplate)
+
+	templates.Post("/:templateId/lists",
+		middleware.AuthRequiredWithRBAC,
+		middleware.RequirePermission("can_create_list"),
+		handler.CreateListFromTemplate)
 
 	// Public routes
-	lists.Get("/", handler.GetLists)
-	lists.Get("/slug/:slug", handler.GetListBySlug)
-	lists.Get("/:id", handler.GetListByID)
+	lists.Get("/", middleware.OptionalAuth, loadUser, listCache, handler.GetLists)
+	lists.Get("/slug/:slug", middleware.OptionalAuth, loadUser, listCache, handler.GetListBySlug)
+	lists.Get("/:id", middleware.OptionalAuth, loadUser, listCache, handler.GetListByID)
+	lists.Get("/:id/export", middleware.OptionalAuth, loadUser, handler.ExportList)
 
 	// Protected admin routes
 	lists.Post("/",
@@ -47,6 +103,21 @@ func SetupListRoutes(app *fiber.App) {
 		middleware.RequirePermission("can_delete_list"),
 		handler.DeleteList)
 
+	lists.Put("/:id/schedule",
+		middleware.AuthRequiredWithRBAC,
+		middleware.RequirePermission("can_update_list"),
+		handler.SetListSchedule)
+
+	lists.Post("/:id/duplicate",
+		middleware.AuthRequiredWithRBAC,
+		middleware.RequirePermission("can_create_list"),
+		handler.DuplicateList)
+
+	lists.Post("/:id/template",
+		middleware.AuthRequiredWithRBAC,
+		middleware.RequirePermission("can_create_list"),
+		handler.SaveListAsTemplate)
+
 	lists.Put("/reorder",
 		middleware.AuthRequiredWithRBAC,
 		middleware.RequirePermission("can_update_list"),
@@ -55,7 +126,7 @@ func SetupListRoutes(app *fiber.App) {
 	// List sections routes
 	listSections := lists.Group("/:listId/sections")
 	
-	listSections.Get("/", handler.GetListSections)
+	listSections.Get("/", middleware.OptionalAuth, loadUser, listCache, handler.GetListSections)
 	
 	listSections.Post("/",
 		middleware.AuthRequiredWithRBAC,
@@ -107,6 +178,11 @@ func (h *ListHandler) GetLists(c *fiber.Ctx) error {
 	limit, _ := strconv.Atoi(c.Query("limit", "10"))
 	status := c.Query("status")
 
+	// Only editors see lists that aren't published
+	if !canSeeDraftLists(c) {
+		status = domain.ListStatusPublished
+	}
+
 	if page < 1 {
 		page = 1
 	}
@@ -145,6 +221,10 @@ func (h *ListHandler) GetListBySlug(c *fiber.Ctx) error {
 		return c.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse("Failed to fetch list"))
 	}
 
+	if !canViewList(c, list.ID, list.Status) {
+		return c.Status(http.StatusNotFound).JSON(utils.ErrorResponse("List not found"))
+	}
+
 	return c.JSON(utils.SuccessResponse("List retrieved successfully", list))
 }
 
@@ -163,6 +243,10 @@ func (h *ListHandler) GetListByID(c *fiber.Ctx) error {
 		return c.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse("Failed to fetch list"))
 	}
 
+	if !canViewList(c, list.ID, list.Status) {
+		return c.Status(http.StatusNotFound).JSON(utils.ErrorResponse("List not found"))
+	}
+
 	return c.JSON(utils.SuccessResponse("List retrieved successfully", list))
 }
 
@@ -184,6 +268,9 @@ func (h *ListHandler) CreateList(c *fiber.Ctx) error {
 		if err.Error() == "slug already exists" {
 			return c.Status(http.StatusConflict).JSON(utils.ErrorResponse("Slug already exists"))
 		}
+		if strings.HasPrefix(err.Error(), "invalid") {
+			return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+		}
 		return c.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse("Failed to create list"))
 	}
 
@@ -237,6 +324,205 @@ func (h *ListHandler) DeleteList(c *fiber.Ctx) error {
 	return c.JSON(utils.SuccessResponse("List deleted successfully", nil))
 }
 
+// SetListSchedule replaces the times the list is automatically published and archived
+func (h *ListHandler) SetListSchedule(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid ID format"))
+	}
+
+	var req dto.SetListScheduleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	list, err := h.listService.SetListSchedule(id, req)
+	if err != nil {
+		if err.Error() == "list not found" {
+			return c.Status(http.StatusNotFound).JSON(utils.ErrorResponse("List not found"))
+		}
+		if strings.HasPrefix(err.Error(), "invalid") {
+			return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+		}
+		return c.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse("Failed to update list schedule"))
+	}
+
+	return c.JSON(utils.SuccessResponse("List schedule updated successfully", list))
+}
+
+// GetScheduledListChanges shows the upcoming scheduled publish and unpublish changes
+func (h *ListHandler) GetScheduledListChanges(c *fiber.Ctx) error {
+	changes, err := h.listService.GetScheduledListChanges()
+	if err != nil {
+		return c.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse("Failed to fetch scheduled list changes"))
+	}
+
+	return c.JSON(utils.SuccessResponse("Scheduled list changes retrieved successfully", changes))
+}
+
+// DuplicateList copies a list with its sections, items and images into a new draft
+func (h *ListHandler) DuplicateList(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid ID format"))
+	}
+
+	// The body is optional, without one the copy keeps the titles and gets a "-copy" slug
+	var req dto.DuplicateListRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+		}
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	list, err := h.listService.DuplicateList(id, req, userID)
+	if err != nil {
+		if err.Error() == "list not found" {
+			return c.Status(http.StatusNotFound).JSON(utils.ErrorResponse("List not found"))
+		}
+		if err.Error() == "slug already exists" {
+			return c.Status(http.StatusConflict).JSON(utils.ErrorResponse("Slug already exists"))
+		}
+		return c.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse("Failed to duplicate list"))
+	}
+
+	return c.JSON(utils.SuccessResponse("List duplicated successfully", list))
+}
+
+// SaveListAsTemplate makes a reusable template from the sections of a list
+func (h *ListHandler) SaveListAsTemplate(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid ID format"))
+	}
+
+	var req dto.SaveListAsTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Validation failed"))
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	template, err := h.listService.SaveListAsTemplate(id, req, userID)
+	if err != nil {
+		return listTemplateError(c, err, "Failed to save list as template")
+	}
+
+	return c.JSON(utils.SuccessResponse("List template created successfully", template))
+}
+
+// List Template Handlers
+func (h *ListHandler) GetListTemplates(c *fiber.Ctx) error {
+	templates, err := h.listService.GetListTemplates()
+	if err != nil {
+		return c.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse("Failed to fetch list templates"))
+	}
+
+	return c.JSON(utils.SuccessResponse("List templates retrieved successfully", templates))
+}
+
+func (h *ListHandler) GetListTemplate(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("templateId"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid template ID format"))
+	}
+
+	template, err := h.listService.GetListTemplateByID(id)
+	if err != nil {
+		return listTemplateError(c, err, "Failed to fetch list template")
+	}
+
+	return c.JSON(utils.SuccessResponse("List template retrieved successfully", template))
+}
+
+func (h *ListHandler) CreateListTemplate(c *fiber.Ctx) error {
+	var req dto.CreateListTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Validation failed"))
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	template, err := h.listService.CreateListTemplate(req, userID)
+	if err != nil {
+		return listTemplateError(c, err, "Failed to create list template")
+	}
+
+	return c.JSON(utils.SuccessResponse("List template created successfully", template))
+}
+
+func (h *ListHandler) UpdateListTemplate(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("templateId"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid template ID format"))
+	}
+
+	var req dto.UpdateListTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Validation failed"))
+	}
+
+	template, err := h.listService.UpdateListTemplate(id, req)
+	if err != nil {
+		return listTemplateError(c, err, "Failed to update list template")
+	}
+
+	return c.JSON(utils.SuccessResponse("List template updated successfully", template))
+}
+
+func (h *ListHandler) DeleteListTemplate(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("templateId"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid template ID format"))
+	}
+
+	if err := h.listService.DeleteListTemplate(id); err != nil {
+		return listTemplateError(c, err, "Failed to delete list template")
+	}
+
+	return c.JSON(utils.SuccessResponse("List template deleted successfully", nil))
+}
+
+// CreateListFromTemplate starts a new draft list with the sections of a template
+func (h *ListHandler) CreateListFromTemplate(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("templateId"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid template ID format"))
+	}
+
+	var req dto.CreateListFromTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Validation failed"))
+	}
+
+	userID := c.Locals("userID").(uuid.UUID)
+
+	list, err := h.listService.CreateListFromTemplate(id, req, userID)
+	if err != nil {
+		return listTemplateError(c, err, "Failed to create list from template")
+	}
+
+	return c.JSON(utils.SuccessResponse("List created successfully", list))
+}
+
 func (h *ListHandler) ReorderLists(c *fiber.Ctx) error {
 	var req dto.ReorderListsRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -354,6 +640,11 @@ func (h *ListHandler) GetListSections(c *fiber.Ctx) error {
 		return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid list ID format"))
 	}
 
+	status, err := h.listService.GetListStatus(listID)
+	if err != nil || !canViewList(c, listID, status) {
+		return c.Status(http.StatusNotFound).JSON(utils.ErrorResponse("List not found"))
+	}
+
 	sections, err := h.listSectionService.GetListSections(listID)
 	if err != nil {
 		return c.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse("Failed to fetch sections"))
@@ -384,7 +675,7 @@ func (h *ListHandler) CreateListSection(c *fiber.Ctx) error {
 		return c.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse("Failed to create section"))
 	}
 
-	return c.Status(http.StatusCreated).JSON(utils.SuccessResponse("Section created successfully", section))
+	return c.JSON(utils.SuccessResponse("Section created successfully", section))
 }
 
 func (h *ListHandler) UpdateListSection(c *fiber.Ctx) error {
@@ -461,4 +752,46 @@ func (h *ListHandler) ReorderListSections(c *fiber.Ctx) error {
 	}
 
 	return c.JSON(utils.SuccessResponse("Sections reordered successfully", nil))
-}
\ No newline at end of file
+}
+// invalidateListCachesOnChange drops the cached public list responses after every
+// successful change made through the list routes
+func invalidateListCachesOnChange(c *fiber.Ctx) error {
+	if err := c.Next(); err != nil {
+		return err
+	}
+	if c.Method() != fiber.MethodGet && c.Response().StatusCode() < 400 {
+		services.InvalidateListCaches()
+	}
+	return nil
+}
+
+// canSeeDraftLists reports whether the signed in caller may see lists that aren't published
+func canSeeDraftLists(c *fiber.Ctx) bool {
+	user, ok := middleware.GetUserFromContext(c)
+	return ok && services.CanUserEditLists(user)
+}
+
+// canViewList allows published lists to everyone, and other lists to editors and to
+// preview links for that list
+func canViewList(c *fiber.Ctx, listID uuid.UUID, status string) bool {
+	if status == domain.ListStatusPublished || canSeeDraftLists(c) {
+		return true
+	}
+	if services.IsValidPreviewToken(c.Query("preview_token"), domain.PreviewEntityList, listID) {
+		c.Set(fiber.HeaderCacheControl, "no-store")
+		return true
+	}
+	return false
+}
+
+// listTemplateError maps list template service errors to HTTP statuses
+func listTemplateError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case err.Error() == "slug already exists":
+		return c.Status(http.StatusConflict).JSON(utils.ErrorResponse("Slug already exists"))
+	case strings.HasSuffix(err.Error(), "not found"):
+		return c.Status(http.StatusNotFound).JSON(utils.ErrorResponse(err.Error()))
+	default:
+		return c.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse(fallback))
+	}
+}
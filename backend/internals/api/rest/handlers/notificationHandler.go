@@ -0,0 +1,80 @@
+// handlers/notificationHandler.go
+package handlers
+
+import (
+	"almlah/internals/middleware"
+	"almlah/internals/services"
+	"almlah/internals/utils"
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type NotificationHandler struct{}
+
+func SetupNotificationRoutes(app *fiber.App) {
+	handler := NotificationHandler{}
+
+	// Notifications are personal, every route needs a signed-in user
+	notifications := app.Group("/api/v1/notifications", middleware.AuthRequiredWithRBAC)
+
+	notifications.Get("/", handler.GetNotifications)
+	notifications.Post("/read", handler.MarkAllRead)
+	notifications.Post("/:id/read", handler.MarkRead)
+}
+
+// GetNotifications lists the caller's notifications, only unread ones with ?unread=true
+func (h *NotificationHandler) GetNotifications(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	page := ctx.QueryInt("page", 1)
+	if page < 1 {
+		page = 1
+	}
+	limit := ctx.QueryInt("limit", 20)
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	notifications, err := services.GetUserNotifications(userID, ctx.QueryBool("unread"), page, limit)
+	if err != nil {
+		return notificationError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Notifications retrieved successfully", notifications))
+}
+
+func (h *NotificationHandler) MarkRead(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid notification ID"))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	if err := services.MarkNotificationRead(userID, id); err != nil {
+		return notificationError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Notification marked as read", nil))
+}
+
+func (h *NotificationHandler) MarkAllRead(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	if err := services.MarkAllNotificationsRead(userID); err != nil {
+		return notificationError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Notifications marked as read", nil))
+}
+
+func notificationError(ctx *fiber.Ctx, err error) error {
+	message := err.Error()
+	if strings.HasSuffix(message, "not found") {
+		return ctx.Status(http.StatusNotFound).JSON(utils.ErrorResponse(message))
+	}
+	return ctx.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse(message))
+}
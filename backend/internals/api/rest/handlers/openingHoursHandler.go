@@ -0,0 +1,184 @@
+// handlers/openingHoursHandler.go
+package handlers
+
+import (
+	"almlah/internals/dto"
+	"almlah/internals/middleware"
+	"almlah/internals/services"
+	"almlah/internals/utils"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type OpeningHoursHandler struct{}
+
+func SetupOpeningHoursRoutes(app *fiber.App) {
+	handler := OpeningHoursHandler{}
+
+	hours := app.Group("/api/v1/places/:placeId/hours")
+
+	// Public routes
+	hours.Get("/", handler.GetOpeningHours)
+
+	// Protected routes - place owner, admins or can_manage_place
+	hours.Put("/",
+		middleware.AuthRequiredWithRBAC,
+		middleware.LoadUserWithPermissions(),
+		handler.UpdateOpeningHours)
+
+	hours.Post("/exceptions",
+		middleware.AuthRequiredWithRBAC,
+		middleware.LoadUserWithPermissions(),
+		handler.CreateException)
+
+	hours.Put("/exceptions/:exceptionId",
+		middleware.AuthRequiredWithRBAC,
+		middleware.LoadUserWithPermissions(),
+		handler.UpdateException)
+
+	hours.Delete("/exceptions/:exceptionId",
+		middleware.AuthRequiredWithRBAC,
+		middleware.LoadUserWithPermissions(),
+		handler.DeleteException)
+}
+
+// GetOpeningHours returns the schedule of a place; ?open_at= evaluates the status at another time
+func (h *OpeningHoursHandler) GetOpeningHours(ctx *fiber.Ctx) error {
+	placeID, err := uuid.Parse(ctx.Params("placeId"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid place ID"))
+	}
+
+	at := time.Now()
+	openAt, err := parseOpenAtFilter(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+	if openAt != nil {
+		at = *openAt
+	}
+
+	hours, err := services.GetPlaceOpeningHours(placeID, at)
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Opening hours retrieved successfully", hours))
+}
+
+func (h *OpeningHoursHandler) UpdateOpeningHours(ctx *fiber.Ctx) error {
+	placeID, err := uuid.Parse(ctx.Params("placeId"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid place ID"))
+	}
+
+	var req dto.UpdateOpeningHoursRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	hours, err := services.UpdatePlaceOpeningHours(placeID, req, userID)
+	if err != nil {
+		return openingHoursError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Opening hours updated successfully", hours))
+}
+
+func (h *OpeningHoursHandler) CreateException(ctx *fiber.Ctx) error {
+	placeID, err := uuid.Parse(ctx.Params("placeId"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid place ID"))
+	}
+
+	var req dto.HoursExceptionRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	exception, err := services.CreatePlaceHoursException(placeID, req, userID)
+	if err != nil {
+		return openingHoursError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(utils.SuccessResponse("Opening hours exception created successfully", exception))
+}
+
+func (h *OpeningHoursHandler) UpdateException(ctx *fiber.Ctx) error {
+	placeID, err := uuid.Parse(ctx.Params("placeId"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid place ID"))
+	}
+
+	exceptionID, err := uuid.Parse(ctx.Params("exceptionId"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid exception ID"))
+	}
+
+	var req dto.HoursExceptionRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	exception, err := services.UpdatePlaceHoursException(placeID, exceptionID, req, userID)
+	if err != nil {
+		return openingHoursError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Opening hours exception updated successfully", exception))
+}
+
+func (h *OpeningHoursHandler) DeleteException(ctx *fiber.Ctx) error {
+	placeID, err := uuid.Parse(ctx.Params("placeId"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid place ID"))
+	}
+
+	exceptionID, err := uuid.Parse(ctx.Params("exceptionId"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid exception ID"))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	if err := services.DeletePlaceHoursException(placeID, exceptionID, userID); err != nil {
+		return openingHoursError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Opening hours exception deleted successfully", nil))
+}
+
+// openingHoursError maps service errors to HTTP status codes
+func openingHoursError(ctx *fiber.Ctx, err error) error {
+	message := err.Error()
+	switch {
+	case strings.HasPrefix(message, "unauthorized"):
+		return ctx.Status(http.StatusForbidden).JSON(utils.ErrorResponse(message))
+	case strings.HasSuffix(message, "not found"):
+		return ctx.Status(http.StatusNotFound).JSON(utils.ErrorResponse(message))
+	default:
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(message))
+	}
+}
@@ -0,0 +1,86 @@
+// handlers/placeExportHandler.go
+package handlers
+
+import (
+	"almlah/internals/services"
+	"almlah/internals/utils"
+	"bufio"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// ExportPlaces streams active places as GeoJSON or KML (?format=geojson|kml), optionally
+// limited to ?governate_id= or ?wilayah_id=
+func (h *PlaceHandler) ExportPlaces(ctx *fiber.Ctx) error {
+	var scope services.PlaceExportScope
+
+	if value := ctx.Query("governate_id"); value != "" {
+		governateID, err := uuid.Parse(value)
+		if err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid governate ID"))
+		}
+		scope.GovernateID = &governateID
+	}
+	if value := ctx.Query("wilayah_id"); value != "" {
+		wilayahID, err := uuid.Parse(value)
+		if err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid wilayah ID"))
+		}
+		scope.WilayahID = &wilayahID
+	}
+
+	return streamPlaceExport(ctx, scope, "places")
+}
+
+// ExportList streams the places of a list as GeoJSON or KML, to anyone who may view the list
+func (h *ListHandler) ExportList(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid ID format"))
+	}
+
+	status, err := h.listService.GetListStatus(id)
+	if err != nil || !canViewList(c, id, status) {
+		return c.Status(http.StatusNotFound).JSON(utils.ErrorResponse("List not found"))
+	}
+
+	return streamPlaceExport(c, services.PlaceExportScope{ListID: &id}, "list-"+id.String())
+}
+
+// streamPlaceExport validates the export up front, then writes the document to the client
+// as it is read from the database
+func streamPlaceExport(ctx *fiber.Ctx, scope services.PlaceExportScope, filename string) error {
+	format := strings.ToLower(ctx.Query("format", services.ExportFormatGeoJSON))
+	var contentType string
+	switch format {
+	case services.ExportFormatGeoJSON:
+		contentType = "application/geo+json"
+	case services.ExportFormatKML:
+		contentType = "application/vnd.google-earth.kml+xml"
+	default:
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Format must be geojson or kml"))
+	}
+
+	title, err := services.PlaceExportTitle(scope)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "not found") {
+			return ctx.Status(http.StatusNotFound).JSON(utils.ErrorResponse(err.Error()))
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	ctx.Set(fiber.HeaderContentType, contentType)
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s.%s"`, filename, format))
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := services.ExportPlaces(w, scope, format, title); err != nil {
+			fmt.Printf("⚠️ Failed to export places: %v\n", err)
+		}
+		w.Flush()
+	})
+
+	return nil
+}
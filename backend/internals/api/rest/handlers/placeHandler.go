@@ -3,14 +3,21 @@ package handlers
 
 import (
 	"almlah/internals/cache"
+	"almlah/internals/domain"
 	"almlah/internals/dto"
 	"almlah/internals/middleware"
 	"almlah/internals/services"
 	"almlah/internals/utils"
+	"crypto/md5"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -25,14 +32,21 @@ func SetupPlaceRoutes(app *fiber.App) {
 	places := app.Group("/api/v1/places")
 
 	// Public routes
-	places.Get("/", handler.GetPlaces)
-	places.Get("/search", handler.SearchPlaces)
+	places.Get("/", middleware.OptionalAuth, handler.GetPlaces)
+	places.Get("/search", middleware.OptionalAuth, handler.SearchPlaces)
+	places.Get("/nearby", middleware.OptionalAuth, handler.GetNearbyPlaces)
+	places.Post("/query", middleware.OptionalAuth, middleware.LoadUserWithPermissions(), handler.QueryPlaces)
+	places.Get("/export", handler.ExportPlaces)
+	places.Get("/slug/:slug", handler.GetPlaceBySlug)
 	places.Get("/:id", handler.GetPlace)
 	places.Get("/:id/complete", handler.GetPlaceComplete) // NEW: Complete endpoint
-	places.Get("/category/:categoryId", handler.GetPlacesByCategory)
-	places.Get("/governate/:governateId", handler.GetPlacesByGovernate)
-	places.Get("/wilayah/:wilayahId", handler.GetPlacesByWilayah)
-	places.Get("/filter/:category_id?/:governate_id?", handler.GetPlacesByFilters)
+	places.Get("/:id/ratings", handler.GetPlaceRatings)
+	places.Get("/:id/photos", handler.GetPlacePhotos)
+	places.Get("/:id/dishes", handler.GetPlaceDishes)
+	places.Get("/category/:categoryId", middleware.OptionalAuth, handler.GetPlacesByCategory)
+	places.Get("/governate/:governateId", middleware.OptionalAuth, handler.GetPlacesByGovernate)
+	places.Get("/wilayah/:wilayahId", middleware.OptionalAuth, handler.GetPlacesByWilayah)
+	places.Get("/filter/:category_id?/:governate_id?", middleware.OptionalAuth, handler.GetPlacesByFilters)
 	
 	// Protected routes with RBAC
 	places.Post("/", 
@@ -40,6 +54,16 @@ func SetupPlaceRoutes(app *fiber.App) {
 		middleware.RequirePermission("can_create_place"), 
 		handler.CreatePlace)
 	
+	places.Post("/search/reindex",
+		middleware.AuthRequiredWithRBAC,
+		middleware.RequirePermission("can_manage_place"),
+		handler.ReindexSearch)
+
+	places.Post("/import",
+		middleware.AuthRequiredWithRBAC,
+		middleware.RequirePermission("can_manage_place"),
+		handler.ImportPlaces)
+
 	places.Put("/:id", 
 		middleware.AuthRequiredWithRBAC, 
 		middleware.LoadUserWithPermissions(), 
@@ -50,6 +74,57 @@ func SetupPlaceRoutes(app *fiber.App) {
 		middleware.LoadUserWithPermissions(), 
 		handler.DeletePlace)
 
+	// Moderation workflow
+	places.Get("/moderation/queue",
+		middleware.AuthRequiredWithRBAC,
+		middleware.RequirePermission("can_moderate_place"),
+		handler.GetModerationQueue)
+
+	places.Get("/:id/moderation-history",
+		middleware.AuthRequiredWithRBAC,
+		handler.GetModerationHistory)
+
+	places.Post("/:id/submit",
+		middleware.AuthRequiredWithRBAC,
+		middleware.LoadUserWithPermissions(),
+		handler.SubmitPlace)
+
+	places.Post("/:id/approve",
+		middleware.AuthRequiredWithRBAC,
+		middleware.RequirePermission("can_moderate_place"),
+		handler.ApprovePlace)
+
+	places.Post("/:id/reject",
+		middleware.AuthRequiredWithRBAC,
+		middleware.RequirePermission("can_moderate_place"),
+		handler.RejectPlace)
+
+	// Permissions granted on a single place
+	places.Get("/:id/permissions",
+		middleware.AuthRequiredWithRBAC,
+		middleware.RequirePermission("can_manage_place"),
+		handler.GetPlacePermissions)
+
+	places.Post("/:id/permissions",
+		middleware.AuthRequiredWithRBAC,
+		middleware.RequirePermission("can_manage_place"),
+		handler.GrantPlacePermission)
+
+	places.Delete("/:id/permissions/:userId",
+		middleware.AuthRequiredWithRBAC,
+		middleware.RequirePermission("can_manage_place"),
+		handler.RevokePlacePermission)
+
+	places.Post("/:id/merge",
+		middleware.AuthRequiredWithRBAC,
+		middleware.RequirePermission("can_manage_place"),
+		handler.MergePlace)
+
+	places.Post("/:id/archive",
+		middleware.AuthRequiredWithRBAC,
+		middleware.RequirePermission("can_moderate_place"),
+		handler.ArchivePlace)
+
 	// Content section routes with RBAC
 	contentSections := places.Group("/:placeId/content-sections", middleware.AuthRequiredWithRBAC)
 	contentSections.Post("/", 
@@ -78,19 +153,23 @@ func (h *PlaceHandler) GetPlaceComplete(ctx *fiber.Ctx) error {
 	
 	if err := cache.Get(cacheKey, &place); err == nil {
 		ctx.Set("X-Cache", "HIT")
+		place.OpeningHours = currentOpeningHours(id)
 		return ctx.JSON(utils.SuccessResponse("Place retrieved successfully", place))
 	}
 
 	// 🔄 Get complete place data from service (both languages)
 	placePtr, err := services.GetPlaceCompleteByID(id)
 	if err != nil {
-		return ctx.Status(http.StatusNotFound).JSON(utils.ErrorResponse("Place not found"))
+		return placeNotFound(ctx, id)
 	}
 
 	// 🔧 REDIS CACHE: Store in cache (background, doesn't block response)
 	go cache.Set(cacheKey, *placePtr, cache.MediumTTL)
 	ctx.Set("X-Cache", "MISS")
 
+	// Opening hours are attached after caching so the open status is never stale
+	placePtr.OpeningHours = currentOpeningHours(id)
+
 	return ctx.JSON(utils.SuccessResponse("Place retrieved successfully", *placePtr))
 }
 
@@ -109,28 +188,83 @@ func (h *PlaceHandler) GetPlace(ctx *fiber.Ctx) error {
 		lang = "en" // Default to English for invalid language codes
 	}
 
+	// Preview links show a draft place, and are never cached
+	if previewToken := ctx.Query("preview_token"); previewToken != "" {
+		if !services.IsValidPreviewToken(previewToken, domain.PreviewEntityPlace, id) {
+			return ctx.Status(http.StatusForbidden).JSON(utils.ErrorResponse("Invalid or expired preview token"))
+		}
+		placePtr, err := services.GetPlacePreviewWithLanguage(id, lang)
+		if err != nil {
+			return ctx.Status(http.StatusNotFound).JSON(utils.ErrorResponse("Place not found"))
+		}
+		placePtr.OpeningHours = currentOpeningHours(id)
+		ctx.Set(fiber.HeaderCacheControl, "no-store")
+		return ctx.JSON(utils.SuccessResponse("Place preview retrieved successfully", *placePtr))
+	}
+
 	// 🔧 REDIS CACHE: Try cache first with language-specific key
 	cacheKey := fmt.Sprintf("place_%s_%s", id.String(), lang)
 	var place dto.PlaceResponseLocalized
 	
 	if err := cache.Get(cacheKey, &place); err == nil {
 		ctx.Set("X-Cache", "HIT")
+		place.OpeningHours = currentOpeningHours(id)
 		return ctx.JSON(utils.SuccessResponse("Place retrieved successfully", place))
 	}
 
 	// 🔄 ORIGINAL: Get place data from service
 	placePtr, err := services.GetPlaceByIDWithLanguage(id, lang)
 	if err != nil {
-		return ctx.Status(http.StatusNotFound).JSON(utils.ErrorResponse("Place not found"))
+		return placeNotFound(ctx, id)
 	}
 
 	// 🔧 REDIS CACHE: Store in cache (background, doesn't block response)
 	go cache.Set(cacheKey, *placePtr, cache.MediumTTL)
 	ctx.Set("X-Cache", "MISS")
 
+	// Opening hours are attached after caching so the open status is never stale
+	placePtr.OpeningHours = currentOpeningHours(id)
+
 	return ctx.JSON(utils.SuccessResponse("Place retrieved successfully", *placePtr))
 }
 
+// GetPlaceBySlug looks a place up by its English or Arabic slug. Old slugs still resolve,
+// with redirect set so the client can switch to the current URL.
+func (h *PlaceHandler) GetPlaceBySlug(ctx *fiber.Ctx) error {
+	slug, err := url.PathUnescape(ctx.Params("slug"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid slug"))
+	}
+
+	lang := ctx.Query("lang", "en")
+	if lang != "ar" && lang != "en" {
+		lang = "en"
+	}
+
+	// 🔧 REDIS CACHE: Try cache first with language-specific key
+	cacheKey := fmt.Sprintf("place_slug_%s_%s", strings.ToLower(slug), lang)
+	var response dto.PlaceSlugResponse
+
+	if err := cache.Get(cacheKey, &response); err == nil {
+		ctx.Set("X-Cache", "HIT")
+		response.Place.OpeningHours = currentOpeningHours(response.Place.ID)
+		return ctx.JSON(utils.SuccessResponse("Place retrieved successfully", response))
+	}
+
+	responsePtr, err := services.GetPlaceBySlug(slug, lang)
+	if err != nil {
+		return ctx.Status(http.StatusNotFound).JSON(utils.ErrorResponse("Place not found"))
+	}
+
+	go cache.Set(cacheKey, *responsePtr, cache.MediumTTL)
+	ctx.Set("X-Cache", "MISS")
+
+	// Opening hours are attached after caching so the open status is never stale
+	responsePtr.Place.OpeningHours = currentOpeningHours(responsePtr.Place.ID)
+
+	return ctx.JSON(utils.SuccessResponse("Place retrieved successfully", *responsePtr))
+}
+
 func (h *PlaceHandler) CreatePlace(ctx *fiber.Ctx) error {
 	var req dto.CreatePlaceRequest
 	
@@ -167,6 +301,14 @@ func (h *PlaceHandler) CreatePlace(ctx *fiber.Ctx) error {
 	// 🔄 ORIGINAL: Your existing create logic
 	response, err := services.CreatePlace(req, userID)
 	if err != nil {
+		var duplicateErr *services.DuplicatePlaceError
+		if errors.As(err, &duplicateErr) {
+			return ctx.Status(http.StatusConflict).JSON(utils.Response{
+				Success: false,
+				Error:   err.Error(),
+				Data:    duplicateErr.Candidates,
+			})
+		}
 		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
 	}
 
@@ -177,6 +319,8 @@ func (h *PlaceHandler) CreatePlace(ctx *fiber.Ctx) error {
 		cache.DeletePattern("places_governate_*")
 		cache.DeletePattern("places_wilayah_*")
 		cache.DeletePattern("places_search_*")
+		cache.DeletePattern("places_nearby_*")
+		cache.DeletePattern("places_query_*")
 	}()
 
 	return ctx.Status(http.StatusCreated).JSON(utils.SuccessResponse("Place created successfully", response))
@@ -189,7 +333,7 @@ func (h *PlaceHandler) GetPlaces(ctx *fiber.Ctx) error {
 	
 	if err := cache.Get(cacheKey, &places); err == nil {
 		ctx.Set("X-Cache", "HIT")
-		return ctx.JSON(utils.SuccessResponse("Places retrieved successfully", places))
+		return placesJSON(ctx, "Places retrieved successfully", places)
 	}
 
 	// 🔄 ORIGINAL: Your existing database call
@@ -202,7 +346,7 @@ func (h *PlaceHandler) GetPlaces(ctx *fiber.Ctx) error {
 	go cache.Set(cacheKey, places, cache.ShortTTL) // Shorter TTL for frequently changing data
 	ctx.Set("X-Cache", "MISS")
 
-	return ctx.JSON(utils.SuccessResponse("Places retrieved successfully", places))
+	return placesJSON(ctx, "Places retrieved successfully", places)
 }
 
 func (h *PlaceHandler) UpdatePlace(ctx *fiber.Ctx) error {
@@ -235,6 +379,9 @@ func (h *PlaceHandler) UpdatePlace(ctx *fiber.Ctx) error {
 		cache.DeletePattern("places_governate_*")
 		cache.DeletePattern("places_wilayah_*")
 		cache.DeletePattern("places_search_*")
+		cache.DeletePattern("places_nearby_*")
+		cache.DeletePattern("places_query_*")
+		cache.DeletePattern("place_slug_*")
 	}()
 
 	return ctx.JSON(utils.SuccessResponse("Place updated successfully", place))
@@ -266,6 +413,9 @@ func (h *PlaceHandler) DeletePlace(ctx *fiber.Ctx) error {
 		cache.DeletePattern("places_governate_*")
 		cache.DeletePattern("places_wilayah_*")
 		cache.DeletePattern("places_search_*")
+		cache.DeletePattern("places_nearby_*")
+		cache.DeletePattern("places_query_*")
+		cache.DeletePattern("place_slug_*")
 		cache.DeletePattern("content_section_images_*") // Content section images for this place
 		cache.DeletePattern("place_properties_*") // Place properties
 	}()
@@ -286,7 +436,7 @@ func (h *PlaceHandler) GetPlacesByCategory(ctx *fiber.Ctx) error {
 	
 	if err := cache.Get(cacheKey, &places); err == nil {
 		ctx.Set("X-Cache", "HIT")
-		return ctx.JSON(utils.SuccessResponse("Places retrieved successfully", places))
+		return placesJSON(ctx, "Places retrieved successfully", places)
 	}
 
 	// 🔄 ORIGINAL: Your existing database call
@@ -299,7 +449,7 @@ func (h *PlaceHandler) GetPlacesByCategory(ctx *fiber.Ctx) error {
 	go cache.Set(cacheKey, places, cache.MediumTTL)
 	ctx.Set("X-Cache", "MISS")
 
-	return ctx.JSON(utils.SuccessResponse("Places retrieved successfully", places))
+	return placesJSON(ctx, "Places retrieved successfully", places)
 }
 
 func (h *PlaceHandler) GetPlacesByGovernate(ctx *fiber.Ctx) error {
@@ -315,7 +465,7 @@ func (h *PlaceHandler) GetPlacesByGovernate(ctx *fiber.Ctx) error {
 	
 	if err := cache.Get(cacheKey, &places); err == nil {
 		ctx.Set("X-Cache", "HIT")
-		return ctx.JSON(utils.SuccessResponse("Places retrieved successfully", places))
+		return placesJSON(ctx, "Places retrieved successfully", places)
 	}
 
 	// 🔄 ORIGINAL: Your existing database call
@@ -328,7 +478,7 @@ func (h *PlaceHandler) GetPlacesByGovernate(ctx *fiber.Ctx) error {
 	go cache.Set(cacheKey, places, cache.MediumTTL)
 	ctx.Set("X-Cache", "MISS")
 
-	return ctx.JSON(utils.SuccessResponse("Places retrieved successfully", places))
+	return placesJSON(ctx, "Places retrieved successfully", places)
 }
 
 func (h *PlaceHandler) GetPlacesByWilayah(ctx *fiber.Ctx) error {
@@ -344,7 +494,7 @@ func (h *PlaceHandler) GetPlacesByWilayah(ctx *fiber.Ctx) error {
 	
 	if err := cache.Get(cacheKey, &places); err == nil {
 		ctx.Set("X-Cache", "HIT")
-		return ctx.JSON(utils.SuccessResponse("Places retrieved successfully", places))
+		return placesJSON(ctx, "Places retrieved successfully", places)
 	}
 
 	// 🔄 ORIGINAL: Your existing database call
@@ -357,35 +507,534 @@ func (h *PlaceHandler) GetPlacesByWilayah(ctx *fiber.Ctx) error {
 	go cache.Set(cacheKey, places, cache.MediumTTL)
 	ctx.Set("X-Cache", "MISS")
 
-	return ctx.JSON(utils.SuccessResponse("Places retrieved successfully", places))
+	return placesJSON(ctx, "Places retrieved successfully", places)
 }
 
 func (h *PlaceHandler) SearchPlaces(ctx *fiber.Ctx) error {
-	query := ctx.Query("q")
-	if query == "" {
+	req := dto.PlaceSearchRequest{
+		Query: ctx.Query("q"),
+		Page:  ctx.QueryInt("page", 1),
+		Limit: ctx.QueryInt("limit", 20),
+	}
+	if strings.TrimSpace(req.Query) == "" {
 		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Search query is required"))
 	}
+	req.SetDefaults()
 
-	// 🔧 REDIS CACHE: Try cache first
-	cacheKey := fmt.Sprintf("places_search_%s", query)
-	var places []dto.PlaceListResponse
-	
-	if err := cache.Get(cacheKey, &places); err == nil {
-		ctx.Set("X-Cache", "HIT")
-		return ctx.JSON(utils.SuccessResponse("Search results retrieved successfully", places))
+	var err error
+	req.OpenAt, err = parseOpenAtFilter(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
 	}
 
-	// 🔄 ORIGINAL: Your existing database call
-	places, err := services.SearchPlaces(query)
+	// 🔧 REDIS CACHE: Key on the normalized query so spelling variants share an entry.
+	// Open filters depend on the time and aren't cached.
+	cacheKey := fmt.Sprintf("places_search_%s_%d_%d", utils.NormalizeSearchText(req.Query), req.Page, req.Limit)
+	var hits []dto.PlaceSearchResult
+	results := dto.PaginationResponse{Data: &hits}
+
+	if req.OpenAt == nil {
+		if err := cache.Get(cacheKey, &results); err == nil {
+			ctx.Set("X-Cache", "HIT")
+			return searchResultsJSON(ctx, results, hits)
+		}
+	}
+
+	response, err := services.SearchPlacesRanked(req)
 	if err != nil {
 		return ctx.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse(err.Error()))
 	}
 
 	// 🔧 REDIS CACHE: Store in cache (background, doesn't block response)
-	go cache.Set(cacheKey, places, cache.ShortTTL) // Shorter TTL for search results
+	if req.OpenAt == nil {
+		go cache.Set(cacheKey, response, cache.ShortTTL) // Shorter TTL for search results
+		ctx.Set("X-Cache", "MISS")
+	}
+
+	hits, _ = response.Data.([]dto.PlaceSearchResult)
+	return searchResultsJSON(ctx, *response, hits)
+}
+
+// searchResultsJSON responds with a page of search hits, marking the signed-in caller's
+// favorites. The hits are copied, the cached page is shared by all callers.
+func searchResultsJSON(ctx *fiber.Ctx, page dto.PaginationResponse, hits []dto.PlaceSearchResult) error {
+	places := make([]dto.PlaceListResponse, len(hits))
+	for i, hit := range hits {
+		places[i] = hit.PlaceListResponse
+	}
+	places, err := markFavoritePlaces(ctx, places)
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	marked := make([]dto.PlaceSearchResult, len(hits))
+	for i, hit := range hits {
+		hit.PlaceListResponse = places[i]
+		marked[i] = hit
+	}
+	page.Data = marked
+
+	return ctx.JSON(utils.SuccessResponse("Search results retrieved successfully", page))
+}
+
+// ReindexSearch rebuilds the search documents of all places
+func (h *PlaceHandler) ReindexSearch(ctx *fiber.Ctx) error {
+	indexed, err := services.RebuildPlaceSearchDocuments()
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	go cache.DeletePattern("places_search_*")
+
+	return ctx.JSON(utils.SuccessResponse("Search index rebuilt successfully", fiber.Map{
+		"indexed": indexed,
+	}))
+}
+
+// ImportPlaces creates or updates places from an uploaded CSV or GeoJSON file.
+// With ?dry_run=true nothing is saved and the per-row report shows what would happen.
+func (h *PlaceHandler) ImportPlaces(ctx *fiber.Ctx) error {
+	file, err := ctx.FormFile("file")
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("No file provided"))
+	}
+
+	const maxImportSize = 10 * 1024 * 1024 // 10MB
+	if file.Size > maxImportSize {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("File size exceeds 10MB limit"))
+	}
+
+	format := strings.ToLower(ctx.Query("format"))
+	if format == "" {
+		format, err = services.DetectImportFormat(file.Filename)
+		if err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+		}
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse("Failed to open file"))
+	}
+	defer src.Close()
+
+	records, err := services.ParsePlaceImportFile(src, format)
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+	dryRun := ctx.QueryBool("dry_run", false)
+
+	report, err := services.ImportPlaces(records, userID, dryRun)
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	if dryRun {
+		return ctx.JSON(utils.SuccessResponse("Place import validated successfully", report))
+	}
+
+	// 🔧 REDIS CACHE: Invalidate related caches after import, the listings only once
+	go func() {
+		for _, row := range report.Rows {
+			if row.PlaceID != nil && (row.Action == dto.ImportActionCreate || row.Action == dto.ImportActionUpdate) {
+				invalidatePlaceDetailCaches(*row.PlaceID)
+			}
+		}
+		if report.Created > 0 || report.Updated > 0 {
+			invalidatePlaceListCaches()
+		}
+	}()
+
+	return ctx.JSON(utils.SuccessResponse("Places imported successfully", report))
+}
+
+// GetPlaceRatings returns the average rating and star distribution of a place
+func (h *PlaceHandler) GetPlaceRatings(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid place ID"))
+	}
+
+	summary, err := services.GetPlaceRatingSummary(id)
+	if err != nil {
+		if err.Error() == "place not found" {
+			return placeNotFound(ctx, id)
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Place ratings retrieved successfully", summary))
+}
+
+// GetPlacePhotos returns the photos visitors attached to their reviews of a place
+func (h *PlaceHandler) GetPlacePhotos(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid place ID"))
+	}
+
+	page := ctx.QueryInt("page", 1)
+	if page < 1 {
+		page = 1
+	}
+	limit := ctx.QueryInt("limit", 24)
+	if limit < 1 || limit > 100 {
+		limit = 24
+	}
+
+	cacheKey := fmt.Sprintf("reviews_photos_%s_%d_%d", id.String(), page, limit)
+	var gallery dto.PaginationResponse
+	if err := cache.Get(cacheKey, &gallery); err == nil {
+		ctx.Set("X-Cache", "HIT")
+		return ctx.JSON(utils.SuccessResponse("Place photos retrieved successfully", gallery))
+	}
+
+	result, err := services.GetPlacePhotoGallery(id, page, limit)
+	if err != nil {
+		if err.Error() == "place not found" {
+			return placeNotFound(ctx, id)
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	go cache.Set(cacheKey, *result, cache.MediumTTL)
 	ctx.Set("X-Cache", "MISS")
 
-	return ctx.JSON(utils.SuccessResponse("Search results retrieved successfully", places))
+	return ctx.JSON(utils.SuccessResponse("Place photos retrieved successfully", result))
+}
+
+// GetPlaceDishes returns the dishes served at a place, signature dishes first
+func (h *PlaceHandler) GetPlaceDishes(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid place ID"))
+	}
+
+	dishes, err := services.GetPlaceDishes(id)
+	if err != nil {
+		if err.Error() == "place not found" {
+			return placeNotFound(ctx, id)
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Place dishes retrieved successfully", dishes))
+}
+
+// GetNearbyPlaces returns places around ?lat=&lng= ordered by distance
+func (h *PlaceHandler) GetNearbyPlaces(ctx *fiber.Ctx) error {
+	lat, err := strconv.ParseFloat(ctx.Query("lat"), 64)
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Valid lat query parameter is required"))
+	}
+	lng, err := strconv.ParseFloat(ctx.Query("lng"), 64)
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Valid lng query parameter is required"))
+	}
+	if !utils.ValidCoordinates(lat, lng) {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Coordinates are out of range"))
+	}
+
+	params := dto.NearbyPlacesParams{
+		Latitude:  lat,
+		Longitude: lng,
+	}
+
+	if radius := ctx.Query("radius_km"); radius != "" {
+		params.RadiusKm, err = strconv.ParseFloat(radius, 64)
+		if err != nil || math.IsNaN(params.RadiusKm) || math.IsInf(params.RadiusKm, 0) {
+			return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid radius_km"))
+		}
+	}
+
+	if limit := ctx.Query("limit"); limit != "" {
+		params.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid limit"))
+		}
+	}
+
+	categoryKey := "all"
+	if categoryParam := ctx.Query("category_id"); categoryParam != "" {
+		categoryID, err := uuid.Parse(categoryParam)
+		if err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid category ID"))
+		}
+		params.CategoryID = &categoryID
+		categoryKey = categoryID.String()
+	}
+
+	// The open filter runs in the query so the limit counts open places only
+	params.OpenAt, err = parseOpenAtFilter(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	params.SetDefaults()
+
+	// 🔧 REDIS CACHE: Try cache first, open filters depend on the time and aren't cached
+	cacheKey := fmt.Sprintf("places_nearby_%.5f_%.5f_%.1f_%s_%d", lat, lng, params.RadiusKm, categoryKey, params.Limit)
+	var places []dto.PlaceListResponse
+
+	if params.OpenAt == nil {
+		if err := cache.Get(cacheKey, &places); err == nil {
+			ctx.Set("X-Cache", "HIT")
+			return placeListJSON(ctx, "Nearby places retrieved successfully", places)
+		}
+	}
+
+	places, err = services.GetNearbyPlaces(params)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidNearbyParams) {
+			return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	if params.OpenAt == nil {
+		go cache.Set(cacheKey, places, cache.ShortTTL)
+		ctx.Set("X-Cache", "MISS")
+	}
+
+	return placeListJSON(ctx, "Nearby places retrieved successfully", places)
+}
+
+// QueryPlaces runs a faceted place filter and returns results with facet counts
+func (h *PlaceHandler) QueryPlaces(ctx *fiber.Ctx) error {
+	var req dto.PlaceFilterRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	req.SetDefaults()
+	if err := utils.ValidateStruct(req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+	if unsupported := req.Unsupported(); unsupported != "" {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(
+			"Unsupported filters: " + unsupported + ", use governate_ids and wilayah_ids"))
+	}
+
+	// Only admins may list deactivated places
+	if user, ok := middleware.GetUserFromContext(ctx); !ok || !user.IsAdmin() {
+		req.IsActive = nil
+	}
+
+	// 🔧 REDIS CACHE: Key on a hash of the normalized filter. open_now depends on the
+	// time and isn't cached.
+	filterJSON, _ := json.Marshal(req)
+	cacheKey := fmt.Sprintf("places_query_%x", md5.Sum(filterJSON))
+	cacheable := !req.OpenNow || req.OpenAt != nil
+	var response dto.PlaceQueryResponse
+
+	if cacheable && cache.Get(cacheKey, &response) == nil {
+		ctx.Set("X-Cache", "HIT")
+	} else {
+		result, err := services.QueryPlaces(req)
+		if err != nil {
+			return ctx.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse(err.Error()))
+		}
+
+		if cacheable {
+			go cache.Set(cacheKey, result, cache.ShortTTL)
+			ctx.Set("X-Cache", "MISS")
+		}
+		response = *result
+	}
+
+	places, err := markFavoritePlaces(ctx, response.Places)
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse(err.Error()))
+	}
+	response.Places = places
+
+	return ctx.JSON(utils.SuccessResponse("Places retrieved successfully", response))
+}
+
+// Moderation Handlers
+func (h *PlaceHandler) GetModerationQueue(ctx *fiber.Ctx) error {
+	page := ctx.QueryInt("page", 1)
+	if page < 1 {
+		page = 1
+	}
+	limit := ctx.QueryInt("limit", 20)
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	var governateID *uuid.UUID
+	if governateParam := ctx.Query("governate_id"); governateParam != "" {
+		id, err := uuid.Parse(governateParam)
+		if err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid governate ID"))
+		}
+		governateID = &id
+	}
+
+	queue, err := services.GetModerationQueue(ctx.Query("status"), governateID, page, limit)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid status") {
+			return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Moderation queue retrieved successfully", queue))
+}
+
+func (h *PlaceHandler) GetModerationHistory(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid place ID"))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	history, err := services.GetPlaceModerationHistory(id, userID)
+	if err != nil {
+		return moderationError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Moderation history retrieved successfully", history))
+}
+
+func (h *PlaceHandler) SubmitPlace(ctx *fiber.Ctx) error {
+	return h.moderatePlace(ctx, services.SubmitPlaceForReview, "Place submitted for review")
+}
+
+func (h *PlaceHandler) ApprovePlace(ctx *fiber.Ctx) error {
+	return h.moderatePlace(ctx, services.ApprovePlace, "Place approved and published")
+}
+
+func (h *PlaceHandler) RejectPlace(ctx *fiber.Ctx) error {
+	return h.moderatePlace(ctx, services.RejectPlace, "Place rejected")
+}
+
+func (h *PlaceHandler) ArchivePlace(ctx *fiber.Ctx) error {
+	return h.moderatePlace(ctx, services.ArchivePlace, "Place archived")
+}
+
+// moderatePlace runs a status transition and clears public caches, since the place may
+// have just appeared on or disappeared from public listings
+func (h *PlaceHandler) moderatePlace(ctx *fiber.Ctx, action func(uuid.UUID, uuid.UUID, string) (*dto.PlaceResponse, error), message string) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid place ID"))
+	}
+
+	var req dto.ModerationActionRequest
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(&req); err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+		}
+	}
+	if err := utils.ValidateStruct(req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	place, err := action(id, userID, strings.TrimSpace(req.Comment))
+	if err != nil {
+		return moderationError(ctx, err)
+	}
+
+	// 🔧 REDIS CACHE: Invalidate related caches after status change
+	go invalidatePlaceCaches(id)
+
+	return ctx.JSON(utils.SuccessResponse(message, place))
+}
+
+// MergePlace folds the place given as duplicate_id into this one
+func (h *PlaceHandler) MergePlace(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid place ID"))
+	}
+
+	var req dto.MergePlaceRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+	if err := utils.ValidateStruct(req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	result, err := services.MergePlaces(id, req.DuplicateID, userID)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "not found") {
+			return ctx.Status(http.StatusNotFound).JSON(utils.ErrorResponse(err.Error()))
+		}
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	// 🔧 REDIS CACHE: Both places changed, and reviews and images moved between them
+	go func() {
+		invalidatePlaceCaches(id)
+		invalidatePlaceCaches(req.DuplicateID)
+		cache.Delete(fmt.Sprintf("place_images_%s", id.String()))
+		cache.Delete(fmt.Sprintf("place_images_%s", req.DuplicateID.String()))
+		cache.DeletePattern("reviews_*")
+	}()
+
+	return ctx.JSON(utils.SuccessResponse("Places merged successfully", result))
+}
+
+// placeNotFound redirects requests for a merged duplicate to the surviving place
+func placeNotFound(ctx *fiber.Ctx, id uuid.UUID) error {
+	redirect, err := services.GetPlaceRedirect(id)
+	if err != nil {
+		return ctx.Status(http.StatusNotFound).JSON(utils.ErrorResponse("Place not found"))
+	}
+
+	ctx.Location(strings.Replace(ctx.OriginalURL(), id.String(), redirect.PlaceID.String(), 1))
+	return ctx.Status(http.StatusMovedPermanently).JSON(utils.SuccessResponse("Place was merged into another place", redirect))
+}
+
+// invalidatePlaceCaches drops every cached response that may contain the given place
+func invalidatePlaceCaches(id uuid.UUID) {
+	invalidatePlaceDetailCaches(id)
+	invalidatePlaceListCaches()
+}
+
+// invalidatePlaceDetailCaches drops the cached responses of a single place
+func invalidatePlaceDetailCaches(id uuid.UUID) {
+	cache.Delete(fmt.Sprintf("place_%s_ar", id.String()))
+	cache.Delete(fmt.Sprintf("place_%s_en", id.String()))
+	cache.Delete(fmt.Sprintf("place_complete_%s", id.String()))
+}
+
+// invalidatePlaceListCaches drops every cached listing, search and lookup of places
+func invalidatePlaceListCaches() {
+	cache.Delete("places_all")
+	cache.DeletePattern("places_category_*")
+	cache.DeletePattern("places_governate_*")
+	cache.DeletePattern("places_wilayah_*")
+	cache.DeletePattern("places_search_*")
+	cache.DeletePattern("places_nearby_*")
+	cache.DeletePattern("places_query_*")
+	cache.DeletePattern("places_filter_*")
+	cache.DeletePattern("recent_places_*")
+	cache.DeletePattern("place_slug_*")
+}
+
+// moderationError maps moderation service errors to HTTP status codes
+func moderationError(ctx *fiber.Ctx, err error) error {
+	message := err.Error()
+	switch {
+	case strings.HasPrefix(message, "unauthorized"):
+		return ctx.Status(http.StatusForbidden).JSON(utils.ErrorResponse(message))
+	case message == "place not found":
+		return ctx.Status(http.StatusNotFound).JSON(utils.ErrorResponse(message))
+	case strings.HasPrefix(message, "cannot move place"):
+		return ctx.Status(http.StatusConflict).JSON(utils.ErrorResponse(message))
+	default:
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(message))
+	}
 }
 
 // Content Section Handlers
@@ -515,7 +1164,7 @@ func (h *PlaceHandler) GetPlacesByFilters(ctx *fiber.Ctx) error {
     var places []dto.PlaceListResponse
     if err := cache.Get(cacheKey, &places); err == nil {
         ctx.Set("X-Cache", "HIT")
-        return ctx.JSON(utils.SuccessResponse("Places retrieved successfully", places))
+        return placesJSON(ctx, "Places retrieved successfully", places)
     }
     
     // Get from service with optimized fields
@@ -528,6 +1177,76 @@ func (h *PlaceHandler) GetPlacesByFilters(ctx *fiber.Ctx) error {
     go cache.Set(cacheKey, places, cache.MediumTTL)
     ctx.Set("X-Cache", "MISS")
     
-    return ctx.JSON(utils.SuccessResponse("Places retrieved successfully", places))
+    return placesJSON(ctx, "Places retrieved successfully", places)
+}
+
+// placesJSON responds with a full, unpaginated place list, applying the optional open_now /
+// open_at filters and marking the signed-in caller's favorites. Paginated listings filter in
+// their query instead.
+func placesJSON(ctx *fiber.Ctx, message string, places []dto.PlaceListResponse) error {
+	openAt, err := parseOpenAtFilter(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	if openAt != nil {
+		places, err = services.FilterPlacesOpenAt(places, *openAt)
+		if err != nil {
+			return ctx.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse(err.Error()))
+		}
+	}
+
+	return placeListJSON(ctx, message, places)
 }
 
+// placeListJSON responds with a place list already filtered by the query, marking the
+// signed-in caller's favorites
+func placeListJSON(ctx *fiber.Ctx, message string, places []dto.PlaceListResponse) error {
+	places, err := markFavoritePlaces(ctx, places)
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	return ctx.JSON(utils.SuccessResponse(message, places))
+}
+
+// markFavoritePlaces sets is_favorited on places for a signed-in caller. It runs after the
+// cache so cached lists stay the same for everyone.
+func markFavoritePlaces(ctx *fiber.Ctx, places []dto.PlaceListResponse) ([]dto.PlaceListResponse, error) {
+	userID, ok := ctx.Locals("userID").(uuid.UUID)
+	if !ok {
+		return places, nil
+	}
+	return services.MarkFavoritePlaces(places, userID)
+}
+
+// parseOpenAtFilter reads ?open_now=true or ?open_at= (RFC3339, or YYYY-MM-DDTHH:MM in Muscat time)
+func parseOpenAtFilter(ctx *fiber.Ctx) (*time.Time, error) {
+	if openAt := ctx.Query("open_at"); openAt != "" {
+		if t, err := time.Parse(time.RFC3339, openAt); err == nil {
+			return &t, nil
+		}
+		t, err := time.ParseInLocation("2006-01-02T15:04", openAt, utils.MuscatLocation())
+		if err != nil {
+			return nil, fmt.Errorf("invalid open_at, expected RFC3339 or YYYY-MM-DDTHH:MM")
+		}
+		return &t, nil
+	}
+
+	if ctx.QueryBool("open_now") {
+		now := time.Now()
+		return &now, nil
+	}
+
+	return nil, nil
+}
+
+// currentOpeningHours loads a place's opening hours with the open status evaluated now
+func currentOpeningHours(placeID uuid.UUID) *dto.OpeningHoursResponse {
+	hours, err := services.GetPlaceOpeningHours(placeID, time.Now())
+	if err != nil {
+		fmt.Printf("⚠️ Failed to load opening hours for place %s: %v\n", placeID, err)
+		return nil
+	}
+	return hours
+}
@@ -0,0 +1,90 @@
+// handlers/placePermissionHandler.go
+package handlers
+
+import (
+	"almlah/internals/dto"
+	"almlah/internals/services"
+	"almlah/internals/utils"
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// GetPlacePermissions lists the users granted permissions on a place
+func (h *PlaceHandler) GetPlacePermissions(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid place ID"))
+	}
+
+	grants, err := services.GetPlacePermissions(id)
+	if err != nil {
+		return ctx.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Place permissions retrieved successfully", grants))
+}
+
+// GrantPlacePermission gives a user a permission on this place only, e.g. can_respond_review
+// for the business that runs it
+func (h *PlaceHandler) GrantPlacePermission(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid place ID"))
+	}
+
+	var req dto.GrantPlacePermissionRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	grantedBy := ctx.Locals("userID").(uuid.UUID)
+
+	grant, err := services.GrantPlacePermission(id, req, grantedBy)
+	if err != nil {
+		return placePermissionError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(utils.SuccessResponse("Permission granted successfully", grant))
+}
+
+// RevokePlacePermission removes the ?permission= grant of a user on this place
+func (h *PlaceHandler) RevokePlacePermission(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid place ID"))
+	}
+	userID, err := uuid.Parse(ctx.Params("userId"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid user ID"))
+	}
+
+	permission := ctx.Query("permission")
+	if permission == "" {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("permission is required"))
+	}
+
+	if err := services.RevokePlacePermission(id, userID, permission); err != nil {
+		return placePermissionError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Permission revoked successfully", nil))
+}
+
+func placePermissionError(ctx *fiber.Ctx, err error) error {
+	message := err.Error()
+	switch {
+	case strings.HasSuffix(message, "not found"):
+		return ctx.Status(http.StatusNotFound).JSON(utils.ErrorResponse(message))
+	case strings.HasPrefix(message, "invalid"):
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(message))
+	default:
+		return ctx.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse(message))
+	}
+}
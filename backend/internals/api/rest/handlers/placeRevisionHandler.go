@@ -0,0 +1,130 @@
+// handlers/placeRevisionHandler.go
+package handlers
+
+import (
+	"almlah/internals/middleware"
+	"almlah/internals/services"
+	"almlah/internals/utils"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type PlaceRevisionHandler struct{}
+
+func SetupPlaceRevisionRoutes(app *fiber.App) {
+	handler := PlaceRevisionHandler{}
+
+	// Protected routes - place editors and moderators
+	revisions := app.Group("/api/v1/places/:placeId/revisions",
+		middleware.AuthRequiredWithRBAC,
+		middleware.LoadUserWithPermissions())
+
+	revisions.Get("/", handler.GetRevisions)
+	revisions.Get("/diff", handler.DiffRevisions) // Must be before /:revision
+	revisions.Get("/:revision", handler.GetRevision)
+	revisions.Post("/:revision/restore", handler.RestoreRevision)
+}
+
+func (h *PlaceRevisionHandler) GetRevisions(ctx *fiber.Ctx) error {
+	placeID, err := uuid.Parse(ctx.Params("placeId"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid place ID"))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	revisions, err := services.GetPlaceRevisions(placeID, userID)
+	if err != nil {
+		return placeRevisionError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Place revisions retrieved successfully", revisions))
+}
+
+func (h *PlaceRevisionHandler) GetRevision(ctx *fiber.Ctx) error {
+	placeID, err := uuid.Parse(ctx.Params("placeId"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid place ID"))
+	}
+
+	revisionNumber, err := strconv.Atoi(ctx.Params("revision"))
+	if err != nil || revisionNumber < 1 {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid revision number"))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	revision, err := services.GetPlaceRevision(placeID, revisionNumber, userID)
+	if err != nil {
+		return placeRevisionError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Place revision retrieved successfully", revision))
+}
+
+// DiffRevisions compares two revisions given as ?from=&to= revision numbers
+func (h *PlaceRevisionHandler) DiffRevisions(ctx *fiber.Ctx) error {
+	placeID, err := uuid.Parse(ctx.Params("placeId"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid place ID"))
+	}
+
+	from, err := strconv.Atoi(ctx.Query("from"))
+	if err != nil || from < 1 {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid from revision"))
+	}
+	to, err := strconv.Atoi(ctx.Query("to"))
+	if err != nil || to < 1 {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid to revision"))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	diff, err := services.DiffPlaceRevisions(placeID, from, to, userID)
+	if err != nil {
+		return placeRevisionError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Place revision diff retrieved successfully", diff))
+}
+
+func (h *PlaceRevisionHandler) RestoreRevision(ctx *fiber.Ctx) error {
+	placeID, err := uuid.Parse(ctx.Params("placeId"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid place ID"))
+	}
+
+	revisionNumber, err := strconv.Atoi(ctx.Params("revision"))
+	if err != nil || revisionNumber < 1 {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid revision number"))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	place, err := services.RestorePlaceRevision(placeID, revisionNumber, userID)
+	if err != nil {
+		return placeRevisionError(ctx, err)
+	}
+
+	// 🔧 REDIS CACHE: Invalidate related caches after restore
+	go invalidatePlaceCaches(placeID)
+
+	return ctx.JSON(utils.SuccessResponse("Place revision restored successfully", place))
+}
+
+// placeRevisionError maps revision service errors to HTTP status codes
+func placeRevisionError(ctx *fiber.Ctx, err error) error {
+	message := err.Error()
+	switch {
+	case strings.HasPrefix(message, "unauthorized"):
+		return ctx.Status(http.StatusForbidden).JSON(utils.ErrorResponse(message))
+	case strings.HasSuffix(message, "not found"):
+		return ctx.Status(http.StatusNotFound).JSON(utils.ErrorResponse(message))
+	default:
+		return ctx.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse(message))
+	}
+}
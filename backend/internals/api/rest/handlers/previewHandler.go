@@ -0,0 +1,94 @@
+// handlers/previewHandler.go
+package handlers
+
+import (
+	"almlah/internals/dto"
+	"almlah/internals/middleware"
+	"almlah/internals/services"
+	"almlah/internals/utils"
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type PreviewHandler struct{}
+
+// SetupPreviewRoutes manages signed preview links for draft lists and places. The links
+// are opened through the public GET endpoints with ?preview_token=.
+func SetupPreviewRoutes(app *fiber.App) {
+	handler := PreviewHandler{}
+
+	previews := app.Group("/api/v1/previews", middleware.AuthRequiredWithRBAC)
+
+	previews.Post("/", handler.CreatePreviewToken)
+	previews.Get("/", handler.GetPreviewTokens)
+	previews.Delete("/:id", handler.RevokePreviewToken)
+}
+
+func (h *PreviewHandler) CreatePreviewToken(ctx *fiber.Ctx) error {
+	var req dto.CreatePreviewTokenRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	token, err := services.CreatePreviewToken(req, userID)
+	if err != nil {
+		return previewError(ctx, err)
+	}
+
+	return ctx.Status(http.StatusCreated).JSON(utils.SuccessResponse("Preview link created successfully", token))
+}
+
+// GetPreviewTokens lists the preview links of ?entity_type=&entity_id=
+func (h *PreviewHandler) GetPreviewTokens(ctx *fiber.Ctx) error {
+	entityID, err := uuid.Parse(ctx.Query("entity_id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Valid entity_id query parameter is required"))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	tokens, err := services.GetPreviewTokens(ctx.Query("entity_type"), entityID, userID)
+	if err != nil {
+		return previewError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Preview links retrieved successfully", tokens))
+}
+
+func (h *PreviewHandler) RevokePreviewToken(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid preview token ID"))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	if err := services.RevokePreviewToken(id, userID); err != nil {
+		return previewError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Preview link revoked successfully", nil))
+}
+
+func previewError(ctx *fiber.Ctx, err error) error {
+	message := err.Error()
+	switch {
+	case strings.HasSuffix(message, "not found"):
+		return ctx.Status(http.StatusNotFound).JSON(utils.ErrorResponse(message))
+	case strings.HasPrefix(message, "invalid"):
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(message))
+	case strings.HasPrefix(message, "unauthorized"):
+		return ctx.Status(http.StatusForbidden).JSON(utils.ErrorResponse(message))
+	default:
+		return ctx.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse(message))
+	}
+}
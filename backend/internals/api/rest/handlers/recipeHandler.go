@@ -9,6 +9,7 @@ import (
 	"almlah/internals/utils"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -24,13 +25,19 @@ func SetupRecipeRoutes(app *fiber.App) {
 	
 	// Public routes
 	recipes.Get("/", handler.GetRecipes)
-	recipes.Get("/:id", handler.GetRecipe)
+	recipes.Get("/:id", middleware.OptionalAuth, handler.GetRecipe)
 	
 	// Protected routes with RBAC
 	recipes.Post("/", 
 		middleware.AuthRequiredWithRBAC, 
 		middleware.RequirePermission("can_create_recipe"), 
 		handler.CreateRecipe)
+
+	// Authors edit their own recipes, admins and moderators with permission any recipe
+	recipes.Put("/:id", middleware.AuthRequiredWithRBAC, handler.UpdateRecipe)
+	recipes.Delete("/:id", middleware.AuthRequiredWithRBAC, handler.DeleteRecipe)
+	recipes.Post("/:id/images", middleware.AuthRequiredWithRBAC, handler.AddRecipeImage)
+	recipes.Delete("/:id/images/:imageId", middleware.AuthRequiredWithRBAC, handler.DeleteRecipeImage)
 }
 
 func (h *RecipeHandler) CreateRecipe(ctx *fiber.Ctx) error {
@@ -99,15 +106,141 @@ func (h *RecipeHandler) GetRecipe(ctx *fiber.Ctx) error {
 		return ctx.JSON(utils.SuccessResponse("Recipe retrieved successfully", recipe))
 	}
 
+	var viewerID *uuid.UUID
+	if userID, ok := ctx.Locals("userID").(uuid.UUID); ok {
+		viewerID = &userID
+	}
+
 	// 🔄 ORIGINAL: Your existing database call
-	recipePtr, err := services.GetRecipeByID(id)
+	recipePtr, err := services.GetRecipeByID(id, viewerID)
 	if err != nil {
-		return ctx.Status(http.StatusNotFound).JSON(utils.ErrorResponse("Recipe not found"))
+		if strings.HasSuffix(err.Error(), "not found") {
+			return ctx.Status(http.StatusNotFound).JSON(utils.ErrorResponse("Recipe not found"))
+		}
+		return ctx.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse(err.Error()))
 	}
 
-	// 🔧 REDIS CACHE: Store in cache (background, doesn't block response)
-	go cache.Set(cacheKey, *recipePtr, cache.LongTTL)
+	// 🔧 REDIS CACHE: Store in cache (background, doesn't block response). Unpublished
+	// recipes aren't cached, the cache is shared with anonymous readers.
+	if recipePtr.IsPublished {
+		go cache.Set(cacheKey, *recipePtr, cache.LongTTL)
+	}
 	ctx.Set("X-Cache", "MISS")
 
 	return ctx.JSON(utils.SuccessResponse("Recipe retrieved successfully", *recipePtr))
-}
\ No newline at end of file
+}
+func (h *RecipeHandler) UpdateRecipe(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid recipe ID"))
+	}
+
+	var req dto.UpdateRecipeRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	response, err := services.UpdateRecipe(id, req, userID)
+	if err != nil {
+		return recipeError(ctx, err)
+	}
+
+	// 🔧 REDIS CACHE: Invalidate related caches after update
+	go invalidateRecipeCaches(id)
+
+	return ctx.JSON(utils.SuccessResponse("Recipe updated successfully", response))
+}
+
+func (h *RecipeHandler) DeleteRecipe(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid recipe ID"))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	if err := services.DeleteRecipe(id, userID); err != nil {
+		return recipeError(ctx, err)
+	}
+
+	// 🔧 REDIS CACHE: Invalidate related caches after deletion
+	go invalidateRecipeCaches(id)
+
+	return ctx.JSON(utils.SuccessResponse("Recipe deleted successfully", nil))
+}
+
+// AddRecipeImage adds an image to a recipe, or to one of its steps with step_number
+func (h *RecipeHandler) AddRecipeImage(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid recipe ID"))
+	}
+
+	var req dto.CreateRecipeImageRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	response, err := services.AddRecipeImage(id, req, userID)
+	if err != nil {
+		return recipeError(ctx, err)
+	}
+
+	go invalidateRecipeCaches(id)
+
+	return ctx.Status(http.StatusCreated).JSON(utils.SuccessResponse("Recipe image added successfully", response))
+}
+
+func (h *RecipeHandler) DeleteRecipeImage(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid recipe ID"))
+	}
+	imageID, err := uuid.Parse(ctx.Params("imageId"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid image ID"))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	response, err := services.DeleteRecipeImage(id, imageID, userID)
+	if err != nil {
+		return recipeError(ctx, err)
+	}
+
+	go invalidateRecipeCaches(id)
+
+	return ctx.JSON(utils.SuccessResponse("Recipe image deleted successfully", response))
+}
+
+func invalidateRecipeCaches(recipeID uuid.UUID) {
+	cache.Delete("recipes_all")
+	cache.Delete(fmt.Sprintf("recipe_%s", recipeID.String()))
+	cache.DeletePattern("recipes_*")
+}
+
+func recipeError(ctx *fiber.Ctx, err error) error {
+	message := err.Error()
+	switch {
+	case strings.HasPrefix(message, "unauthorized"):
+		return ctx.Status(http.StatusForbidden).JSON(utils.ErrorResponse(message))
+	case strings.HasPrefix(message, "invalid"):
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(message))
+	case strings.HasSuffix(message, "not found"):
+		return ctx.Status(http.StatusNotFound).JSON(utils.ErrorResponse(message))
+	default:
+		return ctx.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse(message))
+	}
+}
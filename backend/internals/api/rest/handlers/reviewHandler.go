@@ -3,12 +3,18 @@ package handlers
 
 import (
 	"almlah/internals/cache"
+	"almlah/internals/domain"
 	"almlah/internals/dto"
 	"almlah/internals/middleware"
 	"almlah/internals/services"
 	"almlah/internals/utils"
+	"errors"
 	"fmt"
+	"mime/multipart"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -28,14 +34,74 @@ func SetupReviewRoutes(app *fiber.App) {
 		middleware.RequirePermission("can_create_review"), 
 		handler.CreateReview)
 	
+	reviews.Put("/:id",
+		middleware.AuthRequiredWithRBAC,
+		middleware.LoadUserWithPermissions(),
+		handler.UpdateReview)
+
+	reviews.Delete("/:id",
+		middleware.AuthRequiredWithRBAC,
+		middleware.LoadUserWithPermissions(),
+		handler.DeleteReview)
+
+	reviews.Delete("/:id/images/:imageId",
+		middleware.AuthRequiredWithRBAC,
+		middleware.LoadUserWithPermissions(),
+		handler.DeleteReviewImage)
+
+	// Official reply of the place - place owners and users granted can_respond_review
+	reviews.Put("/:id/reply", middleware.AuthRequiredWithRBAC, handler.SaveReply)
+	reviews.Delete("/:id/reply", middleware.AuthRequiredWithRBAC, handler.DeleteReply)
+	reviews.Get("/:id/reply/history", middleware.AuthRequiredWithRBAC, handler.GetReplyHistory)
+
+	// Helpful votes - any signed in user except the author
+	reviews.Post("/:id/helpful", middleware.AuthRequiredWithRBAC, handler.MarkHelpful)
+	reviews.Delete("/:id/helpful", middleware.AuthRequiredWithRBAC, handler.UnmarkHelpful)
+	reviews.Post("/:id/unhelpful", middleware.AuthRequiredWithRBAC, handler.MarkUnhelpful)
+	reviews.Delete("/:id/unhelpful", middleware.AuthRequiredWithRBAC, handler.UnmarkUnhelpful)
+
+	// Reporting and moderation
+	reviews.Post("/:id/report", middleware.AuthRequiredWithRBAC, handler.ReportReview)
+
+	reviews.Get("/moderation/queue",
+		middleware.AuthRequiredWithRBAC,
+		middleware.RequirePermission("can_moderate_review"),
+		handler.GetModerationQueue)
+
+	reviews.Get("/moderation/log",
+		middleware.AuthRequiredWithRBAC,
+		middleware.RequirePermission("can_moderate_review"),
+		handler.GetModerationLog)
+
+	reviews.Post("/:id/moderate",
+		middleware.AuthRequiredWithRBAC,
+		middleware.RequirePermission("can_moderate_review"),
+		handler.ModerateReview)
+
+	reviews.Get("/:id/history",
+		middleware.AuthRequiredWithRBAC,
+		middleware.RequirePermission("can_moderate_review"),
+		handler.GetReviewHistory)
+
 	// Public routes
 	reviews.Get("/place/:placeId", handler.GetReviewsByPlace)
 	reviews.Get("/:id", handler.GetReview)
 }
 
+// CreateReview accepts a JSON body, or a multipart form when photos are attached
 func (h *ReviewHandler) CreateReview(ctx *fiber.Ctx) error {
 	var req dto.CreateReviewRequest
-	if err := ctx.BodyParser(&req); err != nil {
+	if isMultipartRequest(ctx) {
+		form, err := ctx.MultipartForm()
+		if err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Failed to parse multipart form"))
+		}
+		defer form.RemoveAll()
+
+		if err := parseCreateReviewForm(form, &req); err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+		}
+	} else if err := ctx.BodyParser(&req); err != nil {
 		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
 	}
 
@@ -48,19 +114,20 @@ func (h *ReviewHandler) CreateReview(ctx *fiber.Ctx) error {
 	// 🔄 ORIGINAL: Your existing create logic
 	response, err := services.CreateReview(req, userID)
 	if err != nil {
+		if strings.HasPrefix(err.Error(), "you have already reviewed") {
+			return ctx.Status(http.StatusConflict).JSON(utils.ErrorResponse(err.Error()))
+		}
 		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
 	}
 
 	// 🔧 REDIS CACHE: Invalidate related caches after successful creation
-	go func() {
-		cache.Delete(fmt.Sprintf("reviews_place_%s", req.PlaceID.String()))
-		cache.Delete(fmt.Sprintf("place_%s", req.PlaceID.String())) // Place data may include review stats
-		cache.DeletePattern("reviews_*") // Any review-related caches
-	}()
+	go invalidateReviewCaches(response.ID, req.PlaceID)
 
 	return ctx.Status(http.StatusCreated).JSON(utils.SuccessResponse("Review created successfully", response))
 }
 
+// GetReviewsByPlace returns a page of reviews. Query: sort_by (newest, oldest, most_helpful,
+// highest, lowest), rating (comma separated stars, e.g. 4,5), page and page_size.
 func (h *ReviewHandler) GetReviewsByPlace(ctx *fiber.Ctx) error {
 	placeIdStr := ctx.Params("placeId")
 	placeId, err := uuid.Parse(placeIdStr)
@@ -68,26 +135,45 @@ func (h *ReviewHandler) GetReviewsByPlace(ctx *fiber.Ctx) error {
 		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid place ID"))
 	}
 
+	filters := dto.ReviewFilters{
+		SortBy:   ctx.Query("sort_by"),
+		Page:     ctx.QueryInt("page", 1),
+		PageSize: ctx.QueryInt("page_size", 20),
+	}
+	if ratings := ctx.Query("rating"); ratings != "" {
+		for _, value := range strings.Split(ratings, ",") {
+			rating, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid rating filter"))
+			}
+			filters.Ratings = append(filters.Ratings, rating)
+		}
+	}
+
 	// 🔧 REDIS CACHE: Try cache first
-	cacheKey := fmt.Sprintf("reviews_place_%s", placeId.String())
-	var reviews []dto.ReviewResponse
-	
+	cacheKey := fmt.Sprintf("reviews_place_%s_%s_%s_%d_%d", placeId.String(), filters.SortBy,
+		ctx.Query("rating"), filters.Page, filters.PageSize)
+	var reviews dto.ReviewListResponse
+
 	if err := cache.Get(cacheKey, &reviews); err == nil {
 		ctx.Set("X-Cache", "HIT")
 		return ctx.JSON(utils.SuccessResponse("Reviews retrieved successfully", reviews))
 	}
 
 	// 🔄 ORIGINAL: Your existing database call
-	reviews, err = services.GetReviewsByPlaceID(placeId)
+	result, err := services.GetReviewsByPlaceID(placeId, filters)
 	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+		}
 		return ctx.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse(err.Error()))
 	}
 
 	// 🔧 REDIS CACHE: Store in cache (background, doesn't block response)
-	go cache.Set(cacheKey, reviews, cache.MediumTTL) // Reviews change somewhat frequently
+	go cache.Set(cacheKey, *result, cache.MediumTTL) // Reviews change somewhat frequently
 	ctx.Set("X-Cache", "MISS")
 
-	return ctx.JSON(utils.SuccessResponse("Reviews retrieved successfully", reviews))
+	return ctx.JSON(utils.SuccessResponse("Reviews retrieved successfully", result))
 }
 
 func (h *ReviewHandler) GetReview(ctx *fiber.Ctx) error {
@@ -107,7 +193,7 @@ func (h *ReviewHandler) GetReview(ctx *fiber.Ctx) error {
 	}
 
 	// 🔄 ORIGINAL: Your existing database call
-	reviewPtr, err := services.GetReviewByID(id)
+	reviewPtr, err := services.GetVisibleReviewByID(id)
 	if err != nil {
 		return ctx.Status(http.StatusNotFound).JSON(utils.ErrorResponse("Review not found"))
 	}
@@ -117,4 +203,451 @@ func (h *ReviewHandler) GetReview(ctx *fiber.Ctx) error {
 	ctx.Set("X-Cache", "MISS")
 
 	return ctx.JSON(utils.SuccessResponse("Review retrieved successfully", *reviewPtr))
-}
\ No newline at end of file
+}
+func (h *ReviewHandler) UpdateReview(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid review ID"))
+	}
+
+	var req dto.UpdateReviewRequest
+	if isMultipartRequest(ctx) {
+		form, err := ctx.MultipartForm()
+		if err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Failed to parse multipart form"))
+		}
+		defer form.RemoveAll()
+
+		if err := parseUpdateReviewForm(form, &req); err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+		}
+	} else if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	response, err := services.UpdateReview(id, req, userID)
+	if err != nil {
+		return reviewError(ctx, err)
+	}
+
+	// 🔧 REDIS CACHE: Invalidate related caches after update
+	go invalidateReviewCaches(id, response.PlaceID)
+
+	return ctx.JSON(utils.SuccessResponse("Review updated successfully", response))
+}
+
+func (h *ReviewHandler) DeleteReview(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid review ID"))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	placeID, err := services.DeleteReview(id, userID)
+	if err != nil {
+		return reviewError(ctx, err)
+	}
+
+	// 🔧 REDIS CACHE: Invalidate related caches after deletion
+	go invalidateReviewCaches(id, placeID)
+
+	return ctx.JSON(utils.SuccessResponse("Review deleted successfully", nil))
+}
+
+// GetReviewHistory lists what a review said before each of its edits
+func (h *ReviewHandler) GetReviewHistory(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid review ID"))
+	}
+
+	history, err := services.GetReviewHistory(id)
+	if err != nil {
+		return reviewError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Review history retrieved successfully", history))
+}
+
+func (h *ReviewHandler) DeleteReviewImage(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid review ID"))
+	}
+	imageID, err := uuid.Parse(ctx.Params("imageId"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid photo ID"))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	placeID, err := services.DeleteReviewImage(id, imageID, userID)
+	if err != nil {
+		return reviewError(ctx, err)
+	}
+
+	// 🔧 REDIS CACHE: Invalidate related caches after delete
+	go invalidateReviewCaches(id, placeID)
+
+	return ctx.JSON(utils.SuccessResponse("Photo deleted successfully", nil))
+}
+
+// SaveReply posts the official reply to a review or edits the existing one
+func (h *ReviewHandler) SaveReply(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid review ID"))
+	}
+
+	var req dto.ReviewReplyRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	response, err := services.SaveReviewReply(id, userID, req)
+	if err != nil {
+		return reviewError(ctx, err)
+	}
+
+	// 🔧 REDIS CACHE: Replies are embedded in cached reviews
+	go func() {
+		cache.Delete(fmt.Sprintf("review_%s", id.String()))
+		cache.DeletePattern("reviews_*")
+	}()
+
+	return ctx.JSON(utils.SuccessResponse("Reply saved successfully", response))
+}
+
+func (h *ReviewHandler) DeleteReply(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid review ID"))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	if err := services.DeleteReviewReply(id, userID); err != nil {
+		return reviewError(ctx, err)
+	}
+
+	// 🔧 REDIS CACHE: Replies are embedded in cached reviews
+	go func() {
+		cache.Delete(fmt.Sprintf("review_%s", id.String()))
+		cache.DeletePattern("reviews_*")
+	}()
+
+	return ctx.JSON(utils.SuccessResponse("Reply deleted successfully", nil))
+}
+
+func (h *ReviewHandler) GetReplyHistory(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid review ID"))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	history, err := services.GetReviewReplyHistory(id, userID)
+	if err != nil {
+		return reviewError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Reply history retrieved successfully", history))
+}
+
+func (h *ReviewHandler) MarkHelpful(ctx *fiber.Ctx) error {
+	return h.changeVote(ctx, domain.ReviewVoteHelpful, true)
+}
+
+func (h *ReviewHandler) UnmarkHelpful(ctx *fiber.Ctx) error {
+	return h.changeVote(ctx, domain.ReviewVoteHelpful, false)
+}
+
+func (h *ReviewHandler) MarkUnhelpful(ctx *fiber.Ctx) error {
+	return h.changeVote(ctx, domain.ReviewVoteUnhelpful, true)
+}
+
+func (h *ReviewHandler) UnmarkUnhelpful(ctx *fiber.Ctx) error {
+	return h.changeVote(ctx, domain.ReviewVoteUnhelpful, false)
+}
+
+func (h *ReviewHandler) changeVote(ctx *fiber.Ctx, vote string, add bool) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid review ID"))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	var response *dto.ReviewVoteResponse
+	if add {
+		response, err = services.VoteOnReview(id, userID, vote)
+	} else {
+		response, err = services.RemoveReviewVote(id, userID, vote)
+	}
+	if err != nil {
+		return reviewError(ctx, err)
+	}
+
+	// 🔧 REDIS CACHE: Vote counts are part of cached reviews
+	go func() {
+		cache.Delete(fmt.Sprintf("review_%s", id.String()))
+		cache.DeletePattern("reviews_*")
+	}()
+
+	return ctx.JSON(utils.SuccessResponse("Vote updated successfully", response))
+}
+
+func (h *ReviewHandler) ReportReview(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid review ID"))
+	}
+
+	var req dto.ReportReviewRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	response, err := services.ReportReview(id, userID, req)
+	if err != nil {
+		return reviewError(ctx, err)
+	}
+
+	// 🔧 REDIS CACHE: The report may have hidden the review
+	go func() {
+		cache.Delete(fmt.Sprintf("review_%s", id.String()))
+		cache.DeletePattern("reviews_*")
+	}()
+
+	return ctx.Status(http.StatusCreated).JSON(utils.SuccessResponse("Review reported successfully", response))
+}
+
+// GetModerationQueue lists reported reviews. Query: hidden (true/false), reason, page, limit.
+func (h *ReviewHandler) GetModerationQueue(ctx *fiber.Ctx) error {
+	page := ctx.QueryInt("page", 1)
+	if page < 1 {
+		page = 1
+	}
+	limit := ctx.QueryInt("limit", 20)
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	var hidden *bool
+	if hiddenParam := ctx.Query("hidden"); hiddenParam != "" {
+		value, err := strconv.ParseBool(hiddenParam)
+		if err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid hidden filter"))
+		}
+		hidden = &value
+	}
+
+	result, err := services.GetReviewModerationQueue(hidden, ctx.Query("reason"), page, limit)
+	if err != nil {
+		return reviewError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Moderation queue retrieved successfully", result))
+}
+
+// GetModerationLog lists moderation actions. Query: review_id, action, page, limit.
+func (h *ReviewHandler) GetModerationLog(ctx *fiber.Ctx) error {
+	page := ctx.QueryInt("page", 1)
+	if page < 1 {
+		page = 1
+	}
+	limit := ctx.QueryInt("limit", 20)
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	var reviewID *uuid.UUID
+	if reviewParam := ctx.Query("review_id"); reviewParam != "" {
+		id, err := uuid.Parse(reviewParam)
+		if err != nil {
+			return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid review ID"))
+		}
+		reviewID = &id
+	}
+
+	result, err := services.GetReviewModerationLog(reviewID, ctx.Query("action"), page, limit)
+	if err != nil {
+		return reviewError(ctx, err)
+	}
+
+	return ctx.JSON(utils.SuccessResponse("Moderation log retrieved successfully", result))
+}
+
+func (h *ReviewHandler) ModerateReview(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid review ID"))
+	}
+
+	var req dto.ReviewModerationRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse("Invalid request body"))
+	}
+
+	if err := utils.ValidateStruct(req); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(err.Error()))
+	}
+
+	moderatorID := ctx.Locals("userID").(uuid.UUID)
+
+	response, err := services.ModerateReview(id, moderatorID, req)
+	if err != nil {
+		return reviewError(ctx, err)
+	}
+
+	// 🔧 REDIS CACHE: Visibility and the place rating may have changed
+	go invalidateReviewCaches(id, response.PlaceID)
+
+	return ctx.JSON(utils.SuccessResponse("Review moderated successfully", response))
+}
+
+// invalidateReviewCaches drops cached reviews and the place responses that include the rating
+func invalidateReviewCaches(reviewID uuid.UUID, placeID uuid.UUID) {
+	cache.Delete(fmt.Sprintf("review_%s", reviewID.String()))
+	cache.DeletePattern("reviews_*")
+	invalidatePlaceCaches(placeID)
+}
+
+// reviewError maps review service errors to HTTP status codes
+func reviewError(ctx *fiber.Ctx, err error) error {
+	message := err.Error()
+	switch {
+	case strings.HasPrefix(message, "unauthorized"):
+		return ctx.Status(http.StatusForbidden).JSON(utils.ErrorResponse(message))
+	case strings.HasSuffix(message, "not found"):
+		return ctx.Status(http.StatusNotFound).JSON(utils.ErrorResponse(message))
+	case strings.HasPrefix(message, "you have already"):
+		return ctx.Status(http.StatusConflict).JSON(utils.ErrorResponse(message))
+	case strings.HasPrefix(message, "invalid"), strings.HasPrefix(message, "a comment is required"),
+		strings.HasPrefix(message, "too many photos"):
+		return ctx.Status(http.StatusBadRequest).JSON(utils.ErrorResponse(message))
+	default:
+		return ctx.Status(http.StatusInternalServerError).JSON(utils.ErrorResponse(message))
+	}
+}
+
+// isMultipartRequest reports whether a review was sent as a multipart form with photos
+func isMultipartRequest(ctx *fiber.Ctx) bool {
+	return strings.HasPrefix(string(ctx.Request().Header.ContentType()), fiber.MIMEMultipartForm)
+}
+
+// parseCreateReviewForm reads the fields of CreateReviewRequest from a multipart form
+func parseCreateReviewForm(form *multipart.Form, req *dto.CreateReviewRequest) error {
+	placeID, err := uuid.Parse(reviewFormValue(form, "place_id"))
+	if err != nil {
+		return errors.New("Invalid place ID")
+	}
+	req.PlaceID = placeID
+
+	if req.Rating, err = strconv.Atoi(reviewFormValue(form, "rating")); err != nil {
+		return errors.New("Invalid rating")
+	}
+	req.Title = reviewFormValue(form, "title")
+	req.ReviewText = reviewFormValue(form, "review_text")
+
+	if value := reviewFormValue(form, "visit_date"); value != "" {
+		visitDate, err := parseReviewFormDate(value)
+		if err != nil {
+			return err
+		}
+		req.VisitDate = &visitDate
+	}
+
+	req.Images = reviewFormImages(form)
+	return nil
+}
+
+// parseUpdateReviewForm reads the fields of UpdateReviewRequest from a multipart form. Like
+// in JSON, only the fields that are sent are changed.
+func parseUpdateReviewForm(form *multipart.Form, req *dto.UpdateReviewRequest) error {
+	if value, ok := reviewFormField(form, "rating"); ok {
+		rating, err := strconv.Atoi(value)
+		if err != nil {
+			return errors.New("Invalid rating")
+		}
+		req.Rating = &rating
+	}
+	if value, ok := reviewFormField(form, "title"); ok {
+		req.Title = &value
+	}
+	if value, ok := reviewFormField(form, "review_text"); ok {
+		req.ReviewText = &value
+	}
+	if value, ok := reviewFormField(form, "visit_date"); ok && value != "" {
+		visitDate, err := parseReviewFormDate(value)
+		if err != nil {
+			return err
+		}
+		req.VisitDate = &visitDate
+	}
+
+	req.Images = reviewFormImages(form)
+	return nil
+}
+
+// reviewFormImages returns the photos sent in "images", each with the caption at the same
+// position in "captions"
+func reviewFormImages(form *multipart.Form) []dto.ReviewImageUpload {
+	files := form.File["images"]
+	captions := form.Value["captions"]
+
+	uploads := make([]dto.ReviewImageUpload, 0, len(files))
+	for i, file := range files {
+		upload := dto.ReviewImageUpload{File: file}
+		if i < len(captions) {
+			upload.Caption = captions[i]
+		}
+		uploads = append(uploads, upload)
+	}
+	return uploads
+}
+
+func reviewFormField(form *multipart.Form, key string) (string, bool) {
+	values, ok := form.Value[key]
+	if !ok || len(values) == 0 {
+		return "", false
+	}
+	return values[0], true
+}
+
+func reviewFormValue(form *multipart.Form, key string) string {
+	value, _ := reviewFormField(form, key)
+	return value
+}
+
+// parseReviewFormDate accepts a visit date as 2006-01-02 or RFC 3339
+func parseReviewFormDate(value string) (time.Time, error) {
+	if date, err := time.Parse("2006-01-02", value); err == nil {
+		return date, nil
+	}
+	date, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.New("Invalid visit date")
+	}
+	return date, nil
+}
@@ -152,6 +152,7 @@ func (h *WilayahHandler) UpdateWilayah(ctx *fiber.Ctx) error {
 		cache.DeletePattern("governate_wilayahs_*")
 		cache.DeletePattern("wilayah_search_*")
 		cache.DeletePattern("places_wilayah_*")
+		cache.DeletePattern("places_search_*") // Search matches wilayah names
 	}()
 
 	return ctx.JSON(utils.SuccessResponse("Wilayah updated successfully", wilayah))
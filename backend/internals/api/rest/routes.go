@@ -28,6 +28,10 @@ func SetupRoutes(app *fiber.App, cfg config.AppConfig) {
 	handlers.SetupWilayahRoutes(app)
 	handlers.SetupRBACRoutes(app)
 	handlers.SetupReviewRoutes(app)
+	handlers.SetupFavoriteRoutes(app)
+	handlers.SetupItineraryRoutes(app)
+	handlers.SetupAdviceRoutes(app)
+	handlers.SetupPreviewRoutes(app)
 	handlers.SetupAdminRBACRoutes(app)
 	handlers.SetupPropertyRoutes(app)
 	handlers.SetupUserManagementRoutes(app)
@@ -39,4 +43,7 @@ func SetupRoutes(app *fiber.App, cfg config.AppConfig) {
 	handlers.SetupDishRoutes(app)
 	handlers.SetupListRoutes(app)
 	handlers.SetupWilayahImageRoutes(app)
+	handlers.SetupOpeningHoursRoutes(app)
+	handlers.SetupPlaceRevisionRoutes(app)
+	handlers.SetupNotificationRoutes(app)
 }
\ No newline at end of file
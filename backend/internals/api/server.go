@@ -22,6 +22,27 @@ func StartServer(cfg config.AppConfig) {
 		log.Printf("Warning: Failed to initialize auth config: %v", err)
 	}
 
+	// Index places and generate slugs for places created before search and slugs existed
+	go func() {
+		if indexed, err := services.EnsurePlaceSearchDocuments(); err != nil {
+			log.Printf("Warning: Failed to build place search index: %v", err)
+		} else if indexed > 0 {
+			log.Printf("Indexed %d places for search", indexed)
+		}
+
+		if updated, err := services.EnsurePlaceSlugs(); err != nil {
+			log.Printf("Warning: Failed to generate place slugs: %v", err)
+		} else if updated > 0 {
+			log.Printf("Generated slugs for %d places", updated)
+		}
+	}()
+
+	// Advice views are counted in Redis and saved periodically
+	services.StartAdviceViewFlusher()
+
+	// Scheduled list publishing and unpublishing
+	services.StartListScheduler()
+
 	// Create Fiber app
 	app := fiber.New(fiber.Config{
 		ErrorHandler: func(c *fiber.Ctx, err error) error {
@@ -72,6 +93,10 @@ func setupRoutes(app *fiber.App) {
 	handlers.SetupWilayahRoutes(app)
 	handlers.SetupRBACRoutes(app)
 	handlers.SetupReviewRoutes(app)
+	handlers.SetupFavoriteRoutes(app)
+	handlers.SetupItineraryRoutes(app)
+	handlers.SetupAdviceRoutes(app)
+	handlers.SetupPreviewRoutes(app)
 	handlers.SetupAdminRBACRoutes(app)
 	handlers.SetupPropertyRoutes(app)
 	handlers.SetupUserManagementRoutes(app) 
@@ -83,4 +108,7 @@ func setupRoutes(app *fiber.App) {
 	handlers.SetupDishRoutes(app)
 	handlers.SetupListRoutes(app)
 	handlers.SetupWilayahImageRoutes(app)
+	handlers.SetupOpeningHoursRoutes(app)
+	handlers.SetupPlaceRevisionRoutes(app)
+	handlers.SetupNotificationRoutes(app)
 }
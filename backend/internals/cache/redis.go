@@ -381,4 +381,87 @@ func InvalidateKeys(keys ...string) {
 			}
 		}
 	}()
-}
\ No newline at end of file
+}
+
+// IncrementPending adds delta to a counter and records member in pendingSet, so a periodic
+// flush can find the counters that changed
+func IncrementPending(counterKey, pendingSet, member string, delta int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	if Client == nil {
+		return fmt.Errorf("redis client not initialized")
+	}
+
+	_, err := Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.IncrBy(ctx, counterKey, delta)
+		pipe.SAdd(ctx, pendingSet, member)
+		return nil
+	})
+	if err != nil {
+		cacheErrors.Inc()
+		return fmt.Errorf("failed to increment counter %s: %v", counterKey, err)
+	}
+
+	return nil
+}
+
+// GetCounter returns the value of a counter, 0 when it doesn't exist
+func GetCounter(counterKey string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	if Client == nil {
+		return 0, fmt.Errorf("redis client not initialized")
+	}
+
+	value, err := Client.Get(ctx, counterKey).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	if err != nil {
+		cacheErrors.Inc()
+		return 0, fmt.Errorf("failed to get counter %s: %v", counterKey, err)
+	}
+
+	return value, nil
+}
+
+// PopPending removes up to count members from pendingSet and returns them
+func PopPending(pendingSet string, count int64) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	if Client == nil {
+		return nil, fmt.Errorf("redis client not initialized")
+	}
+
+	members, err := Client.SPopN(ctx, pendingSet, count).Result()
+	if err != nil && err != redis.Nil {
+		cacheErrors.Inc()
+		return nil, fmt.Errorf("failed to pop pending members of %s: %v", pendingSet, err)
+	}
+
+	return members, nil
+}
+
+// TakeCounter returns the value of a counter and deletes it
+func TakeCounter(counterKey string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	if Client == nil {
+		return 0, fmt.Errorf("redis client not initialized")
+	}
+
+	value, err := Client.GetDel(ctx, counterKey).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	if err != nil {
+		cacheErrors.Inc()
+		return 0, fmt.Errorf("failed to take counter %s: %v", counterKey, err)
+	}
+
+	return value, nil
+}
@@ -7,23 +7,43 @@ import (
 	"gorm.io/gorm"
 )
 
+// Advice types
+const (
+	AdviceTypeGeneral   = "general"
+	AdviceTypeSafety    = "safety"
+	AdviceTypeCulture   = "culture"
+	AdviceTypeTransport = "transport"
+	AdviceTypeWeather   = "weather"
+	AdviceTypeMoney     = "money"
+	AdviceTypeHealth    = "health"
+	AdviceTypePacking   = "packing"
+)
+
+// Advice is a travel tip. It can apply to a governate, a wilayah and any number of places;
+// without a region it applies to the whole country.
 type Advice struct {
-	ID                 uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey"`
-	Title              string         `json:"title" gorm:"not null"`
-	Content            string         `json:"content" gorm:"type:text;not null"`
-	AdviceType         string         `json:"advice_type"`
-	DestinationCity    string         `json:"destination_city"`
-	DestinationCountry string         `json:"destination_country"`
-	CreatedBy          uuid.UUID      `json:"created_by" gorm:"type:uuid"`
-	IsFeatured         bool           `json:"is_featured" gorm:"default:false"`
-	ViewCount          int            `json:"view_count" gorm:"default:0"`
-	IsPublished        bool           `json:"is_published" gorm:"default:true"`
-	CreatedAt          time.Time      `json:"created_at"`
-	UpdatedAt          time.Time      `json:"updated_at"`
-	DeletedAt          gorm.DeletedAt `json:"-" gorm:"index"`
+	ID          uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey"`
+	TitleAr     string         `json:"title_ar" gorm:"type:varchar(200);not null"`
+	TitleEn     string         `json:"title_en" gorm:"type:varchar(200);not null"`
+	ContentAr   string         `json:"content_ar" gorm:"type:text;not null"`
+	ContentEn   string         `json:"content_en" gorm:"type:text;not null"`
+	AdviceType  string         `json:"advice_type" gorm:"type:varchar(20);not null;default:'general';index"`
+	GovernateID *uuid.UUID     `json:"governate_id" gorm:"type:uuid;index"`
+	WilayahID   *uuid.UUID     `json:"wilayah_id" gorm:"type:uuid;index"`
+	CreatedBy   uuid.UUID      `json:"created_by" gorm:"type:uuid"`
+	IsFeatured  bool           `json:"is_featured" gorm:"default:false"`
+	ViewCount   int            `json:"view_count" gorm:"default:0"`
+	IsPublished bool           `json:"is_published" gorm:"default:false;index"`
+	PublishedAt *time.Time     `json:"published_at"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
 
 	// Relationships
-	Creator User `json:"creator" gorm:"foreignKey:CreatedBy;references:ID"`
+	Creator   User       `json:"creator" gorm:"foreignKey:CreatedBy;references:ID"`
+	Governate *Governate `json:"governate,omitempty" gorm:"foreignKey:GovernateID;references:ID"`
+	Wilayah   *Wilayah   `json:"wilayah,omitempty" gorm:"foreignKey:WilayahID;references:ID"`
+	Places    []Place    `json:"places,omitempty" gorm:"many2many:advice_places"`
 }
 
 // BeforeCreate hook to generate UUID
@@ -32,4 +52,19 @@ func (a *Advice) BeforeCreate(tx *gorm.DB) error {
 		a.ID = uuid.New()
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+// Helper methods to get localized content
+func (a *Advice) GetTitle(lang string) string {
+	if lang == "ar" {
+		return a.TitleAr
+	}
+	return a.TitleEn
+}
+
+func (a *Advice) GetContent(lang string) string {
+	if lang == "ar" {
+		return a.ContentAr
+	}
+	return a.ContentEn
+}
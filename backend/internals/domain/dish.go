@@ -31,6 +31,8 @@ type Dish struct {
 	Governate *Governate  `json:"governate,omitempty" gorm:"foreignKey:GovernateID;references:ID"`
 	Creator   User        `json:"creator" gorm:"foreignKey:CreatedBy;references:ID"`
 	Images    []DishImage `json:"images" gorm:"foreignKey:DishID;references:ID"`
+	ServedAt  []DishPlace `json:"served_at,omitempty" gorm:"foreignKey:DishID;references:ID"`
+	Tags      []DishTag   `json:"tags" gorm:"many2many:dish_tag_links;constraint:OnDelete:CASCADE"`
 }
 
 // BeforeCreate hook to generate UUID
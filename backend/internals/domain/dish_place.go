@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DishPlace says a place serves a dish, e.g. where to eat Shuwa. Prices are hints in Omani
+// rials for one serving.
+type DishPlace struct {
+	DishID      uuid.UUID  `json:"dish_id" gorm:"type:uuid;primaryKey"`
+	PlaceID     uuid.UUID  `json:"place_id" gorm:"type:uuid;primaryKey;index"`
+	NoteAr      string     `json:"note_ar" gorm:"type:text"`
+	NoteEn      string     `json:"note_en" gorm:"type:text"`
+	PriceMin    *float64   `json:"price_min" gorm:"type:decimal(10,3)"`
+	PriceMax    *float64   `json:"price_max" gorm:"type:decimal(10,3)"`
+	IsSignature bool       `json:"is_signature" gorm:"default:false"` // The place is known for this dish
+	CreatedBy   *uuid.UUID `json:"created_by" gorm:"type:uuid"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+
+	// Relationships
+	Dish  Dish  `json:"-" gorm:"foreignKey:DishID;references:ID;constraint:OnDelete:CASCADE"`
+	Place Place `json:"-" gorm:"foreignKey:PlaceID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+// GetNote returns the localized note
+func (dp *DishPlace) GetNote(lang string) string {
+	if lang == "ar" {
+		return dp.NoteAr
+	}
+	return dp.NoteEn
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Dish tag types
+const (
+	DishTagTypeDietary    = "dietary"    // e.g. vegetarian, halal
+	DishTagTypeAllergen   = "allergen"   // e.g. nuts, dairy
+	DishTagTypeIngredient = "ingredient" // Main ingredients, e.g. rice, goat, dates
+)
+
+// DishTag is one entry of the managed vocabulary of dietary tags, allergens and main
+// ingredients that can be assigned to dishes
+type DishTag struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	Type      string    `json:"type" gorm:"type:varchar(20);not null;index"` // dietary, allergen, ingredient
+	Slug      string    `json:"slug" gorm:"type:varchar(100);uniqueIndex;not null"`
+	NameAr    string    `json:"name_ar" gorm:"not null"`
+	NameEn    string    `json:"name_en" gorm:"not null"`
+	Icon      string    `json:"icon"`
+	SortOrder int       `json:"sort_order" gorm:"default:0"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// BeforeCreate hook to generate UUID
+func (t *DishTag) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+// GetName returns the localized tag name
+func (t *DishTag) GetName(lang string) string {
+	if lang == "ar" {
+		return t.NameAr
+	}
+	return t.NameEn
+}
@@ -9,8 +9,8 @@ import (
 
 type UserFavorite struct {
 	ID      uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
-	UserID  uuid.UUID `json:"user_id" gorm:"type:uuid;not null"`
-	PlaceID uuid.UUID `json:"place_id" gorm:"type:uuid;not null"`
+	UserID  uuid.UUID `json:"user_id" gorm:"type:uuid;not null;uniqueIndex:idx_user_favorites_user_place"`
+	PlaceID uuid.UUID `json:"place_id" gorm:"type:uuid;not null;uniqueIndex:idx_user_favorites_user_place;index"`
 	AddedAt time.Time `json:"added_at" gorm:"default:CURRENT_TIMESTAMP"`
 
 	// Relationships
@@ -18,10 +18,43 @@ type UserFavorite struct {
 	Place Place `json:"place" gorm:"foreignKey:PlaceID;references:ID"`
 }
 
+// FavoriteCollection is a named group of a user's favorite places, e.g. "Winter trip to Dhofar"
+type FavoriteCollection struct {
+	ID          uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	UserID      uuid.UUID `json:"user_id" gorm:"type:uuid;not null;uniqueIndex:idx_favorite_collections_user_name"`
+	Name        string    `json:"name" gorm:"type:varchar(100);not null;uniqueIndex:idx_favorite_collections_user_name"`
+	Description string    `json:"description" gorm:"type:text"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	// Relationships
+	User User `json:"-" gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+// FavoriteCollectionPlace puts a favorite in a collection. Removing the favorite takes it
+// out of every collection.
+type FavoriteCollectionPlace struct {
+	CollectionID uuid.UUID `json:"collection_id" gorm:"type:uuid;primaryKey"`
+	FavoriteID   uuid.UUID `json:"favorite_id" gorm:"type:uuid;primaryKey;index"`
+	AddedAt      time.Time `json:"added_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	// Relationships
+	Collection FavoriteCollection `json:"-" gorm:"foreignKey:CollectionID;references:ID;constraint:OnDelete:CASCADE"`
+	Favorite   UserFavorite       `json:"-" gorm:"foreignKey:FavoriteID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
 // BeforeCreate hook to generate UUID
 func (uf *UserFavorite) BeforeCreate(tx *gorm.DB) error {
 	if uf.ID == uuid.Nil {
 		uf.ID = uuid.New()
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+// BeforeCreate hook to generate UUID
+func (fc *FavoriteCollection) BeforeCreate(tx *gorm.DB) error {
+	if fc.ID == uuid.Nil {
+		fc.ID = uuid.New()
+	}
+	return nil
+}
@@ -30,7 +30,7 @@ func (pi *PlaceImage) BeforeCreate(tx *gorm.DB) error {
 
 type ReviewImage struct {
 	ID         uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
-	ReviewID   uuid.UUID `json:"review_id" gorm:"type:uuid;not null"`
+	ReviewID   uuid.UUID `json:"review_id" gorm:"type:uuid;not null;index"`
 	ImageURL   string    `json:"image_url" gorm:"not null"`
 	Caption    string    `json:"caption"`
 	UploadDate time.Time `json:"upload_date" gorm:"default:CURRENT_TIMESTAMP"`
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Itinerary stop types
+const (
+	ItineraryStopPlace = "place"
+	ItineraryStopDish  = "dish"
+	ItineraryStopNote  = "note"
+)
+
+// Itinerary is a user's multi-day trip plan. It is private to its owner unless shared
+// through its share token, which gives read-only access.
+type Itinerary struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	UserID      uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;index"`
+	Title       string     `json:"title" gorm:"type:varchar(200);not null"`
+	Description string     `json:"description" gorm:"type:text"`
+	StartDate   *time.Time `json:"start_date" gorm:"type:date"`
+	ShareToken  *string    `json:"-" gorm:"type:varchar(64);uniqueIndex"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+
+	// Relationships
+	User User           `json:"-" gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE"`
+	Days []ItineraryDay `json:"days" gorm:"foreignKey:ItineraryID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+// ItineraryDay is one day of a trip. DayNumber starts at 1 and has no gaps.
+type ItineraryDay struct {
+	ID          uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	ItineraryID uuid.UUID `json:"itinerary_id" gorm:"type:uuid;not null;index"`
+	DayNumber   int       `json:"day_number" gorm:"not null"`
+	Title       string    `json:"title" gorm:"type:varchar(200)"`
+	Notes       string    `json:"notes" gorm:"type:text"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	// Relationships
+	Stops []ItineraryStop `json:"stops" gorm:"foreignKey:DayID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+// ItineraryStop is a place to visit, a dish to try or a free-text note, in visiting order
+type ItineraryStop struct {
+	ID              uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	DayID           uuid.UUID  `json:"day_id" gorm:"type:uuid;not null;index"`
+	StopType        string     `json:"stop_type" gorm:"type:varchar(20);not null"` // place, dish, note
+	PlaceID         *uuid.UUID `json:"place_id" gorm:"type:uuid;index"`
+	DishID          *uuid.UUID `json:"dish_id" gorm:"type:uuid;index"`
+	Note            string     `json:"note" gorm:"type:text"`
+	DurationMinutes *int       `json:"duration_minutes"` // Planned time spent at the stop
+	SortOrder       int        `json:"sort_order" gorm:"not null;default:0"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+
+	// Relationships
+	Place *Place `json:"place,omitempty" gorm:"foreignKey:PlaceID;references:ID"`
+	Dish  *Dish  `json:"dish,omitempty" gorm:"foreignKey:DishID;references:ID"`
+}
+
+// BeforeCreate hook to generate UUID
+func (i *Itinerary) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
+
+// BeforeCreate hook to generate UUID
+func (d *ItineraryDay) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return nil
+}
+
+// BeforeCreate hook to generate UUID
+func (s *ItineraryStop) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
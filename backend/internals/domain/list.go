@@ -7,6 +7,13 @@ import (
 	"gorm.io/gorm"
 )
 
+// List statuses
+const (
+	ListStatusDraft     = "draft"
+	ListStatusPublished = "published"
+	ListStatusArchived  = "archived"
+)
+
 type List struct {
 	ID               uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey"`
 	TitleAr          string         `json:"title_ar" gorm:"not null"`
@@ -17,6 +24,8 @@ type List struct {
 	FeaturedImage    string         `json:"featured_image"`
 	Status           string         `json:"status" gorm:"default:'draft'"` // draft, published, archived
 	SortOrder        int            `json:"sort_order" gorm:"default:0"`
+	PublishAt        *time.Time     `json:"publish_at" gorm:"index"`   // The scheduler publishes the list at this time
+	UnpublishAt      *time.Time     `json:"unpublish_at" gorm:"index"` // The scheduler archives the published list at this time
 	CreatedBy        uuid.UUID      `json:"created_by" gorm:"type:uuid"`
 	CreatedAt        time.Time      `json:"created_at"`
 	UpdatedAt        time.Time      `json:"updated_at"`
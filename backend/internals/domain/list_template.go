@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ListTemplate is a reusable skeleton of sections that new lists are started from,
+// e.g. "Best of a governorate" with its usual Breakfast, Lunch and Dinner sections
+type ListTemplate struct {
+	ID            uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey"`
+	NameAr        string         `json:"name_ar" gorm:"not null"`
+	NameEn        string         `json:"name_en" gorm:"not null"`
+	DescriptionAr string         `json:"description_ar" gorm:"type:text"`
+	DescriptionEn string         `json:"description_en" gorm:"type:text"`
+	CreatedBy     uuid.UUID      `json:"created_by" gorm:"type:uuid"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Relationships
+	Sections []ListTemplateSection `json:"sections" gorm:"foreignKey:TemplateID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+// BeforeCreate hook to generate UUID
+func (lt *ListTemplate) BeforeCreate(tx *gorm.DB) error {
+	if lt.ID == uuid.Nil {
+		lt.ID = uuid.New()
+	}
+	return nil
+}
+
+// ListTemplateSection is a section a list instantiated from the template starts with
+type ListTemplateSection struct {
+	ID            uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	TemplateID    uuid.UUID `json:"template_id" gorm:"type:uuid;not null;index"`
+	TitleAr       string    `json:"title_ar" gorm:"not null"`
+	TitleEn       string    `json:"title_en" gorm:"not null"`
+	DescriptionAr string    `json:"description_ar" gorm:"type:text"`
+	DescriptionEn string    `json:"description_en" gorm:"type:text"`
+	SortOrder     int       `json:"sort_order" gorm:"default:0"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// BeforeCreate hook to generate UUID
+func (lts *ListTemplateSection) BeforeCreate(tx *gorm.DB) error {
+	if lts.ID == uuid.Nil {
+		lts.ID = uuid.New()
+	}
+	return nil
+}
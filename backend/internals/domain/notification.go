@@ -0,0 +1,40 @@
+// domain/notification.go
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Notification types
+const (
+	NotificationReviewWarning = "review_warning"
+	NotificationReviewReply   = "review_reply"
+)
+
+// Notification is an in-app notice for a user, e.g. a moderator's warning about one of
+// their reviews. It stays unread until the user opens it.
+type Notification struct {
+	ID         uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	UserID     uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;index:idx_notifications_user_created"`
+	Type       string     `json:"type" gorm:"type:varchar(30);not null"` // See Notification* type constants
+	EntityType string     `json:"entity_type" gorm:"type:varchar(30)"`
+	EntityID   *uuid.UUID `json:"entity_id" gorm:"type:uuid"`
+	PlaceID    *uuid.UUID `json:"place_id" gorm:"type:uuid"`
+	Message    string     `json:"message" gorm:"type:text"`
+	ReadAt     *time.Time `json:"read_at"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"index:idx_notifications_user_created"`
+
+	// Relationships
+	User User `json:"-" gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+// BeforeCreate hook to generate UUID
+func (n *Notification) BeforeCreate(tx *gorm.DB) error {
+	if n.ID == uuid.Nil {
+		n.ID = uuid.New()
+	}
+	return nil
+}
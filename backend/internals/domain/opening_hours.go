@@ -0,0 +1,93 @@
+// domain/opening_hours.go
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PlaceOpeningHours is one opening interval on a weekday (0 = Sunday ... 6 = Saturday).
+// Times are "HH:MM" in Asia/Muscat local time. When ClosesAt is not after OpensAt the
+// interval runs overnight and closes on the following day. "24:00" closes at midnight.
+type PlaceOpeningHours struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	PlaceID   uuid.UUID `json:"place_id" gorm:"type:uuid;not null;index"`
+	Weekday   int       `json:"weekday" gorm:"not null;check:weekday >= 0 AND weekday <= 6"`
+	OpensAt   string    `json:"opens_at" gorm:"type:varchar(5);not null"`
+	ClosesAt  string    `json:"closes_at" gorm:"type:varchar(5);not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Relationships
+	Place Place `json:"-" gorm:"foreignKey:PlaceID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+// PlaceHoursException overrides the weekly schedule for every day between StartDate and
+// EndDate (inclusive), e.g. Ramadan hours, Eid closures or national holidays.
+type PlaceHoursException struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	PlaceID   uuid.UUID `json:"place_id" gorm:"type:uuid;not null;index"`
+	NameAr    string    `json:"name_ar"`
+	NameEn    string    `json:"name_en"`
+	StartDate time.Time `json:"start_date" gorm:"type:date;not null;index"`
+	EndDate   time.Time `json:"end_date" gorm:"type:date;not null;index"`
+	IsClosed  bool      `json:"is_closed" gorm:"default:false"` // Closed all day, intervals are ignored
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Relationships
+	Place     Place                         `json:"-" gorm:"foreignKey:PlaceID;references:ID;constraint:OnDelete:CASCADE"`
+	Intervals []PlaceHoursExceptionInterval `json:"intervals,omitempty" gorm:"foreignKey:ExceptionID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+// PlaceHoursExceptionInterval is an opening interval that applies on each day of an exception
+type PlaceHoursExceptionInterval struct {
+	ID          uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	ExceptionID uuid.UUID `json:"exception_id" gorm:"type:uuid;not null;index"`
+	OpensAt     string    `json:"opens_at" gorm:"type:varchar(5);not null"`
+	ClosesAt    string    `json:"closes_at" gorm:"type:varchar(5);not null"`
+}
+
+// TableName specifies the table name for GORM
+func (PlaceOpeningHours) TableName() string {
+	return "place_opening_hours"
+}
+
+// BeforeCreate hook to generate UUID
+func (h *PlaceOpeningHours) BeforeCreate(tx *gorm.DB) error {
+	if h.ID == uuid.Nil {
+		h.ID = uuid.New()
+	}
+	return nil
+}
+
+// BeforeCreate hook to generate UUID
+func (e *PlaceHoursException) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
+
+// BeforeCreate hook to generate UUID
+func (i *PlaceHoursExceptionInterval) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
+
+// CoversDate reports whether the exception applies on the given "2006-01-02" date
+func (e *PlaceHoursException) CoversDate(date string) bool {
+	return e.StartDate.Format("2006-01-02") <= date && date <= e.EndDate.Format("2006-01-02")
+}
+
+// GetName returns the localized exception name
+func (e *PlaceHoursException) GetName(lang string) string {
+	if lang == "ar" {
+		return e.NameAr
+	}
+	return e.NameEn
+}
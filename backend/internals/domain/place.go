@@ -7,22 +7,44 @@ import (
 	"gorm.io/gorm"
 )
 
+// Editorial statuses of a place. Only published places are visible on public endpoints.
+const (
+	PlaceStatusDraft         = "draft"
+	PlaceStatusPendingReview = "pending_review"
+	PlaceStatusPublished     = "published"
+	PlaceStatusRejected      = "rejected"
+	PlaceStatusArchived      = "archived"
+)
+
 type Place struct {
 	ID            uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey"`
 	NameAr        string         `json:"name_ar" gorm:"not null"`
 	NameEn        string         `json:"name_en" gorm:"not null"`
+	Slug          string         `json:"slug" gorm:"type:varchar(100);uniqueIndex:idx_places_slug,where:slug <> ''"`          // Generated from NameEn
+	SlugAr        string         `json:"slug_ar" gorm:"type:varchar(100);uniqueIndex:idx_places_slug_ar,where:slug_ar <> ''"` // Generated from NameAr
 	DescriptionAr string         `json:"description_ar" gorm:"type:text"`
 	DescriptionEn string         `json:"description_en" gorm:"type:text"`
 	SubtitleAr    string         `json:"subtitle_ar"`
 	SubtitleEn    string         `json:"subtitle_en"`
 	GovernateID   *uuid.UUID     `json:"governate_id" gorm:"type:uuid;index"`
 	WilayahID     *uuid.UUID     `json:"wilayah_id" gorm:"type:uuid;index"`
-	Latitude      float64        `json:"latitude"`
+	Latitude      float64        `json:"latitude"` // Indexed with Longitude by idx_places_location, see MigrateDB
 	Longitude     float64        `json:"longitude"`
 	Phone         string         `json:"phone"`
 	Email         string         `json:"email"`
 	Website       string         `json:"website"`
 	IsActive      bool           `json:"is_active" gorm:"default:true"`
+	Status        string         `json:"status" gorm:"type:varchar(20);not null;default:'published';index"`                                            // See PlaceStatus* constants
+	ExternalRef   string         `json:"external_ref,omitempty" gorm:"type:varchar(100);uniqueIndex:idx_places_external_ref,where:external_ref <> ''"` // ID in the source of a bulk import
+	MergedIntoID  *uuid.UUID     `json:"merged_into_id,omitempty" gorm:"type:uuid;index"`                                                              // Set on duplicates merged into another place
+	RatingAverage float64        `json:"rating_average" gorm:"not null;default:0;index"`                                                               // Review aggregates, see RefreshPlaceRatingStats
+	ReviewCount   int            `json:"review_count" gorm:"not null;default:0;index"`
+	Rating1Count  int            `json:"rating1_count" gorm:"not null;default:0"`
+	Rating2Count  int            `json:"rating2_count" gorm:"not null;default:0"`
+	Rating3Count  int            `json:"rating3_count" gorm:"not null;default:0"`
+	Rating4Count  int            `json:"rating4_count" gorm:"not null;default:0"`
+	Rating5Count  int            `json:"rating5_count" gorm:"not null;default:0"`
+	LastReviewAt  *time.Time     `json:"last_review_at"`
 	CreatedBy     uuid.UUID      `json:"created_by" gorm:"type:uuid"`
 	CreatedAt     time.Time      `json:"created_at"`
 	UpdatedAt     time.Time      `json:"updated_at"`
@@ -40,6 +62,36 @@ type Place struct {
 	ContentSections []PlaceContentSection `json:"content_sections" gorm:"foreignKey:PlaceID;references:ID"`
 }
 
+// PlaceRatingColumns are the review aggregate columns. Saving a whole place must omit them so
+// a stale copy doesn't overwrite counts updated by a concurrent review.
+var PlaceRatingColumns = []string{
+	"rating_average", "review_count", "rating1_count", "rating2_count",
+	"rating3_count", "rating4_count", "rating5_count", "last_review_at",
+}
+
+// PlaceCountedReviews selects the reviews that count towards a place's rating
+const PlaceCountedReviews = "deleted_at IS NULL AND is_hidden = false"
+
+// PlaceRatingAggregates computes the aggregate columns of Place from the reviews table
+const PlaceRatingAggregates = `ROUND(AVG(rating)::numeric, 2) AS rating_average,
+	COUNT(*) AS review_count,
+	COUNT(*) FILTER (WHERE rating = 1) AS rating1_count,
+	COUNT(*) FILTER (WHERE rating = 2) AS rating2_count,
+	COUNT(*) FILTER (WHERE rating = 3) AS rating3_count,
+	COUNT(*) FILTER (WHERE rating = 4) AS rating4_count,
+	COUNT(*) FILTER (WHERE rating = 5) AS rating5_count,
+	MAX(created_at) AS last_review_at`
+
+// PlaceRatingAssignments copies the aggregates of a "stats" subquery onto places
+const PlaceRatingAssignments = `rating_average = COALESCE(stats.rating_average, 0),
+	review_count = COALESCE(stats.review_count, 0),
+	rating1_count = COALESCE(stats.rating1_count, 0),
+	rating2_count = COALESCE(stats.rating2_count, 0),
+	rating3_count = COALESCE(stats.rating3_count, 0),
+	rating4_count = COALESCE(stats.rating4_count, 0),
+	rating5_count = COALESCE(stats.rating5_count, 0),
+	last_review_at = stats.last_review_at`
+
 // BeforeCreate hook to generate UUID
 func (p *Place) BeforeCreate(tx *gorm.DB) error {
 	if p.ID == uuid.Nil {
@@ -56,6 +108,14 @@ func (p *Place) GetName(lang string) string {
 	return p.NameEn
 }
 
+// GetSlug returns the slug for the language; Arabic falls back to the English slug
+func (p *Place) GetSlug(lang string) string {
+	if lang == "ar" && p.SlugAr != "" {
+		return p.SlugAr
+	}
+	return p.Slug
+}
+
 func (p *Place) GetDescription(lang string) string {
 	if lang == "ar" {
 		return p.DescriptionAr
@@ -70,3 +130,7 @@ func (p *Place) GetSubtitle(lang string) string {
 	return p.SubtitleEn
 }
 
+// IsPublished reports whether the place is visible on public endpoints
+func (p *Place) IsPublished() bool {
+	return p.Status == PlaceStatusPublished
+}
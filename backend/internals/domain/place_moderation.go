@@ -0,0 +1,32 @@
+// domain/place_moderation.go
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PlaceModerationEvent records a status transition of a place with the reviewer's comment
+type PlaceModerationEvent struct {
+	ID         uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	PlaceID    uuid.UUID `json:"place_id" gorm:"type:uuid;not null;index"`
+	ActorID    uuid.UUID `json:"actor_id" gorm:"type:uuid;not null"`
+	FromStatus string    `json:"from_status" gorm:"type:varchar(20)"`
+	ToStatus   string    `json:"to_status" gorm:"type:varchar(20);not null"`
+	Comment    string    `json:"comment" gorm:"type:text"`
+	CreatedAt  time.Time `json:"created_at"`
+
+	// Relationships
+	Place Place `json:"-" gorm:"foreignKey:PlaceID;references:ID;constraint:OnDelete:CASCADE"`
+	Actor User  `json:"actor" gorm:"foreignKey:ActorID;references:ID"`
+}
+
+// BeforeCreate hook to generate UUID
+func (e *PlaceModerationEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+// domain/place_permission.go
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PlacePermission grants a user a permission on one place only, such as letting a business
+// reply to the reviews of its own place
+type PlacePermission struct {
+	ID           uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	PlaceID      uuid.UUID `json:"place_id" gorm:"type:uuid;not null;uniqueIndex:idx_place_permissions_grant"`
+	UserID       uuid.UUID `json:"user_id" gorm:"type:uuid;not null;uniqueIndex:idx_place_permissions_grant;index"`
+	PermissionID uuid.UUID `json:"permission_id" gorm:"type:uuid;not null;uniqueIndex:idx_place_permissions_grant"`
+	GrantedBy    uuid.UUID `json:"granted_by" gorm:"type:uuid"`
+	CreatedAt    time.Time `json:"created_at"`
+
+	// Relationships
+	Place      Place      `json:"-" gorm:"foreignKey:PlaceID;references:ID;constraint:OnDelete:CASCADE"`
+	User       User       `json:"user" gorm:"foreignKey:UserID;references:ID"`
+	Permission Permission `json:"permission" gorm:"foreignKey:PermissionID;references:ID"`
+}
+
+// BeforeCreate hook to generate UUID
+func (p *PlacePermission) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
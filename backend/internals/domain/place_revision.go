@@ -0,0 +1,76 @@
+// domain/place_revision.go
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Change types recorded on place revisions
+const (
+	RevisionChangeBaseline       = "baseline" // State captured before the first tracked change
+	RevisionChangeCreate         = "create"
+	RevisionChangeUpdate         = "update"
+	RevisionChangeContentSection = "content_section"
+	RevisionChangeRestore        = "restore"
+	RevisionChangeMerge          = "merge"
+)
+
+// PlaceRevision is an immutable snapshot of a place and its content sections after a change
+type PlaceRevision struct {
+	ID             uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	PlaceID        uuid.UUID `json:"place_id" gorm:"type:uuid;not null;uniqueIndex:idx_place_revision_number"`
+	RevisionNumber int       `json:"revision_number" gorm:"not null;uniqueIndex:idx_place_revision_number"`
+	ChangeType     string    `json:"change_type" gorm:"type:varchar(30);not null"`
+	Summary        string    `json:"summary"`
+	Snapshot       string    `json:"-" gorm:"type:jsonb;not null"` // JSON encoded PlaceSnapshot
+	CreatedBy      uuid.UUID `json:"created_by" gorm:"type:uuid;not null"`
+	CreatedAt      time.Time `json:"created_at"`
+
+	// Relationships
+	Place   Place `json:"-" gorm:"foreignKey:PlaceID;references:ID;constraint:OnDelete:CASCADE"`
+	Creator User  `json:"creator" gorm:"foreignKey:CreatedBy;references:ID"`
+}
+
+// PlaceSnapshot holds the editable state of a place at one revision
+type PlaceSnapshot struct {
+	NameAr          string                   `json:"name_ar"`
+	NameEn          string                   `json:"name_en"`
+	DescriptionAr   string                   `json:"description_ar"`
+	DescriptionEn   string                   `json:"description_en"`
+	SubtitleAr      string                   `json:"subtitle_ar"`
+	SubtitleEn      string                   `json:"subtitle_en"`
+	GovernateID     *uuid.UUID               `json:"governate_id"`
+	WilayahID       *uuid.UUID               `json:"wilayah_id"`
+	Latitude        float64                  `json:"latitude"`
+	Longitude       float64                  `json:"longitude"`
+	Phone           string                   `json:"phone"`
+	Email           string                   `json:"email"`
+	Website         string                   `json:"website"`
+	IsActive        bool                     `json:"is_active"`
+	CategoryIDs     []uuid.UUID              `json:"category_ids"`
+	PropertyIDs     []uuid.UUID              `json:"property_ids"`
+	ContentSections []ContentSectionSnapshot `json:"content_sections"`
+}
+
+// ContentSectionSnapshot holds the text of a content section at one revision (images are not versioned)
+type ContentSectionSnapshot struct {
+	ID          uuid.UUID `json:"id"`
+	SectionType string    `json:"section_type"`
+	TitleAr     string    `json:"title_ar"`
+	TitleEn     string    `json:"title_en"`
+	ContentAr   string    `json:"content_ar"`
+	ContentEn   string    `json:"content_en"`
+	SortOrder   int       `json:"sort_order"`
+	IsActive    bool      `json:"is_active"`
+}
+
+// BeforeCreate hook to generate UUID
+func (r *PlaceRevision) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+// domain/place_search_document.go
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PlaceSearchDocument stores the normalized, bilingual text of a place used by search.
+// Every column holds text passed through utils.NormalizeSearchText so queries can match
+// Arabic spelling variants with plain LIKE comparisons.
+type PlaceSearchDocument struct {
+	PlaceID         uuid.UUID `json:"place_id" gorm:"type:uuid;primaryKey"`
+	NameText        string    `json:"name_text" gorm:"type:text"`
+	SubtitleText    string    `json:"subtitle_text" gorm:"type:text"`
+	CategoryText    string    `json:"category_text" gorm:"type:text"`
+	LocationText    string    `json:"location_text" gorm:"type:text"` // wilayah and governate names
+	DescriptionText string    `json:"description_text" gorm:"type:text"`
+	UpdatedAt       time.Time `json:"updated_at"`
+
+	// Relationships
+	Place Place `json:"-" gorm:"foreignKey:PlaceID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName specifies the table name for GORM
+func (PlaceSearchDocument) TableName() string {
+	return "place_search_documents"
+}
@@ -0,0 +1,34 @@
+// domain/place_slug.go
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PlaceSlugHistory keeps slugs a place no longer uses so old URLs keep resolving
+type PlaceSlugHistory struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	PlaceID   uuid.UUID `json:"place_id" gorm:"type:uuid;not null;index"`
+	Slug      string    `json:"slug" gorm:"type:varchar(100);uniqueIndex;not null"`
+	Language  string    `json:"language" gorm:"type:varchar(2);not null"` // "en" or "ar"
+	CreatedAt time.Time `json:"created_at"`                               // When the slug was retired
+
+	// Relationships
+	Place Place `json:"-" gorm:"foreignKey:PlaceID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName specifies the table name for GORM
+func (PlaceSlugHistory) TableName() string {
+	return "place_slug_history"
+}
+
+// BeforeCreate hook to generate UUID
+func (h *PlaceSlugHistory) BeforeCreate(tx *gorm.DB) error {
+	if h.ID == uuid.Nil {
+		h.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Entities that can be previewed before they're published
+const (
+	PreviewEntityList  = "list"
+	PreviewEntityPlace = "place"
+)
+
+// PreviewToken records a signed preview link so it can be listed and revoked. The token
+// itself is not stored, it's derived from the record with an HMAC.
+type PreviewToken struct {
+	ID         uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	EntityType string     `json:"entity_type" gorm:"type:varchar(20);not null;index:idx_preview_tokens_entity"` // list, place
+	EntityID   uuid.UUID  `json:"entity_id" gorm:"type:uuid;not null;index:idx_preview_tokens_entity"`
+	ExpiresAt  time.Time  `json:"expires_at" gorm:"not null"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedBy  uuid.UUID  `json:"created_by" gorm:"type:uuid;not null"`
+	CreatedAt  time.Time  `json:"created_at"`
+
+	// Relationships
+	Creator User `json:"-" gorm:"foreignKey:CreatedBy;references:ID"`
+}
+
+// BeforeCreate hook to generate UUID
+func (pt *PreviewToken) BeforeCreate(tx *gorm.DB) error {
+	if pt.ID == uuid.Nil {
+		pt.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsActive reports whether the token can still be used
+func (pt *PreviewToken) IsActive(now time.Time) bool {
+	return pt.RevokedAt == nil && now.Before(pt.ExpiresAt)
+}
@@ -142,6 +142,7 @@ const (
 	ActionManage = "manage" // Full control
 	ActionView   = "view"   // Read-only access
 	ActionModerate = "moderate" // Moderate content
+	ActionRespond  = "respond"  // Reply on behalf of a place
 )
 
 // Constants for resources
@@ -151,6 +152,7 @@ const (
 	ResourceCategory = "category"
 	ResourceReview   = "review"
 	ResourceRecipe   = "recipe"
+	ResourceDish     = "dish"
 	ResourceAdvice   = "advice"
 	ResourceRole     = "role"
 	ResourceProperty   = "property"  
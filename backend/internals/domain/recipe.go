@@ -7,27 +7,65 @@ import (
 	"gorm.io/gorm"
 )
 
+// Ingredient units. UnitToTaste has no quantity.
+const (
+	UnitGram       = "g"
+	UnitKilogram   = "kg"
+	UnitMilliliter = "ml"
+	UnitLiter      = "l"
+	UnitTeaspoon   = "tsp"
+	UnitTablespoon = "tbsp"
+	UnitCup        = "cup"
+	UnitPiece      = "piece"
+	UnitPinch      = "pinch"
+	UnitToTaste    = "to_taste"
+)
+
 type Recipe struct {
-	ID           uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey"`
-	Title        string         `json:"title" gorm:"not null"`
-	Description  string         `json:"description"`
-	CuisineType  string         `json:"cuisine_type"`
-	Difficulty   string         `json:"difficulty" gorm:"default:Easy"`
-	PrepTime     int            `json:"prep_time"`
-	CookTime     int            `json:"cook_time"`
-	Servings     int            `json:"servings"`
-	Ingredients  string         `json:"ingredients" gorm:"type:text"`
-	Instructions string         `json:"instructions" gorm:"type:text"`
-	CreatedBy    uuid.UUID      `json:"created_by" gorm:"type:uuid;not null"`
-	IsPublished  bool           `json:"is_published" gorm:"default:true"`
-	ViewCount    int            `json:"view_count" gorm:"default:0"`
-	CreatedAt    time.Time      `json:"created_at"`
-	UpdatedAt    time.Time      `json:"updated_at"`
-	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
+	ID          uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey"`
+	Title       string         `json:"title" gorm:"not null"`
+	Description string         `json:"description"`
+	CuisineType string         `json:"cuisine_type"`
+	Difficulty  string         `json:"difficulty" gorm:"default:Easy"`
+	PrepTime    int            `json:"prep_time"`
+	CookTime    int            `json:"cook_time"`
+	Servings    int            `json:"servings"`
+	DishID      *uuid.UUID     `json:"dish_id" gorm:"type:uuid;index"` // The traditional dish this recipe makes
+	CreatedBy   uuid.UUID      `json:"created_by" gorm:"type:uuid;not null"`
+	IsPublished bool           `json:"is_published" gorm:"default:true"`
+	ViewCount   int            `json:"view_count" gorm:"default:0"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
 
 	// Relationships
-	Creator User          `json:"creator" gorm:"foreignKey:CreatedBy;references:ID"`
-	Images  []RecipeImage `json:"images" gorm:"foreignKey:RecipeID;references:ID"`
+	Creator     User               `json:"creator" gorm:"foreignKey:CreatedBy;references:ID"`
+	Dish        *Dish              `json:"dish,omitempty" gorm:"foreignKey:DishID;references:ID"`
+	Ingredients []RecipeIngredient `json:"ingredients" gorm:"foreignKey:RecipeID;references:ID;constraint:OnDelete:CASCADE"`
+	Steps       []RecipeStep       `json:"steps" gorm:"foreignKey:RecipeID;references:ID;constraint:OnDelete:CASCADE"`
+	Images      []RecipeImage      `json:"images" gorm:"foreignKey:RecipeID;references:ID"`
+}
+
+// RecipeIngredient is one line of a recipe's ingredient list
+type RecipeIngredient struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	RecipeID  uuid.UUID `json:"recipe_id" gorm:"type:uuid;not null;index"`
+	NameAr    string    `json:"name_ar" gorm:"type:varchar(200);not null"`
+	NameEn    string    `json:"name_en" gorm:"type:varchar(200);not null"`
+	Quantity  *float64  `json:"quantity"`
+	Unit      string    `json:"unit" gorm:"type:varchar(20)"` // g, kg, ml, l, tsp, tbsp, cup, piece, pinch, to_taste
+	Note      string    `json:"note" gorm:"type:varchar(500)"`
+	SortOrder int       `json:"sort_order" gorm:"not null;default:0"`
+}
+
+// RecipeStep is one instruction of a recipe. Images of the step are the recipe images
+// with the same StepNumber.
+type RecipeStep struct {
+	ID              uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	RecipeID        uuid.UUID `json:"recipe_id" gorm:"type:uuid;not null;uniqueIndex:idx_recipe_steps_recipe_step"`
+	StepNumber      int       `json:"step_number" gorm:"not null;uniqueIndex:idx_recipe_steps_recipe_step"`
+	Instruction     string    `json:"instruction" gorm:"type:text;not null"`
+	DurationMinutes *int      `json:"duration_minutes"`
 }
 
 // BeforeCreate hook to generate UUID
@@ -37,3 +75,27 @@ func (r *Recipe) BeforeCreate(tx *gorm.DB) error {
 	}
 	return nil
 }
+
+// BeforeCreate hook to generate UUID
+func (ri *RecipeIngredient) BeforeCreate(tx *gorm.DB) error {
+	if ri.ID == uuid.Nil {
+		ri.ID = uuid.New()
+	}
+	return nil
+}
+
+// BeforeCreate hook to generate UUID
+func (rs *RecipeStep) BeforeCreate(tx *gorm.DB) error {
+	if rs.ID == uuid.Nil {
+		rs.ID = uuid.New()
+	}
+	return nil
+}
+
+// GetName returns the localized ingredient name
+func (ri *RecipeIngredient) GetName(lang string) string {
+	if lang == "ar" {
+		return ri.NameAr
+	}
+	return ri.NameEn
+}
@@ -8,23 +8,29 @@ import (
 )
 
 type Review struct {
-	ID           uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey"`
-	PlaceID      uuid.UUID      `json:"place_id" gorm:"type:uuid;not null"`
-	UserID       uuid.UUID      `json:"user_id" gorm:"type:uuid;not null"`
-	Rating       int            `json:"rating" gorm:"not null;check:rating >= 1 AND rating <= 5"`
-	Title        string         `json:"title"`
-	ReviewText   string         `json:"review_text"`
-	VisitDate    *time.Time     `json:"visit_date"`
-	IsVerified   bool           `json:"is_verified" gorm:"default:false"`
-	HelpfulCount int            `json:"helpful_count" gorm:"default:0"`
-	CreatedAt    time.Time      `json:"created_at"`
-	UpdatedAt    time.Time      `json:"updated_at"`
-	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
+	ID             uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey"`
+	PlaceID        uuid.UUID      `json:"place_id" gorm:"type:uuid;not null;uniqueIndex:idx_reviews_user_place,where:deleted_at IS NULL"`
+	UserID         uuid.UUID      `json:"user_id" gorm:"type:uuid;not null;uniqueIndex:idx_reviews_user_place,where:deleted_at IS NULL"` // One review per user and place
+	Rating         int            `json:"rating" gorm:"not null;check:rating >= 1 AND rating <= 5"`
+	Title          string         `json:"title"`
+	ReviewText     string         `json:"review_text"`
+	VisitDate      *time.Time     `json:"visit_date"`
+	IsVerified     bool           `json:"is_verified" gorm:"default:false"`
+	HelpfulCount   int            `json:"helpful_count" gorm:"default:0"`
+	UnhelpfulCount int            `json:"unhelpful_count" gorm:"default:0"`
+	EditedAt       *time.Time     `json:"edited_at"`                            // Last edit by the author or a moderator
+	IsHidden       bool           `json:"is_hidden" gorm:"default:false;index"` // Hidden by moderation, not counted in the place rating
+	HiddenAt       *time.Time     `json:"hidden_at"`
+	ReportCount    int            `json:"report_count" gorm:"default:0"` // Open reports
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `json:"-" gorm:"index"`
 
 	// Relationships
 	Place  Place         `json:"place" gorm:"foreignKey:PlaceID;references:ID"`
 	User   User          `json:"user" gorm:"foreignKey:UserID;references:ID"`
 	Images []ReviewImage `json:"images" gorm:"foreignKey:ReviewID;references:ID"`
+	Reply  *ReviewReply  `json:"reply,omitempty" gorm:"foreignKey:ReviewID;references:ID;constraint:OnDelete:CASCADE"`
 }
 
 // BeforeCreate hook to generate UUID
@@ -0,0 +1,53 @@
+// domain/review_reply.go
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReviewReply is the official public reply of a place's owner to a review, at most one per review
+type ReviewReply struct {
+	ID        uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey"`
+	ReviewID  uuid.UUID      `json:"review_id" gorm:"type:uuid;not null;uniqueIndex:idx_review_replies_review,where:deleted_at IS NULL"`
+	AuthorID  uuid.UUID      `json:"author_id" gorm:"type:uuid;not null"`
+	ReplyText string         `json:"reply_text" gorm:"type:text;not null"`
+	EditedAt  *time.Time     `json:"edited_at"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Relationships
+	Author User `json:"author" gorm:"foreignKey:AuthorID;references:ID"`
+}
+
+// BeforeCreate hook to generate UUID
+func (r *ReviewReply) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// ReviewReplyRevision keeps the text a reply had before an edit
+type ReviewReplyRevision struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	ReplyID   uuid.UUID `json:"reply_id" gorm:"type:uuid;not null;index"`
+	EditedBy  uuid.UUID `json:"edited_by" gorm:"type:uuid;not null"` // Who made the edit that replaced this text
+	ReplyText string    `json:"reply_text" gorm:"type:text"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Relationships
+	Reply  ReviewReply `json:"-" gorm:"foreignKey:ReplyID;references:ID;constraint:OnDelete:CASCADE"`
+	Editor User        `json:"editor" gorm:"foreignKey:EditedBy;references:ID"`
+}
+
+// BeforeCreate hook to generate UUID
+func (r *ReviewReplyRevision) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
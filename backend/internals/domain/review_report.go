@@ -0,0 +1,81 @@
+// domain/review_report.go
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Reasons a review can be reported for
+const (
+	ReviewReportSpam      = "spam"
+	ReviewReportOffensive = "offensive"
+	ReviewReportOffTopic  = "off_topic"
+	ReviewReportFake      = "fake"
+)
+
+// Review report statuses. A report stays open until a moderator acts on its review.
+const (
+	ReviewReportOpen      = "open"
+	ReviewReportDismissed = "dismissed"
+	ReviewReportResolved  = "resolved"
+)
+
+// Review moderation actions. auto_hide is taken by the system when a review collects
+// enough open reports, the others by moderators.
+const (
+	ReviewActionAutoHide = "auto_hide"
+	ReviewActionDismiss  = "dismiss"
+	ReviewActionHide     = "hide"
+	ReviewActionDelete   = "delete"
+	ReviewActionWarn     = "warn"
+)
+
+// ReviewReport is one user's flag on a review. A user reports a review at most once.
+type ReviewReport struct {
+	ID         uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	ReviewID   uuid.UUID  `json:"review_id" gorm:"type:uuid;not null;uniqueIndex:idx_review_reports_review_reporter"`
+	ReporterID uuid.UUID  `json:"reporter_id" gorm:"type:uuid;not null;uniqueIndex:idx_review_reports_review_reporter"`
+	Reason     string     `json:"reason" gorm:"type:varchar(20);not null"` // See ReviewReport* reason constants
+	Comment    string     `json:"comment" gorm:"type:text"`
+	Status     string     `json:"status" gorm:"type:varchar(20);not null;default:open;index"`
+	ResolvedAt *time.Time `json:"resolved_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+
+	// Relationships
+	Review   Review `json:"-" gorm:"foreignKey:ReviewID;references:ID;constraint:OnDelete:CASCADE"`
+	Reporter User   `json:"reporter" gorm:"foreignKey:ReporterID;references:ID"`
+}
+
+// BeforeCreate hook to generate UUID
+func (r *ReviewReport) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// ReviewModerationAction is the audit log of decisions taken on reported reviews
+type ReviewModerationAction struct {
+	ID              uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	ReviewID        uuid.UUID  `json:"review_id" gorm:"type:uuid;not null;index"`
+	ActorID         *uuid.UUID `json:"actor_id" gorm:"type:uuid"` // Empty for automatic actions
+	Action          string     `json:"action" gorm:"type:varchar(20);not null;index"`
+	Comment         string     `json:"comment" gorm:"type:text"`
+	ReportsResolved int        `json:"reports_resolved"` // Open reports closed by this action
+	CreatedAt       time.Time  `json:"created_at"`
+
+	// Relationships
+	Review Review `json:"-" gorm:"foreignKey:ReviewID;references:ID;constraint:OnDelete:CASCADE"`
+	Actor  *User  `json:"actor,omitempty" gorm:"foreignKey:ActorID;references:ID"`
+}
+
+// BeforeCreate hook to generate UUID
+func (a *ReviewModerationAction) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
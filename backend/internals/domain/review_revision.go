@@ -0,0 +1,34 @@
+// domain/review_revision.go
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReviewRevision keeps the content a review had before an edit, so moderators can see
+// what the review originally said
+type ReviewRevision struct {
+	ID         uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	ReviewID   uuid.UUID  `json:"review_id" gorm:"type:uuid;not null;index"`
+	EditedBy   uuid.UUID  `json:"edited_by" gorm:"type:uuid;not null"` // Who made the edit that replaced this content
+	Rating     int        `json:"rating" gorm:"not null"`
+	Title      string     `json:"title"`
+	ReviewText string     `json:"review_text"`
+	VisitDate  *time.Time `json:"visit_date"`
+	CreatedAt  time.Time  `json:"created_at"` // When the content was replaced
+
+	// Relationships
+	Review Review `json:"-" gorm:"foreignKey:ReviewID;references:ID;constraint:OnDelete:CASCADE"`
+	Editor User   `json:"editor" gorm:"foreignKey:EditedBy;references:ID"`
+}
+
+// BeforeCreate hook to generate UUID
+func (r *ReviewRevision) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+// domain/review_vote.go
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Review vote values
+const (
+	ReviewVoteHelpful   = "helpful"
+	ReviewVoteUnhelpful = "unhelpful"
+)
+
+// ReviewVote is one user's helpful or unhelpful vote on a review. A user has at most one vote
+// per review; Review.HelpfulCount and UnhelpfulCount are counted from these rows.
+type ReviewVote struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	ReviewID  uuid.UUID `json:"review_id" gorm:"type:uuid;not null;uniqueIndex:idx_review_votes_review_user"`
+	UserID    uuid.UUID `json:"user_id" gorm:"type:uuid;not null;uniqueIndex:idx_review_votes_review_user;index"`
+	Vote      string    `json:"vote" gorm:"type:varchar(10);not null"` // See ReviewVote* constants
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Relationships
+	Review Review `json:"-" gorm:"foreignKey:ReviewID;references:ID;constraint:OnDelete:CASCADE"`
+	User   User   `json:"-" gorm:"foreignKey:UserID;references:ID"`
+}
+
+// BeforeCreate hook to generate UUID
+func (v *ReviewVote) BeforeCreate(tx *gorm.DB) error {
+	if v.ID == uuid.Nil {
+		v.ID = uuid.New()
+	}
+	return nil
+}
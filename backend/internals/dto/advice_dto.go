@@ -2,38 +2,73 @@ package dto
 
 import (
 	"time"
+
 	"github.com/google/uuid"
 )
 
 type CreateAdviceRequest struct {
-	Title              string `json:"title" validate:"required"`
-	Content            string `json:"content" validate:"required"`
-	AdviceType         string `json:"advice_type"`
-	DestinationCity    string `json:"destination_city"`
-	DestinationCountry string `json:"destination_country"`
+	TitleAr     string      `json:"title_ar" validate:"required,min=2,max=200"`
+	TitleEn     string      `json:"title_en" validate:"required,min=2,max=200"`
+	ContentAr   string      `json:"content_ar" validate:"required"`
+	ContentEn   string      `json:"content_en" validate:"required"`
+	AdviceType  string      `json:"advice_type" validate:"omitempty,oneof=general safety culture transport weather money health packing"`
+	GovernateID *uuid.UUID  `json:"governate_id"`
+	WilayahID   *uuid.UUID  `json:"wilayah_id"`
+	PlaceIDs    []uuid.UUID `json:"place_ids" validate:"max=50"`
+	IsFeatured  bool        `json:"is_featured"`
+	IsPublished bool        `json:"is_published"`
 }
 
+// UpdateAdviceRequest changes the given fields. A nil uuid clears the governate or wilayah,
+// and place_ids replaces the linked places when present.
 type UpdateAdviceRequest struct {
-	Title              string `json:"title"`
-	Content            string `json:"content"`
-	AdviceType         string `json:"advice_type"`
-	DestinationCity    string `json:"destination_city"`
-	DestinationCountry string `json:"destination_country"`
-	IsFeatured         *bool  `json:"is_featured"`
-	IsPublished        *bool  `json:"is_published"`
+	TitleAr     *string      `json:"title_ar" validate:"omitempty,min=2,max=200"`
+	TitleEn     *string      `json:"title_en" validate:"omitempty,min=2,max=200"`
+	ContentAr   *string      `json:"content_ar" validate:"omitempty,min=1"`
+	ContentEn   *string      `json:"content_en" validate:"omitempty,min=1"`
+	AdviceType  *string      `json:"advice_type" validate:"omitempty,oneof=general safety culture transport weather money health packing"`
+	GovernateID *uuid.UUID   `json:"governate_id"`
+	WilayahID   *uuid.UUID   `json:"wilayah_id"`
+	PlaceIDs    *[]uuid.UUID `json:"place_ids" validate:"omitempty,max=50"`
+}
+
+type SetAdviceFeaturedRequest struct {
+	IsFeatured bool `json:"is_featured"`
+}
+
+type SetAdvicePublishedRequest struct {
+	IsPublished bool `json:"is_published"`
+}
+
+// AdviceFilters selects advice for listings. Advice for a wilayah also matches its
+// governate's general advice, and advice for a place matches its wilayah and governate.
+type AdviceFilters struct {
+	AdviceType         string
+	GovernateID        *uuid.UUID
+	WilayahID          *uuid.UUID
+	PlaceID            *uuid.UUID
+	IsFeatured         *bool
+	Search             string
+	IncludeUnpublished bool
+	Page               int
+	Limit              int
 }
 
 type AdviceResponse struct {
-	ID                 uuid.UUID `json:"id"`
-	Title              string    `json:"title"`
-	Content            string    `json:"content"`
-	AdviceType         string    `json:"advice_type"`
-	DestinationCity    string    `json:"destination_city"`
-	DestinationCountry string    `json:"destination_country"`
-	IsFeatured         bool      `json:"is_featured"`
-	ViewCount          int       `json:"view_count"`
-	IsPublished        bool      `json:"is_published"`
-	CreatedAt          time.Time `json:"created_at"`
-	UpdatedAt          time.Time `json:"updated_at"`
-	Creator            UserInfo  `json:"creator"`
-}
\ No newline at end of file
+	ID          uuid.UUID                `json:"id"`
+	TitleAr     string                   `json:"title_ar"`
+	TitleEn     string                   `json:"title_en"`
+	ContentAr   string                   `json:"content_ar"`
+	ContentEn   string                   `json:"content_en"`
+	AdviceType  string                   `json:"advice_type"`
+	Governate   *SimpleGovernateResponse `json:"governate,omitempty"`
+	Wilayah     *SimpleWilayahResponse   `json:"wilayah,omitempty"`
+	Places      []PlaceListResponse      `json:"places"`
+	IsFeatured  bool                     `json:"is_featured"`
+	ViewCount   int                      `json:"view_count"`
+	IsPublished bool                     `json:"is_published"`
+	PublishedAt *time.Time               `json:"published_at,omitempty"`
+	CreatedAt   time.Time                `json:"created_at"`
+	UpdatedAt   time.Time                `json:"updated_at"`
+	Creator     UserInfo                 `json:"creator"`
+}
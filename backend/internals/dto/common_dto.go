@@ -1,6 +1,11 @@
 package dto
 
-import "github.com/google/uuid"
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
 
 // Common response wrapper
 type APIResponse struct {
@@ -29,15 +34,72 @@ type PaginationResponse struct {
 
 // Filter requests
 type PlaceFilterRequest struct {
-	CategoryIDs []uuid.UUID `json:"category_ids"`
-	PropertyIDs []uuid.UUID `json:"property_ids"`
-	City        string      `json:"city"`
-	Country     string      `json:"country"`
-	PriceRange  []string    `json:"price_range"`
-	MinRating   float64     `json:"min_rating"`
-	IsActive    *bool       `json:"is_active"`
+	CategoryIDs   []uuid.UUID `json:"category_ids"`
+	CategoryMatch string      `json:"category_match" validate:"omitempty,oneof=any all"` // "any" (default) or "all"
+	PropertyIDs   []uuid.UUID `json:"property_ids"`                                      // Places must have every property
+	GovernateIDs  []uuid.UUID `json:"governate_ids"`
+	WilayahIDs    []uuid.UUID `json:"wilayah_ids"`
+	MinRating     float64     `json:"min_rating" validate:"min=0,max=5"`
+	MinReviews    int         `json:"min_reviews" validate:"min=0"`
+	IsActive      *bool       `json:"is_active"` // Only honored for admins, others always get active places
+	SortBy        string      `json:"sort_by" validate:"omitempty,oneof=newest name rating reviews last_review"`
+	OpenNow       bool        `json:"open_now"`
+	OpenAt        *time.Time  `json:"open_at"` // RFC3339, takes precedence over open_now
+
+	// Places have no city, country or price data, these are rejected when set
+	City       string   `json:"city"`
+	Country    string   `json:"country"`
+	PriceRange []string `json:"price_range"`
+
 	PaginationRequest
 }
+
+// OpenTime returns the time places must be open at, or nil when not filtering by opening hours
+func (r *PlaceFilterRequest) OpenTime() *time.Time {
+	if r.OpenAt != nil {
+		return r.OpenAt
+	}
+	if r.OpenNow {
+		now := time.Now()
+		return &now
+	}
+	return nil
+}
+
+// Unsupported names the filters that were set but can't be applied, or returns ""
+func (r *PlaceFilterRequest) Unsupported() string {
+	var fields []string
+	if r.City != "" {
+		fields = append(fields, "city")
+	}
+	if r.Country != "" {
+		fields = append(fields, "country")
+	}
+	if len(r.PriceRange) > 0 {
+		fields = append(fields, "price_range")
+	}
+	return strings.Join(fields, ", ")
+}
+
+// SetDefaults fills in pagination, sorting and matching defaults
+func (r *PlaceFilterRequest) SetDefaults() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+	if r.Limit < 1 {
+		r.Limit = 20
+	}
+	if r.Limit > 100 {
+		r.Limit = 100
+	}
+	if r.CategoryMatch == "" {
+		r.CategoryMatch = "any"
+	}
+	if r.SortBy == "" {
+		r.SortBy = "newest"
+	}
+}
+
 type ImageResponse struct {
 	ID           uuid.UUID `json:"id"`
 	URL          string    `json:"url"`
@@ -77,11 +139,20 @@ type SimpleWilayahResponse struct {
 	Slug   string    `json:"slug"`
 }
 
+type SimpleDishResponse struct {
+	ID     uuid.UUID `json:"id"`
+	NameAr string    `json:"name_ar"`
+	NameEn string    `json:"name_en"`
+	Slug   string    `json:"slug"`
+}
+
 // Response DTOs - List response for places (minimal info for lists)
 type PlaceListResponse struct {
     ID            uuid.UUID                      `json:"id"`
     NameAr        string                         `json:"name_ar"`
     NameEn        string                         `json:"name_en"`
+    Slug          string                         `json:"slug,omitempty"`
+    SlugAr        string                         `json:"slug_ar,omitempty"`
     DescriptionAr string                         `json:"description_ar"`
     DescriptionEn string                         `json:"description_en"`
     SubtitleAr    string                         `json:"subtitle_ar"`
@@ -92,6 +163,11 @@ type PlaceListResponse struct {
     Longitude     float64                        `json:"longitude"`    
     Rating        float64                        `json:"rating"`
     ReviewCount   int                            `json:"review_count"`
+    LastReviewAt  *time.Time                     `json:"last_review_at,omitempty"`
     Categories    []SimpleCategoryResponse       `json:"categories"`
     PrimaryImage  *ImageResponse                 `json:"primary_image,omitempty"`
+    Status        string                         `json:"status,omitempty"`
+    DistanceKm    *float64                       `json:"distance_km,omitempty"` // Only set by location based queries
+    IsOpen        *bool                          `json:"is_open,omitempty"`     // Only set when filtering by open_now/open_at
+    IsFavorited   *bool                          `json:"is_favorited,omitempty"` // Only set for signed-in callers
 }
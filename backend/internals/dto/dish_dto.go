@@ -23,6 +23,7 @@ type CreateDishRequest struct {
 	IsActive               bool                        `json:"is_active"`
 	SortOrder              int                        `json:"sort_order"`
 	Images                 []CreateDishImageRequest    `json:"images,omitempty"`
+	TagIDs                 []uuid.UUID                 `json:"tag_ids" validate:"max=50"`
 }
 
 type UpdateDishRequest struct {
@@ -39,6 +40,7 @@ type UpdateDishRequest struct {
 	IsFeatured             *bool                       `json:"is_featured"`
 	IsActive               *bool                       `json:"is_active"`
 	SortOrder              int                        `json:"sort_order"`
+	TagIDs                 *[]uuid.UUID                `json:"tag_ids" validate:"omitempty,max=50"` // Replaces all tags when present
 }
 
 // Dish Image DTOs
@@ -61,6 +63,34 @@ type UpdateDishImageRequest struct {
 	DisplayOrder int       `json:"display_order"`
 }
 
+// Dish tag DTOs
+type CreateDishTagRequest struct {
+	Type      string `json:"type" validate:"required,oneof=dietary allergen ingredient"`
+	Slug      string `json:"slug" validate:"required,min=2,max=100"`
+	NameAr    string `json:"name_ar" validate:"required,max=100"`
+	NameEn    string `json:"name_en" validate:"required,max=100"`
+	Icon      string `json:"icon"`
+	SortOrder int    `json:"sort_order"`
+}
+
+type UpdateDishTagRequest struct {
+	Type      *string `json:"type" validate:"omitempty,oneof=dietary allergen ingredient"`
+	Slug      *string `json:"slug" validate:"omitempty,min=2,max=100"`
+	NameAr    *string `json:"name_ar" validate:"omitempty,max=100"`
+	NameEn    *string `json:"name_en" validate:"omitempty,max=100"`
+	Icon      *string `json:"icon"`
+	SortOrder *int    `json:"sort_order"`
+}
+
+// SaveDishPlaceRequest links a dish to a place that serves it. Prices are in OMR.
+type SaveDishPlaceRequest struct {
+	NoteAr      string   `json:"note_ar" validate:"max=1000"`
+	NoteEn      string   `json:"note_en" validate:"max=1000"`
+	PriceMin    *float64 `json:"price_min" validate:"omitempty,gte=0"`
+	PriceMax    *float64 `json:"price_max" validate:"omitempty,gte=0"`
+	IsSignature bool     `json:"is_signature"`
+}
+
 // Response DTOs
 type DishResponse struct {
 	ID                     uuid.UUID              `json:"id"`
@@ -78,6 +108,7 @@ type DishResponse struct {
 	SortOrder              int                    `json:"sort_order"`
 	Governate              *SimpleGovernateResponse `json:"governate,omitempty"`
 	Images                 []DishImageResponse    `json:"images"`
+	Tags                   []DishTagResponse      `json:"tags"`
 	CreatedAt              time.Time              `json:"created_at"`
 	UpdatedAt              time.Time              `json:"updated_at"`
 }
@@ -97,6 +128,8 @@ type DishResponseLocalized struct {
 	SortOrder              int                    `json:"sort_order"`
 	Governate              *SimpleGovernateLocalized `json:"governate,omitempty"`
 	Images                 []DishImageResponseLocalized `json:"images"`
+	Tags                   []DishTagLocalized     `json:"tags"`
+	WhereToEat             []DishPlaceLocalized   `json:"where_to_eat"`
 	CreatedAt              time.Time              `json:"created_at"`
 	UpdatedAt              time.Time              `json:"updated_at"`
 }
@@ -115,6 +148,24 @@ type DishImageResponse struct {
 	UpdatedAt    time.Time `json:"updated_at"`
 }
 
+type DishTagResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Type      string    `json:"type"`
+	Slug      string    `json:"slug"`
+	NameAr    string    `json:"name_ar"`
+	NameEn    string    `json:"name_en"`
+	Icon      string    `json:"icon"`
+	SortOrder int       `json:"sort_order"`
+}
+
+type DishTagLocalized struct {
+	ID   uuid.UUID `json:"id"`
+	Type string    `json:"type"`
+	Slug string    `json:"slug"`
+	Name string    `json:"name"`
+	Icon string    `json:"icon"`
+}
+
 type DishImageResponseLocalized struct {
 	ID           uuid.UUID `json:"id"`
 	DishID       uuid.UUID `json:"dish_id"`
@@ -137,16 +188,18 @@ type DishListResponse struct {
 }
 
 type DishFilters struct {
-	GovernateID   string `json:"governate_id"`
-	Difficulty    string `json:"difficulty"`
-	IsTraditional *bool  `json:"is_traditional"`
-	IsFeatured    *bool  `json:"is_featured"`
-	IsActive      *bool  `json:"is_active"`
-	Search        string `json:"search"`
-	Page          int    `json:"page"`
-	PageSize      int    `json:"page_size"`
-	SortBy        string `json:"sort_by"`
-	SortOrder     string `json:"sort_order"`
+	GovernateID   string   `json:"governate_id"`
+	Difficulty    string   `json:"difficulty"`
+	IsTraditional *bool    `json:"is_traditional"`
+	IsFeatured    *bool    `json:"is_featured"`
+	IsActive      *bool    `json:"is_active"`
+	Search        string   `json:"search"`
+	IncludeTags   []string `json:"include_tags"` // Tag slugs the dish must all have
+	ExcludeTags   []string `json:"exclude_tags"` // Tag slugs the dish must not have, e.g. allergens
+	Page          int      `json:"page"`
+	PageSize      int      `json:"page_size"`
+	SortBy        string   `json:"sort_by"`
+	SortOrder     string   `json:"sort_order"`
 }
 
 // Helper methods for CreateDishRequest
@@ -188,4 +241,36 @@ func (f *DishFilters) SetDefaults() {
 	if f.SortOrder == "" {
 		f.SortOrder = "desc"
 	}
-}
\ No newline at end of file
+}
+// DishPlaceResponse is a place serving a dish. Place.DistanceKm is set when coordinates are given.
+type DishPlaceResponse struct {
+	Place       PlaceListResponse `json:"place"`
+	NoteAr      string            `json:"note_ar"`
+	NoteEn      string            `json:"note_en"`
+	PriceMin    *float64          `json:"price_min,omitempty"`
+	PriceMax    *float64          `json:"price_max,omitempty"`
+	IsSignature bool              `json:"is_signature"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+// PlaceDishResponse is a dish served at a place
+type PlaceDishResponse struct {
+	Dish        DishResponse `json:"dish"`
+	NoteAr      string       `json:"note_ar"`
+	NoteEn      string       `json:"note_en"`
+	PriceMin    *float64     `json:"price_min,omitempty"`
+	PriceMax    *float64     `json:"price_max,omitempty"`
+	IsSignature bool         `json:"is_signature"`
+}
+
+type DishPlaceLocalized struct {
+	PlaceID     uuid.UUID `json:"place_id"`
+	Name        string    `json:"name"`
+	Slug        string    `json:"slug"`
+	Note        string    `json:"note"`
+	PriceMin    *float64  `json:"price_min,omitempty"`
+	PriceMax    *float64  `json:"price_max,omitempty"`
+	IsSignature bool      `json:"is_signature"`
+	Latitude    float64   `json:"latitude"`
+	Longitude   float64   `json:"longitude"`
+}
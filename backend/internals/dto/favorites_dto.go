@@ -2,6 +2,7 @@ package dto
 
 import (
 	"time"
+
 	"github.com/google/uuid"
 )
 
@@ -10,9 +11,42 @@ type AddFavoriteRequest struct {
 }
 
 type FavoriteResponse struct {
-	ID      uuid.UUID `json:"id"`
-	UserID  uuid.UUID `json:"user_id"`
-	PlaceID uuid.UUID `json:"place_id"`
-	AddedAt time.Time `json:"added_at"`
-	Place   PlaceListResponse `json:"place"`
-}
\ No newline at end of file
+	ID            uuid.UUID         `json:"id"`
+	UserID        uuid.UUID         `json:"user_id"`
+	PlaceID       uuid.UUID         `json:"place_id"`
+	AddedAt       time.Time         `json:"added_at"`
+	Place         PlaceListResponse `json:"place"`
+	CollectionIDs []uuid.UUID       `json:"collection_ids"`
+}
+
+// FavoriteStatusRequest asks which of a page of places the caller has favorited
+type FavoriteStatusRequest struct {
+	PlaceIDs []uuid.UUID `json:"place_ids" validate:"required,min=1,max=100"`
+}
+
+type FavoriteStatusResponse struct {
+	Favorites map[uuid.UUID]bool `json:"favorites"` // Place ID to favorited
+}
+
+type CreateFavoriteCollectionRequest struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description" validate:"max=1000"`
+}
+
+type UpdateFavoriteCollectionRequest struct {
+	Name        *string `json:"name" validate:"omitempty,max=100"`
+	Description *string `json:"description" validate:"omitempty,max=1000"`
+}
+
+type AddCollectionPlaceRequest struct {
+	PlaceID uuid.UUID `json:"place_id" validate:"required"`
+}
+
+type FavoriteCollectionResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	PlaceCount  int       `json:"place_count"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
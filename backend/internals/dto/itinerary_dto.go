@@ -0,0 +1,107 @@
+// dto/itinerary_dto.go
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateItineraryRequest starts a trip plan with Days empty days
+type CreateItineraryRequest struct {
+	Title       string  `json:"title" validate:"required,max=200"`
+	Description string  `json:"description" validate:"max=5000"`
+	StartDate   *string `json:"start_date" validate:"omitempty,datetime=2006-01-02"`
+	Days        int     `json:"days" validate:"min=0,max=30"`
+}
+
+// UpdateItineraryRequest changes the trip details. An empty start_date clears it.
+type UpdateItineraryRequest struct {
+	Title       *string `json:"title" validate:"omitempty,max=200"`
+	Description *string `json:"description" validate:"omitempty,max=5000"`
+	StartDate   *string `json:"start_date" validate:"omitempty,datetime=2006-01-02"`
+}
+
+type ItineraryDayRequest struct {
+	Title string `json:"title" validate:"max=200"`
+	Notes string `json:"notes" validate:"max=5000"`
+}
+
+// CreateItineraryStopRequest adds a stop to a day. place_id is required for place stops,
+// dish_id for dish stops and note for note stops. Without a position the stop is appended.
+type CreateItineraryStopRequest struct {
+	StopType        string     `json:"stop_type" validate:"required,oneof=place dish note"`
+	PlaceID         *uuid.UUID `json:"place_id"`
+	DishID          *uuid.UUID `json:"dish_id"`
+	Note            string     `json:"note" validate:"max=2000"`
+	DurationMinutes *int       `json:"duration_minutes" validate:"omitempty,min=0,max=1440"`
+	Position        *int       `json:"position" validate:"omitempty,min=1"`
+}
+
+type UpdateItineraryStopRequest struct {
+	Note            *string `json:"note" validate:"omitempty,max=2000"`
+	DurationMinutes *int    `json:"duration_minutes" validate:"omitempty,min=0,max=1440"`
+}
+
+// ReorderItineraryStopsRequest lists every stop of a day in its new order. Stops of other
+// days of the same itinerary can be included to move them to this day.
+type ReorderItineraryStopsRequest struct {
+	StopIDs []uuid.UUID `json:"stop_ids" validate:"required,min=1"`
+}
+
+// TravelEstimateResponse is the estimated drive from the previous stop with coordinates
+type TravelEstimateResponse struct {
+	FromStopID      uuid.UUID `json:"from_stop_id"`
+	DistanceKm      float64   `json:"distance_km"`
+	DurationMinutes int       `json:"duration_minutes"`
+}
+
+type ItineraryStopResponse struct {
+	ID                 uuid.UUID               `json:"id"`
+	StopType           string                  `json:"stop_type"`
+	SortOrder          int                     `json:"sort_order"`
+	Place              *PlaceListResponse      `json:"place,omitempty"`
+	Dish               *DishResponse           `json:"dish,omitempty"`
+	Note               string                  `json:"note"`
+	DurationMinutes    *int                    `json:"duration_minutes,omitempty"`
+	TravelFromPrevious *TravelEstimateResponse `json:"travel_from_previous,omitempty"`
+}
+
+type ItineraryDayResponse struct {
+	ID             uuid.UUID               `json:"id"`
+	DayNumber      int                     `json:"day_number"`
+	Date           *string                 `json:"date,omitempty"` // Only set when the itinerary has a start date
+	Title          string                  `json:"title"`
+	Notes          string                  `json:"notes"`
+	Stops          []ItineraryStopResponse `json:"stops"`
+	DistanceKm     float64                 `json:"distance_km"`
+	DrivingMinutes int                     `json:"driving_minutes"`
+}
+
+type ItineraryResponse struct {
+	ID             uuid.UUID              `json:"id"`
+	Title          string                 `json:"title"`
+	Description    string                 `json:"description"`
+	StartDate      *string                `json:"start_date,omitempty"`
+	Owner          UserInfo               `json:"owner"`
+	IsOwner        bool                   `json:"is_owner"`
+	ShareToken     *string                `json:"share_token,omitempty"` // Only shown to the owner
+	ShareURL       *string                `json:"share_url,omitempty"`   // Only shown to the owner
+	Days           []ItineraryDayResponse `json:"days"`
+	DistanceKm     float64                `json:"distance_km"`
+	DrivingMinutes int                    `json:"driving_minutes"`
+	CreatedAt      time.Time              `json:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+}
+
+type ItinerarySummaryResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	StartDate   *string   `json:"start_date,omitempty"`
+	DayCount    int       `json:"day_count"`
+	StopCount   int       `json:"stop_count"`
+	IsShared    bool      `json:"is_shared"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
@@ -7,13 +7,15 @@ import (
 )
 
 type CreateListRequest struct {
-	TitleAr       string `json:"title_ar" validate:"required"`
-	TitleEn       string `json:"title_en" validate:"required"`
-	Slug          string `json:"slug" validate:"required"`
-	DescriptionAr string `json:"description_ar"`
-	DescriptionEn string `json:"description_en"`
-	FeaturedImage string `json:"featured_image"`
-	Status        string `json:"status" validate:"omitempty,oneof=draft published archived"`
+	TitleAr       string     `json:"title_ar" validate:"required"`
+	TitleEn       string     `json:"title_en" validate:"required"`
+	Slug          string     `json:"slug" validate:"required"`
+	DescriptionAr string     `json:"description_ar"`
+	DescriptionEn string     `json:"description_en"`
+	FeaturedImage string     `json:"featured_image"`
+	Status        string     `json:"status" validate:"omitempty,oneof=draft published archived"`
+	PublishAt     *time.Time `json:"publish_at"`
+	UnpublishAt   *time.Time `json:"unpublish_at"`
 }
 
 type UpdateListRequest struct {
@@ -36,6 +38,8 @@ type ListResponse struct {
 	FeaturedImage    string                  `json:"featured_image"`
 	Status           string                  `json:"status"`
 	SortOrder        int                     `json:"sort_order"`
+	PublishAt        *time.Time              `json:"publish_at"`
+	UnpublishAt      *time.Time              `json:"unpublish_at"`
 	CreatedBy        uuid.UUID               `json:"created_by"`
 	CreatedAt        time.Time               `json:"created_at"`
 	UpdatedAt        time.Time               `json:"updated_at"`
@@ -45,16 +49,35 @@ type ListResponse struct {
 }
 
 type ListSummaryResponse struct {
-	ID            uuid.UUID `json:"id"`
-	TitleAr       string    `json:"title_ar"`
-	TitleEn       string    `json:"title_en"`
-	Slug          string    `json:"slug"`
-	DescriptionAr string    `json:"description_ar"`
-	DescriptionEn string    `json:"description_en"`
-	FeaturedImage string    `json:"featured_image"`
-	Status        string    `json:"status"`
-	ItemCount     int       `json:"item_count"`
-	CreatedAt     time.Time `json:"created_at"`
+	ID            uuid.UUID  `json:"id"`
+	TitleAr       string     `json:"title_ar"`
+	TitleEn       string     `json:"title_en"`
+	Slug          string     `json:"slug"`
+	DescriptionAr string     `json:"description_ar"`
+	DescriptionEn string     `json:"description_en"`
+	FeaturedImage string     `json:"featured_image"`
+	Status        string     `json:"status"`
+	PublishAt     *time.Time `json:"publish_at"`
+	UnpublishAt   *time.Time `json:"unpublish_at"`
+	ItemCount     int        `json:"item_count"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// SetListScheduleRequest replaces the publishing schedule of a list, null clears a time
+type SetListScheduleRequest struct {
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
+}
+
+// ScheduledListChange is an upcoming transition made by the list scheduler
+type ScheduledListChange struct {
+	ListID  uuid.UUID `json:"list_id"`
+	TitleAr string    `json:"title_ar"`
+	TitleEn string    `json:"title_en"`
+	Slug    string    `json:"slug"`
+	Status  string    `json:"status"`
+	Action  string    `json:"action"` // publish or unpublish
+	At      time.Time `json:"at"`
 }
 
 type ReorderListsRequest struct {
@@ -193,4 +216,75 @@ type ReorderListSectionsRequest struct {
 type ListSectionOrderItem struct {
 	SectionID uuid.UUID `json:"section_id" validate:"required"`
 	SortOrder int       `json:"sort_order" validate:"required"`
-}
\ No newline at end of file
+}
+
+// DuplicateListRequest overrides the titles and slug of the copy. The copy keeps the
+// source titles and gets "<slug>-copy" when they are left empty.
+type DuplicateListRequest struct {
+	TitleAr string `json:"title_ar"`
+	TitleEn string `json:"title_en"`
+	Slug    string `json:"slug"`
+}
+
+// List Template DTOs
+type ListTemplateSectionRequest struct {
+	TitleAr       string `json:"title_ar" validate:"required"`
+	TitleEn       string `json:"title_en" validate:"required"`
+	DescriptionAr string `json:"description_ar"`
+	DescriptionEn string `json:"description_en"`
+}
+
+type CreateListTemplateRequest struct {
+	NameAr        string                       `json:"name_ar" validate:"required"`
+	NameEn        string                       `json:"name_en" validate:"required"`
+	DescriptionAr string                       `json:"description_ar"`
+	DescriptionEn string                       `json:"description_en"`
+	Sections      []ListTemplateSectionRequest `json:"sections" validate:"dive"` // In the order lists get them
+}
+
+type UpdateListTemplateRequest struct {
+	NameAr        *string                       `json:"name_ar"`
+	NameEn        *string                       `json:"name_en"`
+	DescriptionAr *string                       `json:"description_ar"`
+	DescriptionEn *string                       `json:"description_en"`
+	Sections      *[]ListTemplateSectionRequest `json:"sections" validate:"omitempty,dive"` // Replaces every section when set
+}
+
+// SaveListAsTemplateRequest names a template made from the sections of an existing list
+type SaveListAsTemplateRequest struct {
+	NameAr        string `json:"name_ar" validate:"required"`
+	NameEn        string `json:"name_en" validate:"required"`
+	DescriptionAr string `json:"description_ar"`
+	DescriptionEn string `json:"description_en"`
+}
+
+// CreateListFromTemplateRequest starts a draft list with the sections of a template
+type CreateListFromTemplateRequest struct {
+	TitleAr       string `json:"title_ar" validate:"required"`
+	TitleEn       string `json:"title_en" validate:"required"`
+	Slug          string `json:"slug" validate:"required"`
+	DescriptionAr string `json:"description_ar"`
+	DescriptionEn string `json:"description_en"`
+	FeaturedImage string `json:"featured_image"`
+}
+
+type ListTemplateSectionResponse struct {
+	ID            uuid.UUID `json:"id"`
+	TitleAr       string    `json:"title_ar"`
+	TitleEn       string    `json:"title_en"`
+	DescriptionAr string    `json:"description_ar"`
+	DescriptionEn string    `json:"description_en"`
+	SortOrder     int       `json:"sort_order"`
+}
+
+type ListTemplateResponse struct {
+	ID            uuid.UUID                     `json:"id"`
+	NameAr        string                        `json:"name_ar"`
+	NameEn        string                        `json:"name_en"`
+	DescriptionAr string                        `json:"description_ar"`
+	DescriptionEn string                        `json:"description_en"`
+	CreatedBy     uuid.UUID                     `json:"created_by"`
+	CreatedAt     time.Time                     `json:"created_at"`
+	UpdatedAt     time.Time                     `json:"updated_at"`
+	Sections      []ListTemplateSectionResponse `json:"sections"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NotificationResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	Type       string     `json:"type"`
+	EntityType string     `json:"entity_type"`
+	EntityID   *uuid.UUID `json:"entity_id"`
+	PlaceID    *uuid.UUID `json:"place_id"`
+	Message    string     `json:"message"`
+	ReadAt     *time.Time `json:"read_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type NotificationListResponse struct {
+	PaginationResponse
+	UnreadCount int64 `json:"unread_count"`
+}
@@ -0,0 +1,64 @@
+// dto/opening_hours_dto.go
+package dto
+
+import "github.com/google/uuid"
+
+// Request DTOs
+
+// OpeningIntervalRequest is an "HH:MM"-"HH:MM" interval; closes_at before opens_at means overnight
+type OpeningIntervalRequest struct {
+	OpensAt  string `json:"opens_at" validate:"required"`
+	ClosesAt string `json:"closes_at" validate:"required"`
+}
+
+type WeeklyHoursRequest struct {
+	Weekday  int    `json:"weekday" validate:"min=0,max=6"` // 0 = Sunday ... 6 = Saturday
+	OpensAt  string `json:"opens_at" validate:"required"`
+	ClosesAt string `json:"closes_at" validate:"required"`
+}
+
+// UpdateOpeningHoursRequest replaces the whole weekly schedule; days without intervals are closed
+type UpdateOpeningHoursRequest struct {
+	Weekly []WeeklyHoursRequest `json:"weekly" validate:"dive"`
+}
+
+type HoursExceptionRequest struct {
+	NameAr    string                   `json:"name_ar"`
+	NameEn    string                   `json:"name_en" validate:"required"`
+	StartDate string                   `json:"start_date" validate:"required"` // 2006-01-02
+	EndDate   string                   `json:"end_date" validate:"required"`   // 2006-01-02, inclusive
+	IsClosed  bool                     `json:"is_closed"`
+	Intervals []OpeningIntervalRequest `json:"intervals" validate:"dive"`
+}
+
+// Response DTOs
+
+type OpeningIntervalResponse struct {
+	OpensAt  string `json:"opens_at"`
+	ClosesAt string `json:"closes_at"`
+}
+
+type DailyHoursResponse struct {
+	Weekday   int                       `json:"weekday"`
+	DayNameAr string                    `json:"day_name_ar"`
+	DayNameEn string                    `json:"day_name_en"`
+	Intervals []OpeningIntervalResponse `json:"intervals"` // Empty means closed
+}
+
+type HoursExceptionResponse struct {
+	ID        uuid.UUID                 `json:"id"`
+	NameAr    string                    `json:"name_ar"`
+	NameEn    string                    `json:"name_en"`
+	StartDate string                    `json:"start_date"`
+	EndDate   string                    `json:"end_date"`
+	IsClosed  bool                      `json:"is_closed"`
+	Intervals []OpeningIntervalResponse `json:"intervals"`
+}
+
+type OpeningHoursResponse struct {
+	Timezone    string                   `json:"timezone"`
+	HasSchedule bool                     `json:"has_schedule"`
+	IsOpenNow   *bool                    `json:"is_open_now,omitempty"` // Unknown when no schedule is set
+	Weekly      []DailyHoursResponse     `json:"weekly"`
+	Exceptions  []HoursExceptionResponse `json:"exceptions"` // Current and upcoming only
+}
@@ -101,6 +101,12 @@ type CreatePlaceRequest struct {
 	CategoryIDs   []string                       `json:"category_ids" validate:"required,min=1"`   // Accept as strings first
 	PropertyIDs   []string                       `json:"property_ids"`   // Accept as strings first
 	ContentSections []CreateContentSectionRequest `json:"content_sections,omitempty"`
+
+	// Identifier of the place in an external dataset, used to make bulk imports idempotent
+	ExternalRef   string                         `json:"external_ref" validate:"omitempty,max=100"`
+
+	// Create the place even if a likely duplicate exists; only honoured for users with can_manage_place
+	IgnoreDuplicates bool                        `json:"ignore_duplicates"`
 }
 
 type UpdatePlaceRequest struct {
@@ -160,6 +166,8 @@ type PlaceResponse struct {
 	ID              uuid.UUID                      `json:"id"`
 	NameAr          string                         `json:"name_ar"`
 	NameEn          string                         `json:"name_en"`
+	Slug            string                         `json:"slug"`
+	SlugAr          string                         `json:"slug_ar"`
 	DescriptionAr   string                         `json:"description_ar"`
 	DescriptionEn   string                         `json:"description_en"`
 	SubtitleAr      string                         `json:"subtitle_ar"`
@@ -173,13 +181,20 @@ type PlaceResponse struct {
 	Website         string                         `json:"website"`
 	Rating          float64                        `json:"rating"`
 	ReviewCount     int                            `json:"review_count"`
+	RatingDistribution []RatingBucket              `json:"rating_distribution"`
+	LastReviewAt    *time.Time                     `json:"last_review_at,omitempty"`
 	IsActive        bool                           `json:"is_active"`
+	Status          string                         `json:"status"`
 	Categories      []SimpleCategoryResponse       `json:"categories"`
 	Properties      []PropertyResponse             `json:"properties"`
 	Images          []ImageResponse                `json:"images"`
 	ContentSections []ContentSectionResponse       `json:"content_sections"`
+	OpeningHours    *OpeningHoursResponse          `json:"opening_hours,omitempty"`
 	CreatedAt       string                         `json:"created_at"`
 	UpdatedAt       string                         `json:"updated_at"`
+
+	// Possible duplicates found when the place was created, only set in the create response
+	DuplicateCandidates []DuplicateCandidate `json:"duplicate_candidates,omitempty"`
 }
 
 // Content Section Response
@@ -213,6 +228,8 @@ type ContentSectionImageResponse struct {
 type PlaceResponseLocalized struct {
 	ID              uuid.UUID                      `json:"id"`
 	Name            string                         `json:"name"`            // Single language name
+	Slug            string                         `json:"slug"`            // Slug in the requested language
+	AlternateSlug   string                         `json:"alternate_slug"`  // Slug in the other language, for language switchers
 	Description     string                         `json:"description"`     // Single language description  
 	Subtitle        string                         `json:"subtitle"`        // Single language subtitle
 	Governate       *SimpleGovernateLocalized      `json:"governate,omitempty"`
@@ -224,11 +241,15 @@ type PlaceResponseLocalized struct {
 	Website         string                         `json:"website"`
 	Rating          float64                        `json:"rating"`
 	ReviewCount     int                            `json:"review_count"`
+	RatingDistribution []RatingBucket              `json:"rating_distribution"`
+	LastReviewAt    *time.Time                     `json:"last_review_at,omitempty"`
 	IsActive        bool                           `json:"is_active"`
+	Status          string                         `json:"status"`
 	Categories      []SimpleCategoryLocalized      `json:"categories"`
 	Properties      []PropertyResponseLocalized    `json:"properties"`
 	Images          []ImageResponse                `json:"images"`
 	ContentSections []ContentSectionLocalized     `json:"content_sections"`
+	OpeningHours    *OpeningHoursResponse          `json:"opening_hours,omitempty"`
 	CreatedAt       string                         `json:"created_at"`
 	UpdatedAt       string                         `json:"updated_at"`
 }
@@ -334,4 +355,38 @@ func (r *CreatePlaceRequest) GetPropertyUUIDs() ([]uuid.UUID, error) {
 		uuids = append(uuids, id)
 	}
 	return uuids, nil
-}
\ No newline at end of file
+}
+// NearbyPlacesParams represents query parameters for the nearby places search
+type NearbyPlacesParams struct {
+	Latitude   float64    `query:"lat"`
+	Longitude  float64    `query:"lng"`
+	RadiusKm   float64    `query:"radius_km"`
+	CategoryID *uuid.UUID `query:"category_id"`
+	Limit      int        `query:"limit"`
+	OpenAt     *time.Time `query:"-"` // Set from open_now / open_at
+}
+
+// SetDefaults applies default radius and limit values and caps them to sane maximums
+func (p *NearbyPlacesParams) SetDefaults() {
+	if p.RadiusKm <= 0 {
+		p.RadiusKm = 10
+	}
+	if p.RadiusKm > 100 {
+		p.RadiusKm = 100
+	}
+	if p.Limit <= 0 {
+		p.Limit = 20
+	}
+	if p.Limit > 100 {
+		p.Limit = 100
+	}
+}
+
+// PlaceSlugResponse is returned by the slug lookup. Redirect is set when the requested slug is
+// an old one; clients should then replace the URL with Slug.
+type PlaceSlugResponse struct {
+	Place        *PlaceResponseLocalized `json:"place"`
+	Slug         string                  `json:"slug"` // Current slug
+	Redirect     bool                    `json:"redirect"`
+	RedirectFrom string                  `json:"redirect_from,omitempty"`
+}
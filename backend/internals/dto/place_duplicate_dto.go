@@ -0,0 +1,41 @@
+// dto/place_duplicate_dto.go
+package dto
+
+import "github.com/google/uuid"
+
+// DuplicateCandidate is an existing place that looks like the one being created
+type DuplicateCandidate struct {
+	PlaceID        uuid.UUID `json:"place_id"`
+	NameAr         string    `json:"name_ar"`
+	NameEn         string    `json:"name_en"`
+	Slug           string    `json:"slug"`
+	Status         string    `json:"status"`
+	DistanceKm     float64   `json:"distance_km"`
+	NameSimilarity float64   `json:"name_similarity"` // 0-1, best of the Arabic and English names
+	Strength       float64   `json:"strength"`        // 0-1, combined name and distance score
+	Blocking       bool      `json:"blocking"`        // Strong enough to reject the new place
+}
+
+type MergePlaceRequest struct {
+	DuplicateID uuid.UUID `json:"duplicate_id" validate:"required"`
+}
+
+type PlaceMergeResponse struct {
+	Place                *PlaceResponse `json:"place"`
+	MergedPlaceID        uuid.UUID      `json:"merged_place_id"`
+	ImagesMoved          int64          `json:"images_moved"`
+	ReviewsMoved         int64          `json:"reviews_moved"`
+	FavoritesMoved       int64          `json:"favorites_moved"`
+	PropertiesAdded      int64          `json:"properties_added"`
+	CategoriesAdded      int64          `json:"categories_added"`
+	ContentSectionsMoved int64          `json:"content_sections_moved"`
+	ListItemsMoved       int64          `json:"list_items_moved"`
+	ItineraryStopsMoved  int64          `json:"itinerary_stops_moved"`
+	PermissionsMoved     int64          `json:"permissions_moved"`
+}
+
+// PlaceRedirectResponse points from a merged place to the place that replaced it
+type PlaceRedirectResponse struct {
+	PlaceID uuid.UUID `json:"place_id"`
+	Slug    string    `json:"slug"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+import "github.com/google/uuid"
+
+// PlaceExportFeature is one place in a GeoJSON export
+type PlaceExportFeature struct {
+	Type       string                `json:"type"`
+	ID         uuid.UUID             `json:"id"`
+	Geometry   GeoJSONGeometry       `json:"geometry"`
+	Properties PlaceExportProperties `json:"properties"`
+}
+
+type PlaceExportProperties struct {
+	NameAr       string `json:"name_ar"`
+	NameEn       string `json:"name_en"`
+	Slug         string `json:"slug,omitempty"`
+	SlugAr       string `json:"slug_ar,omitempty"`
+	CategorySlug string `json:"category_slug,omitempty"`
+	CategoryAr   string `json:"category_ar,omitempty"`
+	CategoryEn   string `json:"category_en,omitempty"`
+	GovernateAr  string `json:"governate_ar,omitempty"`
+	GovernateEn  string `json:"governate_en,omitempty"`
+	WilayahAr    string `json:"wilayah_ar,omitempty"`
+	WilayahEn    string `json:"wilayah_en,omitempty"`
+	ImageURL     string `json:"image_url,omitempty"`
+	URL          string `json:"url"`
+	URLAr        string `json:"url_ar"`
+}
@@ -0,0 +1,75 @@
+// dto/place_import_dto.go
+package dto
+
+import "github.com/google/uuid"
+
+// PlaceImportRecord is one place read from an import file. Governate, wilayah and
+// categories are given by slug.
+type PlaceImportRecord struct {
+	Row            int      `json:"row"` // Line in a CSV file or 1-based feature index in GeoJSON
+	ExternalRef    string   `json:"external_ref"`
+	NameAr         string   `json:"name_ar"`
+	NameEn         string   `json:"name_en"`
+	DescriptionAr  string   `json:"description_ar"`
+	DescriptionEn  string   `json:"description_en"`
+	SubtitleAr     string   `json:"subtitle_ar"`
+	SubtitleEn     string   `json:"subtitle_en"`
+	GovernateSlug  string   `json:"governate"`
+	WilayahSlug    string   `json:"wilayah"`
+	CategorySlugs  []string `json:"categories"`
+	Latitude       float64  `json:"latitude"`
+	Longitude      float64  `json:"longitude"`
+	Phone          string   `json:"phone"`
+	Email          string   `json:"email"`
+	Website        string   `json:"website"`
+	HasCoordinates bool     `json:"-"`
+	Errors         []string `json:"-"` // Values that could not be read
+}
+
+// Import row actions
+const (
+	ImportActionCreate    = "create"
+	ImportActionUpdate    = "update"
+	ImportActionUnchanged = "unchanged"
+	ImportActionError     = "error"
+)
+
+type PlaceImportRowResult struct {
+	Row         int        `json:"row"`
+	ExternalRef string     `json:"external_ref"`
+	NameEn      string     `json:"name_en"`
+	Action      string     `json:"action"`
+	PlaceID     *uuid.UUID `json:"place_id,omitempty"`
+	Changes     []string   `json:"changes,omitempty"` // Fields an update changes
+	Errors      []string   `json:"errors,omitempty"`
+	Warnings    []string   `json:"warnings,omitempty"`
+}
+
+type PlaceImportReport struct {
+	DryRun        bool                   `json:"dry_run"`
+	CreatedStatus string                 `json:"created_status"` // Created places need a moderator's approval to go live
+	Total         int                    `json:"total"`
+	Created       int                    `json:"created"`
+	Updated       int                    `json:"updated"`
+	Unchanged     int                    `json:"unchanged"`
+	Failed        int                    `json:"failed"`
+	Rows          []PlaceImportRowResult `json:"rows"`
+}
+
+// GeoJSON structures used by the import and export; only Point geometries are supported
+type GeoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []GeoJSONFeature `json:"features"`
+}
+
+type GeoJSONFeature struct {
+	Type       string                 `json:"type"`
+	ID         interface{}            `json:"id,omitempty"`
+	Geometry   *GeoJSONGeometry       `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+type GeoJSONGeometry struct {
+	Type        string    `json:"type"`
+	Coordinates []float64 `json:"coordinates"` // [longitude, latitude]
+}
@@ -0,0 +1,26 @@
+// dto/place_moderation_dto.go
+package dto
+
+import "github.com/google/uuid"
+
+// ModerationActionRequest carries the optional comment of a submit/approve/reject/archive action
+type ModerationActionRequest struct {
+	Comment string `json:"comment" validate:"max=2000"`
+}
+
+type PlaceModerationEventResponse struct {
+	ID         uuid.UUID     `json:"id"`
+	FromStatus string        `json:"from_status"`
+	ToStatus   string        `json:"to_status"`
+	Comment    string        `json:"comment"`
+	Actor      *UserResponse `json:"actor,omitempty"`
+	CreatedAt  string        `json:"created_at"`
+}
+
+// ModerationQueueItem is a place awaiting review together with its latest moderation event
+type ModerationQueueItem struct {
+	PlaceListResponse
+	CreatedBy   uuid.UUID                     `json:"created_by"`
+	UpdatedAt   string                        `json:"updated_at"`
+	LatestEvent *PlaceModerationEventResponse `json:"latest_event,omitempty"`
+}
@@ -0,0 +1,23 @@
+// dto/place_permission_dto.go
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GrantPlacePermissionRequest gives a user a permission on a single place
+type GrantPlacePermissionRequest struct {
+	UserID     uuid.UUID `json:"user_id" validate:"required"`
+	Permission string    `json:"permission" validate:"required"`
+}
+
+type PlacePermissionResponse struct {
+	ID         uuid.UUID `json:"id"`
+	PlaceID    uuid.UUID `json:"place_id"`
+	Permission string    `json:"permission"`
+	User       UserInfo  `json:"user"`
+	GrantedBy  uuid.UUID `json:"granted_by"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,30 @@
+// dto/place_query_dto.go
+package dto
+
+import "github.com/google/uuid"
+
+// FacetCount is the number of matching places for one filter option
+type FacetCount struct {
+	ID     uuid.UUID `json:"id"`
+	NameAr string    `json:"name_ar"`
+	NameEn string    `json:"name_en"`
+	Count  int64     `json:"count"`
+}
+
+// PlaceFacets groups facet counts by filter dimension. Each dimension is counted with
+// every other filter applied but not its own, so unchecked options still show counts.
+type PlaceFacets struct {
+	Categories []FacetCount `json:"categories"`
+	Properties []FacetCount `json:"properties"`
+	Governates []FacetCount `json:"governates"`
+}
+
+// PlaceQueryResponse is the result of a faceted place query
+type PlaceQueryResponse struct {
+	Places     []PlaceListResponse `json:"places"`
+	Page       int                 `json:"page"`
+	Limit      int                 `json:"limit"`
+	Total      int64               `json:"total"`
+	TotalPages int                 `json:"total_pages"`
+	Facets     PlaceFacets         `json:"facets"`
+}
@@ -0,0 +1,37 @@
+// dto/place_revision_dto.go
+package dto
+
+import (
+	"almlah/internals/domain"
+
+	"github.com/google/uuid"
+)
+
+type PlaceRevisionSummary struct {
+	ID             uuid.UUID     `json:"id"`
+	RevisionNumber int           `json:"revision_number"`
+	ChangeType     string        `json:"change_type"`
+	Summary        string        `json:"summary"`
+	Author         *UserResponse `json:"author,omitempty"`
+	CreatedAt      string        `json:"created_at"`
+}
+
+type PlaceRevisionResponse struct {
+	PlaceRevisionSummary
+	Snapshot domain.PlaceSnapshot `json:"snapshot"`
+}
+
+// FieldChange is one changed field between two revisions. Content section fields are
+// addressed as content_sections.<section id>.<field>.
+type FieldChange struct {
+	Field    string      `json:"field"`
+	OldValue interface{} `json:"old_value"`
+	NewValue interface{} `json:"new_value"`
+}
+
+type PlaceRevisionDiffResponse struct {
+	PlaceID      uuid.UUID     `json:"place_id"`
+	FromRevision int           `json:"from_revision"`
+	ToRevision   int           `json:"to_revision"`
+	Changes      []FieldChange `json:"changes"`
+}
@@ -0,0 +1,40 @@
+// dto/place_search_dto.go
+package dto
+
+import "time"
+
+// PlaceSearchResult is a ranked search hit with highlighted text
+type PlaceSearchResult struct {
+	PlaceListResponse
+	Score      float64               `json:"score"`
+	Highlights PlaceSearchHighlights `json:"highlights"`
+}
+
+// PlaceSearchHighlights holds matched text wrapped in <mark></mark>; fields without a match are omitted
+type PlaceSearchHighlights struct {
+	NameAr  string `json:"name_ar,omitempty"`
+	NameEn  string `json:"name_en,omitempty"`
+	Snippet string `json:"snippet,omitempty"` // Excerpt from subtitle or description
+}
+
+// PlaceSearchRequest represents query parameters for place search
+type PlaceSearchRequest struct {
+	Query string `query:"q" validate:"required"`
+	Page  int    `query:"page"`
+	Limit int    `query:"limit"`
+
+	OpenAt *time.Time `query:"-"` // Set from open_now / open_at
+}
+
+// SetDefaults applies default pagination values
+func (r *PlaceSearchRequest) SetDefaults() {
+	if r.Page < 1 {
+		r.Page = 1
+	}
+	if r.Limit < 1 {
+		r.Limit = 20
+	}
+	if r.Limit > 100 {
+		r.Limit = 100
+	}
+}
@@ -0,0 +1,26 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreatePreviewTokenRequest mints a preview link for one draft list or place
+type CreatePreviewTokenRequest struct {
+	EntityType     string    `json:"entity_type" validate:"required,oneof=list place"`
+	EntityID       uuid.UUID `json:"entity_id" validate:"required"`
+	ExpiresInHours int       `json:"expires_in_hours" validate:"omitempty,min=1,max=720"` // Defaults to 72
+}
+
+type PreviewTokenResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	EntityType string     `json:"entity_type"`
+	EntityID   uuid.UUID  `json:"entity_id"`
+	Token      string     `json:"token"` // Pass as ?preview_token= to the public GET endpoint of the entity
+	ExpiresAt  time.Time  `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	IsActive   bool       `json:"is_active"`
+	CreatedBy  uuid.UUID  `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
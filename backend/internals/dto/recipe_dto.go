@@ -5,31 +5,91 @@ import (
 	"github.com/google/uuid"
 )
 
+type RecipeIngredientRequest struct {
+	NameAr   string   `json:"name_ar" validate:"required,max=200"`
+	NameEn   string   `json:"name_en" validate:"required,max=200"`
+	Quantity *float64 `json:"quantity" validate:"omitempty,gt=0"`
+	Unit     string   `json:"unit" validate:"omitempty,oneof=g kg ml l tsp tbsp cup piece pinch to_taste"`
+	Note     string   `json:"note" validate:"max=500"`
+}
+
+// RecipeStepRequest is one instruction; steps are numbered in the order they are sent
+type RecipeStepRequest struct {
+	Instruction     string `json:"instruction" validate:"required"`
+	DurationMinutes *int   `json:"duration_minutes" validate:"omitempty,min=0,max=1440"`
+}
+
 type CreateRecipeRequest struct {
-	Title        string `json:"title" validate:"required"`
-	Description  string `json:"description"`
-	CuisineType  string `json:"cuisine_type"`
-	Difficulty   string `json:"difficulty"`
-	PrepTime     int    `json:"prep_time"`
-	CookTime     int    `json:"cook_time"`
-	Servings     int    `json:"servings"`
-	Ingredients  string `json:"ingredients" validate:"required"`
-	Instructions string `json:"instructions" validate:"required"`
+	Title       string                    `json:"title" validate:"required"`
+	Description string                    `json:"description"`
+	CuisineType string                    `json:"cuisine_type"`
+	Difficulty  string                    `json:"difficulty"`
+	PrepTime    int                       `json:"prep_time"`
+	CookTime    int                       `json:"cook_time"`
+	Servings    int                       `json:"servings"`
+	DishID      *uuid.UUID                `json:"dish_id"`
+	Ingredients []RecipeIngredientRequest `json:"ingredients" validate:"required,min=1,max=100,dive"`
+	Steps       []RecipeStepRequest       `json:"steps" validate:"required,min=1,max=100,dive"`
+}
+
+// UpdateRecipeRequest changes the given fields. Ingredients and steps replace the whole
+// list when present; a nil uuid dish_id unlinks the dish.
+type UpdateRecipeRequest struct {
+	Title       *string                    `json:"title" validate:"omitempty,min=1"`
+	Description *string                    `json:"description"`
+	CuisineType *string                    `json:"cuisine_type"`
+	Difficulty  *string                    `json:"difficulty"`
+	PrepTime    *int                       `json:"prep_time"`
+	CookTime    *int                       `json:"cook_time"`
+	Servings    *int                       `json:"servings"`
+	DishID      *uuid.UUID                 `json:"dish_id"`
+	IsPublished *bool                      `json:"is_published"`
+	Ingredients *[]RecipeIngredientRequest `json:"ingredients" validate:"omitempty,min=1,max=100,dive"`
+	Steps       *[]RecipeStepRequest       `json:"steps" validate:"omitempty,min=1,max=100,dive"`
+}
+
+// CreateRecipeImageRequest adds an image to a recipe, or to one of its steps when
+// step_number is set
+type CreateRecipeImageRequest struct {
+	ImageURL     string `json:"image_url" validate:"required,url"`
+	AltText      string `json:"alt_text"`
+	StepNumber   int    `json:"step_number" validate:"min=0"`
+	DisplayOrder int    `json:"display_order"`
+}
+
+type RecipeIngredientResponse struct {
+	ID       uuid.UUID `json:"id"`
+	NameAr   string    `json:"name_ar"`
+	NameEn   string    `json:"name_en"`
+	Quantity *float64  `json:"quantity,omitempty"`
+	Unit     string    `json:"unit"`
+	Note     string    `json:"note"`
+}
+
+type RecipeStepResponse struct {
+	ID              uuid.UUID       `json:"id"`
+	StepNumber      int             `json:"step_number"`
+	Instruction     string          `json:"instruction"`
+	DurationMinutes *int            `json:"duration_minutes,omitempty"`
+	Images          []ImageResponse `json:"images"`
 }
 
 type RecipeResponse struct {
-	ID           uuid.UUID       `json:"id"`
-	Title        string          `json:"title"`
-	Description  string          `json:"description"`
-	CuisineType  string          `json:"cuisine_type"`
-	Difficulty   string          `json:"difficulty"`
-	PrepTime     int             `json:"prep_time"`
-	CookTime     int             `json:"cook_time"`
-	Servings     int             `json:"servings"`
-	Ingredients  string          `json:"ingredients"`
-	Instructions string          `json:"instructions"`
-	ViewCount    int             `json:"view_count"`
-	CreatedAt    time.Time       `json:"created_at"`
-	Author       UserInfo        `json:"author"`
-	Images       []ImageResponse `json:"images"`
+	ID          uuid.UUID                  `json:"id"`
+	Title       string                     `json:"title"`
+	Description string                     `json:"description"`
+	CuisineType string                     `json:"cuisine_type"`
+	Difficulty  string                     `json:"difficulty"`
+	PrepTime    int                        `json:"prep_time"`
+	CookTime    int                        `json:"cook_time"`
+	Servings    int                        `json:"servings"`
+	Dish        *SimpleDishResponse        `json:"dish,omitempty"`
+	Ingredients []RecipeIngredientResponse `json:"ingredients"`
+	Steps       []RecipeStepResponse       `json:"steps"`
+	IsPublished bool                       `json:"is_published"`
+	ViewCount   int                        `json:"view_count"`
+	CreatedAt   time.Time                  `json:"created_at"`
+	UpdatedAt   time.Time                  `json:"updated_at"`
+	Author      UserInfo                   `json:"author"`
+	Images      []ImageResponse            `json:"images"` // Images of the whole recipe, step images are on the steps
 }
@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"mime/multipart"
 	"time"
 	"github.com/google/uuid"
 )
@@ -11,19 +12,122 @@ type CreateReviewRequest struct {
 	Title      string     `json:"title"`
 	ReviewText string     `json:"review_text"`
 	VisitDate  *time.Time `json:"visit_date"`
+
+	Images []ReviewImageUpload `json:"-"` // Photos of a multipart request
+}
+
+// ReviewImageUpload is a photo sent with a multipart review form
+type ReviewImageUpload struct {
+	File    *multipart.FileHeader
+	Caption string
+}
+
+// UpdateReviewRequest changes the fields that are set; a title or text can be cleared with ""
+type UpdateReviewRequest struct {
+	Rating     *int       `json:"rating" validate:"omitempty,min=1,max=5"`
+	Title      *string    `json:"title"`
+	ReviewText *string    `json:"review_text"`
+	VisitDate  *time.Time `json:"visit_date"`
+
+	Images []ReviewImageUpload `json:"-"` // Photos added by a multipart request
 }
 
 type ReviewResponse struct {
-	ID           uuid.UUID       `json:"id"`
-	PlaceID      uuid.UUID       `json:"place_id"`
-	Rating       int             `json:"rating"`
-	Title        string          `json:"title"`
-	ReviewText   string          `json:"review_text"`
-	VisitDate    *time.Time      `json:"visit_date"`
-	IsVerified   bool            `json:"is_verified"`
-	HelpfulCount int             `json:"helpful_count"`
-	CreatedAt    time.Time       `json:"created_at"`
-	Author       UserInfo        `json:"author"`
-	Images       []ImageResponse `json:"images"`
+	ID             uuid.UUID            `json:"id"`
+	PlaceID        uuid.UUID            `json:"place_id"`
+	Rating         int                  `json:"rating"`
+	Title          string               `json:"title"`
+	ReviewText     string               `json:"review_text"`
+	VisitDate      *time.Time           `json:"visit_date"`
+	IsVerified     bool                 `json:"is_verified"`
+	HelpfulCount   int                  `json:"helpful_count"`
+	UnhelpfulCount int                  `json:"unhelpful_count"`
+	CreatedAt      time.Time            `json:"created_at"`
+	EditedAt       *time.Time           `json:"edited_at,omitempty"`
+	Author         UserInfo             `json:"author"`
+	Images         []ImageResponse      `json:"images"`
+	Reply          *ReviewReplyResponse `json:"reply,omitempty"` // Official reply of the place
+}
+
+// ReviewFilters selects and orders the reviews of a place
+type ReviewFilters struct {
+	Ratings  []int  `json:"ratings"` // Star ratings to include, all when empty
+	SortBy   string `json:"sort_by"` // newest (default), oldest, most_helpful, highest, lowest
+	Page     int    `json:"page"`
+	PageSize int    `json:"page_size"`
+}
+
+type ReviewListResponse struct {
+	Reviews    []ReviewResponse `json:"reviews"`
+	Total      int64            `json:"total"`
+	Page       int              `json:"page"`
+	PageSize   int              `json:"page_size"`
+	TotalPages int              `json:"total_pages"`
+}
+
+// ReviewVoteResponse is the vote tally of a review after a user voted
+type ReviewVoteResponse struct {
+	ReviewID       uuid.UUID `json:"review_id"`
+	HelpfulCount   int       `json:"helpful_count"`
+	UnhelpfulCount int       `json:"unhelpful_count"`
+	UserVote       string    `json:"user_vote"` // helpful, unhelpful or empty
+}
+
+// ReviewReplyRequest posts or edits the official reply to a review
+type ReviewReplyRequest struct {
+	ReplyText string `json:"reply_text" validate:"required,max=5000"`
 }
 
+type ReviewReplyResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	ReviewID  uuid.UUID  `json:"review_id"`
+	ReplyText string     `json:"reply_text"`
+	Author    UserInfo   `json:"author"`
+	CreatedAt time.Time  `json:"created_at"`
+	EditedAt  *time.Time `json:"edited_at,omitempty"`
+}
+
+// ReviewReplyRevisionResponse is the text a reply had before one of its edits
+type ReviewReplyRevisionResponse struct {
+	ID         uuid.UUID `json:"id"`
+	ReplyText  string    `json:"reply_text"`
+	EditedBy   UserInfo  `json:"edited_by"`
+	ReplacedAt time.Time `json:"replaced_at"`
+}
+
+// ReviewPhotoResponse is a visitor photo in a place's gallery
+type ReviewPhotoResponse struct {
+	ID         uuid.UUID `json:"id"`
+	ReviewID   uuid.UUID `json:"review_id"`
+	ImageURL   string    `json:"image_url"`
+	Caption    string    `json:"caption"`
+	Rating     int       `json:"rating"` // Rating of the review the photo belongs to
+	Author     UserInfo  `json:"author"`
+	UploadDate time.Time `json:"upload_date"`
+}
+
+// ReviewRevisionResponse is the content a review had before one of its edits
+type ReviewRevisionResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	Rating     int        `json:"rating"`
+	Title      string     `json:"title"`
+	ReviewText string     `json:"review_text"`
+	VisitDate  *time.Time `json:"visit_date"`
+	EditedBy   UserInfo   `json:"edited_by"`
+	ReplacedAt time.Time  `json:"replaced_at"`
+}
+
+// PlaceRatingSummary is the rating breakdown of a place
+type PlaceRatingSummary struct {
+	PlaceID      uuid.UUID      `json:"place_id"`
+	Average      float64        `json:"average"`
+	ReviewCount  int            `json:"review_count"`
+	Distribution []RatingBucket `json:"distribution"` // 5 stars first
+	LastReviewAt *time.Time     `json:"last_review_at"`
+}
+
+type RatingBucket struct {
+	Stars   int     `json:"stars"`
+	Count   int     `json:"count"`
+	Percent float64 `json:"percent"`
+}
@@ -0,0 +1,52 @@
+// dto/review_moderation_dto.go
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReportReviewRequest flags a review for the moderators
+type ReportReviewRequest struct {
+	Reason  string `json:"reason" validate:"required,oneof=spam offensive off_topic fake"`
+	Comment string `json:"comment" validate:"max=1000"`
+}
+
+type ReviewReportResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	ReviewID   uuid.UUID  `json:"review_id"`
+	Reason     string     `json:"reason"`
+	Comment    string     `json:"comment"`
+	Status     string     `json:"status"`
+	Reporter   *UserInfo  `json:"reporter,omitempty"` // Only shown to moderators
+	CreatedAt  time.Time  `json:"created_at"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+}
+
+// ReviewModerationRequest is a moderator's decision on a reported review. A comment is
+// required when warning the author, it is sent to them.
+type ReviewModerationRequest struct {
+	Action  string `json:"action" validate:"required,oneof=dismiss hide delete warn"`
+	Comment string `json:"comment" validate:"max=2000"`
+}
+
+type ReviewModerationActionResponse struct {
+	ID              uuid.UUID `json:"id"`
+	ReviewID        uuid.UUID `json:"review_id"`
+	PlaceID         uuid.UUID `json:"place_id"`
+	Action          string    `json:"action"`
+	Comment         string    `json:"comment"`
+	ReportsResolved int       `json:"reports_resolved"`
+	Actor           *UserInfo `json:"actor,omitempty"` // Empty for automatic actions
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// ReviewModerationQueueItem is a reported review with its open reports, most reported first
+type ReviewModerationQueueItem struct {
+	Review      ReviewResponse         `json:"review"`
+	IsHidden    bool                   `json:"is_hidden"`
+	ReportCount int                    `json:"report_count"`
+	Reasons     map[string]int         `json:"reasons"` // Open reports per reason
+	Reports     []ReviewReportResponse `json:"reports"`
+}
@@ -30,4 +30,18 @@ func AuthRequired(ctx *fiber.Ctx) error {
 	// Store userID as UUID in context
 	ctx.Locals("userID", userID)
 	return ctx.Next()
-}
\ No newline at end of file
+}
+
+// OptionalAuth stores the userID of callers that send a valid token and lets everyone
+// else through, for public routes that add per-user details when signed in
+func OptionalAuth(ctx *fiber.Ctx) error {
+	tokenParts := strings.Split(ctx.Get("Authorization"), " ")
+	if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
+		return ctx.Next()
+	}
+
+	if userID, err := utils.ValidateJWT(tokenParts[1]); err == nil {
+		ctx.Locals("userID", userID)
+	}
+	return ctx.Next()
+}
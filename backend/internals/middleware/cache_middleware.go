@@ -15,6 +15,7 @@ type CacheConfig struct {
 	TTL          time.Duration
 	SkipQuery    bool
 	KeyGenerator func(c *fiber.Ctx) string
+	Skip         func(c *fiber.Ctx) bool // Requests it returns true for are neither served from nor stored in the cache
 }
 
 // DefaultCacheConfig provides default cache settings
@@ -60,6 +61,11 @@ func CacheMiddleware(config ...CacheConfig) fiber.Handler {
 			return c.Next()
 		}
 
+		// Skip responses that must not be shared, e.g. previews of drafts
+		if cfg.Skip != nil && cfg.Skip(c) {
+			return c.Next()
+		}
+
 		// Generate cache key
 		key := cfg.KeyGenerator(c)
 
@@ -0,0 +1,490 @@
+// services/advice_service.go
+package services
+
+import (
+	"almlah/config"
+	"almlah/internals/cache"
+	"almlah/internals/domain"
+	"almlah/internals/dto"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const maxAdvicePlaces = 50
+
+// Views are counted in Redis and added to the database by a periodic flush, so reading
+// advice doesn't write to the advices table
+const (
+	adviceViewsPendingKey   = "advice_views_pending"
+	adviceViewFlushInterval = time.Minute
+	adviceViewFlushBatch    = 100
+)
+
+func adviceViewKey(adviceID uuid.UUID) string {
+	return "advice_views:" + adviceID.String()
+}
+
+// Advice CRUD Operations
+
+func CreateAdvice(req dto.CreateAdviceRequest, userID uuid.UUID) (*dto.AdviceResponse, error) {
+	governateID, wilayahID, err := resolveAdviceRegion(config.DB, req.GovernateID, req.WilayahID)
+	if err != nil {
+		return nil, err
+	}
+	places, err := findAdvicePlaces(config.DB, req.PlaceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	advice := domain.Advice{
+		TitleAr:     strings.TrimSpace(req.TitleAr),
+		TitleEn:     strings.TrimSpace(req.TitleEn),
+		ContentAr:   strings.TrimSpace(req.ContentAr),
+		ContentEn:   strings.TrimSpace(req.ContentEn),
+		AdviceType:  req.AdviceType,
+		GovernateID: governateID,
+		WilayahID:   wilayahID,
+		CreatedBy:   userID,
+		IsFeatured:  req.IsFeatured,
+		IsPublished: req.IsPublished,
+		Places:      places,
+	}
+	if advice.AdviceType == "" {
+		advice.AdviceType = domain.AdviceTypeGeneral
+	}
+	if advice.IsPublished {
+		now := time.Now()
+		advice.PublishedAt = &now
+	}
+
+	// Places are only linked, never created or updated through advice
+	err = config.DB.Omit("Creator", "Governate", "Wilayah", "Places.*").Create(&advice).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to create advice: %w", err)
+	}
+
+	return GetAdviceByID(advice.ID, true)
+}
+
+// GetAdviceByID returns a piece of advice. Unpublished advice is only returned with
+// includeUnpublished.
+func GetAdviceByID(adviceID uuid.UUID, includeUnpublished bool) (*dto.AdviceResponse, error) {
+	query := adviceWithRelations(config.DB).Where("id = ?", adviceID)
+	if !includeUnpublished {
+		query = query.Where("is_published = ?", true)
+	}
+
+	var advice domain.Advice
+	if err := query.First(&advice).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("advice not found")
+		}
+		return nil, err
+	}
+
+	response := mapAdviceToResponse(advice)
+	if pending, err := cache.GetCounter(adviceViewKey(advice.ID)); err == nil {
+		response.ViewCount += int(pending)
+	}
+	return &response, nil
+}
+
+// GetAdvice lists advice matching the filters, featured advice first
+func GetAdvice(filters dto.AdviceFilters) (*dto.PaginationResponse, error) {
+	query := config.DB.Model(&domain.Advice{})
+	if !filters.IncludeUnpublished {
+		query = query.Where("advices.is_published = ?", true)
+	}
+	if filters.AdviceType != "" {
+		query = query.Where("advices.advice_type = ?", filters.AdviceType)
+	}
+	if filters.IsFeatured != nil {
+		query = query.Where("advices.is_featured = ?", *filters.IsFeatured)
+	}
+	if filters.GovernateID != nil {
+		query = query.Where("advices.governate_id = ?", *filters.GovernateID)
+	}
+	if filters.WilayahID != nil {
+		var wilayah domain.Wilayah
+		if err := config.DB.Select("id", "governate_id").First(&wilayah, "id = ?", *filters.WilayahID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("wilayah not found")
+			}
+			return nil, err
+		}
+		query = query.Where("advices.wilayah_id = ? OR (advices.governate_id = ? AND advices.wilayah_id IS NULL)",
+			wilayah.ID, wilayah.GovernateID)
+	}
+	if filters.PlaceID != nil {
+		var place domain.Place
+		if err := config.DB.Select("id", "governate_id", "wilayah_id").First(&place, "id = ?", *filters.PlaceID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("place not found")
+			}
+			return nil, err
+		}
+		query = query.Where(`advices.id IN (SELECT advice_id FROM advice_places WHERE place_id = ?)
+			OR advices.wilayah_id = ?
+			OR (advices.governate_id = ? AND advices.wilayah_id IS NULL)`,
+			place.ID, place.WilayahID, place.GovernateID)
+	}
+	if search := strings.TrimSpace(filters.Search); search != "" {
+		term := "%" + strings.ToLower(search) + "%"
+		query = query.Where("LOWER(advices.title_ar) LIKE ? OR LOWER(advices.title_en) LIKE ? OR LOWER(advices.content_ar) LIKE ? OR LOWER(advices.content_en) LIKE ?",
+			term, term, term, term)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count advice: %w", err)
+	}
+
+	var advices []domain.Advice
+	err := adviceWithRelations(query).
+		Order("advices.is_featured DESC, COALESCE(advices.published_at, advices.created_at) DESC, advices.id").
+		Offset((filters.Page - 1) * filters.Limit).
+		Limit(filters.Limit).
+		Find(&advices).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load advice: %w", err)
+	}
+
+	responses := make([]dto.AdviceResponse, 0, len(advices))
+	for _, advice := range advices {
+		responses = append(responses, mapAdviceToResponse(advice))
+	}
+
+	return &dto.PaginationResponse{
+		Page:       filters.Page,
+		Limit:      filters.Limit,
+		Total:      total,
+		TotalPages: int((total + int64(filters.Limit) - 1) / int64(filters.Limit)),
+		Data:       responses,
+	}, nil
+}
+
+func UpdateAdvice(adviceID uuid.UUID, req dto.UpdateAdviceRequest) (*dto.AdviceResponse, error) {
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		var advice domain.Advice
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&advice, "id = ?", adviceID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("advice not found")
+			}
+			return err
+		}
+
+		updates := map[string]interface{}{}
+		for column, value := range map[string]*string{
+			"title_ar":   req.TitleAr,
+			"title_en":   req.TitleEn,
+			"content_ar": req.ContentAr,
+			"content_en": req.ContentEn,
+		} {
+			if value == nil {
+				continue
+			}
+			trimmed := strings.TrimSpace(*value)
+			if trimmed == "" {
+				return fmt.Errorf("invalid advice: %s cannot be empty", column)
+			}
+			updates[column] = trimmed
+		}
+		if req.AdviceType != nil {
+			updates["advice_type"] = *req.AdviceType
+		}
+
+		if req.GovernateID != nil || req.WilayahID != nil {
+			governateID, wilayahID := advice.GovernateID, advice.WilayahID
+			if req.GovernateID != nil {
+				governateID = nilIfZeroUUID(*req.GovernateID)
+			}
+			if req.WilayahID != nil {
+				wilayahID = nilIfZeroUUID(*req.WilayahID)
+			}
+			governateID, wilayahID, err := resolveAdviceRegion(tx, governateID, wilayahID)
+			if err != nil {
+				return err
+			}
+			updates["governate_id"] = governateID
+			updates["wilayah_id"] = wilayahID
+		}
+
+		if req.PlaceIDs != nil {
+			places, err := findAdvicePlaces(tx, *req.PlaceIDs)
+			if err != nil {
+				return err
+			}
+			if err := tx.Model(&advice).Omit("Places.*").Association("Places").Replace(places); err != nil {
+				return fmt.Errorf("failed to update advice places: %w", err)
+			}
+			updates["updated_at"] = time.Now()
+		}
+
+		if len(updates) == 0 {
+			return nil
+		}
+		if err := tx.Model(&advice).Updates(updates).Error; err != nil {
+			return fmt.Errorf("failed to update advice: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return GetAdviceByID(adviceID, true)
+}
+
+// DeleteAdvice soft deletes advice and drops its pending views
+func DeleteAdvice(adviceID uuid.UUID) error {
+	result := config.DB.Delete(&domain.Advice{}, "id = ?", adviceID)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete advice: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("advice not found")
+	}
+
+	cache.InvalidateKeys(adviceViewKey(adviceID))
+	return nil
+}
+
+func SetAdviceFeatured(adviceID uuid.UUID, featured bool) (*dto.AdviceResponse, error) {
+	result := config.DB.Model(&domain.Advice{}).Where("id = ?", adviceID).Update("is_featured", featured)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to update advice: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, errors.New("advice not found")
+	}
+
+	return GetAdviceByID(adviceID, true)
+}
+
+// SetAdvicePublished publishes or unpublishes advice. PublishedAt keeps the first
+// publication date when advice is published again.
+func SetAdvicePublished(adviceID uuid.UUID, published bool) (*dto.AdviceResponse, error) {
+	updates := map[string]interface{}{"is_published": published}
+	if published {
+		updates["published_at"] = gorm.Expr("COALESCE(published_at, ?)", time.Now())
+	}
+
+	result := config.DB.Model(&domain.Advice{}).Where("id = ?", adviceID).Updates(updates)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to update advice: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, errors.New("advice not found")
+	}
+
+	return GetAdviceByID(adviceID, true)
+}
+
+// View Counting
+
+// RecordAdviceView counts a view of published advice. Without Redis the view is written
+// to the database right away.
+func RecordAdviceView(adviceID uuid.UUID) {
+	err := cache.IncrementPending(adviceViewKey(adviceID), adviceViewsPendingKey, adviceID.String(), 1)
+	if err == nil {
+		return
+	}
+
+	err = config.DB.Model(&domain.Advice{}).
+		Where("id = ?", adviceID).
+		UpdateColumn("view_count", gorm.Expr("view_count + 1")).Error
+	if err != nil {
+		log.Printf("Warning: Failed to count view of advice %s: %v", adviceID, err)
+	}
+}
+
+// FlushAdviceViews adds the views counted in Redis to the database and returns how many
+// pieces of advice were updated
+func FlushAdviceViews() (int, error) {
+	flushed := 0
+	for {
+		members, err := cache.PopPending(adviceViewsPendingKey, adviceViewFlushBatch)
+		if err != nil {
+			return flushed, err
+		}
+		if len(members) == 0 {
+			return flushed, nil
+		}
+
+		for _, member := range members {
+			adviceID, err := uuid.Parse(member)
+			if err != nil {
+				continue
+			}
+			views, err := cache.TakeCounter(adviceViewKey(adviceID))
+			if err != nil {
+				return flushed, err
+			}
+			if views == 0 {
+				continue
+			}
+
+			err = config.DB.Model(&domain.Advice{}).
+				Where("id = ?", adviceID).
+				UpdateColumn("view_count", gorm.Expr("view_count + ?", views)).Error
+			if err != nil {
+				// Put the views back so the next flush retries them
+				if restoreErr := cache.IncrementPending(adviceViewKey(adviceID), adviceViewsPendingKey, member, views); restoreErr != nil {
+					log.Printf("Warning: Lost %d views of advice %s: %v", views, adviceID, restoreErr)
+				}
+				return flushed, fmt.Errorf("failed to save advice views: %w", err)
+			}
+			flushed++
+		}
+	}
+}
+
+// StartAdviceViewFlusher flushes advice views to the database every minute until the
+// process exits
+func StartAdviceViewFlusher() {
+	go func() {
+		ticker := time.NewTicker(adviceViewFlushInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if !cache.IsAvailable() {
+				continue
+			}
+			if _, err := FlushAdviceViews(); err != nil {
+				log.Printf("Warning: Failed to flush advice views: %v", err)
+			}
+		}
+	}()
+}
+
+// Helper functions
+
+func adviceWithRelations(query *gorm.DB) *gorm.DB {
+	return query.
+		Preload("Creator").
+		Preload("Governate").
+		Preload("Wilayah").
+		Preload("Places", func(db *gorm.DB) *gorm.DB {
+			return db.Scopes(publishedPlacesOnly).Order("places.name_en ASC")
+		}).
+		Preload("Places.Categories").
+		Preload("Places.Images").
+		Preload("Places.Governate").
+		Preload("Places.Wilayah")
+}
+
+// resolveAdviceRegion checks the governate and wilayah of advice. The governate of a
+// wilayah is filled in when it's missing.
+func resolveAdviceRegion(tx *gorm.DB, governateID, wilayahID *uuid.UUID) (*uuid.UUID, *uuid.UUID, error) {
+	if wilayahID != nil {
+		var wilayah domain.Wilayah
+		if err := tx.Select("id", "governate_id").First(&wilayah, "id = ?", *wilayahID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, nil, errors.New("invalid advice: wilayah not found")
+			}
+			return nil, nil, err
+		}
+		if governateID != nil && *governateID != wilayah.GovernateID {
+			return nil, nil, errors.New("invalid advice: the wilayah is not in the governate")
+		}
+		return &wilayah.GovernateID, wilayahID, nil
+	}
+
+	if governateID != nil {
+		var governate domain.Governate
+		if err := tx.Select("id").First(&governate, "id = ?", *governateID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, nil, errors.New("invalid advice: governate not found")
+			}
+			return nil, nil, err
+		}
+	}
+	return governateID, nil, nil
+}
+
+// findAdvicePlaces loads the places advice links to, ignoring repeated IDs
+func findAdvicePlaces(tx *gorm.DB, placeIDs []uuid.UUID) ([]domain.Place, error) {
+	unique := make([]uuid.UUID, 0, len(placeIDs))
+	seen := make(map[uuid.UUID]bool, len(placeIDs))
+	for _, id := range placeIDs {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) == 0 {
+		return []domain.Place{}, nil
+	}
+	if len(unique) > maxAdvicePlaces {
+		return nil, fmt.Errorf("invalid advice: at most %d places can be linked", maxAdvicePlaces)
+	}
+
+	var places []domain.Place
+	if err := tx.Select("id").Where("id IN ?", unique).Find(&places).Error; err != nil {
+		return nil, err
+	}
+	if len(places) != len(unique) {
+		return nil, errors.New("invalid advice: place not found")
+	}
+	return places, nil
+}
+
+func nilIfZeroUUID(id uuid.UUID) *uuid.UUID {
+	if id == uuid.Nil {
+		return nil
+	}
+	return &id
+}
+
+func mapAdviceToResponse(advice domain.Advice) dto.AdviceResponse {
+	response := dto.AdviceResponse{
+		ID:          advice.ID,
+		TitleAr:     advice.TitleAr,
+		TitleEn:     advice.TitleEn,
+		ContentAr:   advice.ContentAr,
+		ContentEn:   advice.ContentEn,
+		AdviceType:  advice.AdviceType,
+		Places:      make([]dto.PlaceListResponse, 0, len(advice.Places)),
+		IsFeatured:  advice.IsFeatured,
+		ViewCount:   advice.ViewCount,
+		IsPublished: advice.IsPublished,
+		PublishedAt: advice.PublishedAt,
+		CreatedAt:   advice.CreatedAt,
+		UpdatedAt:   advice.UpdatedAt,
+		Creator: dto.UserInfo{
+			ID:        advice.Creator.ID,
+			Username:  advice.Creator.Username,
+			FirstName: advice.Creator.FirstName,
+			LastName:  advice.Creator.LastName,
+		},
+	}
+
+	if advice.Governate != nil {
+		response.Governate = &dto.SimpleGovernateResponse{
+			ID:     advice.Governate.ID,
+			NameAr: advice.Governate.NameAr,
+			NameEn: advice.Governate.NameEn,
+			Slug:   advice.Governate.Slug,
+		}
+	}
+	if advice.Wilayah != nil {
+		response.Wilayah = &dto.SimpleWilayahResponse{
+			ID:     advice.Wilayah.ID,
+			NameAr: advice.Wilayah.NameAr,
+			NameEn: advice.Wilayah.NameEn,
+			Slug:   advice.Wilayah.Slug,
+		}
+	}
+	for _, place := range advice.Places {
+		response.Places = append(response.Places, mapPlaceToListResponse(place))
+	}
+
+	return response
+}
@@ -6,6 +6,7 @@ import (
 	"almlah/internals/domain"
 	"almlah/internals/dto"
 	"errors"
+	"fmt"
 
 	"github.com/google/uuid"
 )
@@ -215,6 +216,9 @@ func UpdateCategory(id uuid.UUID, req dto.UpdateCategoryRequest) (*dto.CategoryR
 		return nil, errors.New("category not found")
 	}
 
+	// Search documents copy the names, the places are reindexed when they change
+	renamed := (req.NameAr != "" && req.NameAr != category.NameAr) || (req.NameEn != "" && req.NameEn != category.NameEn)
+
 	// Update fields if provided
 	if req.NameAr != "" {
 		category.NameAr = req.NameAr
@@ -252,6 +256,12 @@ func UpdateCategory(id uuid.UUID, req dto.UpdateCategoryRequest) (*dto.CategoryR
 		return nil, err
 	}
 
+	if renamed {
+		if _, err := RefreshCategorySearchDocuments(category.ID); err != nil {
+			fmt.Printf("⚠️ Failed to reindex places of category %s: %v\n", id, err)
+		}
+	}
+
 	// Load with relationships
 	config.DB.Preload("Parent").First(&category, category.ID)
 
@@ -0,0 +1,243 @@
+package services
+
+import (
+	"almlah/config"
+	"almlah/internals/domain"
+	"almlah/internals/dto"
+	"almlah/internals/utils"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const maxDishPlaces = 100
+
+type dishPlaceRow struct {
+	PlaceID     uuid.UUID
+	NoteAr      string
+	NoteEn      string
+	PriceMin    *float64
+	PriceMax    *float64
+	IsSignature bool
+	UpdatedAt   time.Time
+	DistanceKm  *float64
+}
+
+// GetDishPlaces returns the published places serving a dish. With coordinates they come closest
+// first, otherwise signature places first and then by rating.
+func GetDishPlaces(dishID uuid.UUID, lat, lng *float64) ([]dto.DishPlaceResponse, error) {
+	if err := ensureActiveDish(dishID); err != nil {
+		return nil, err
+	}
+
+	query := config.DB.Table("dish_places").
+		Joins("JOIN places ON places.id = dish_places.place_id AND places.deleted_at IS NULL").
+		Where("dish_places.dish_id = ? AND places.is_active = ?", dishID, true).
+		Scopes(publishedPlacesOnly).
+		Limit(maxDishPlaces)
+
+	columns := "dish_places.place_id, dish_places.note_ar, dish_places.note_en, dish_places.price_min, " +
+		"dish_places.price_max, dish_places.is_signature, dish_places.updated_at"
+	if lat != nil && lng != nil {
+		if !utils.ValidCoordinates(*lat, *lng) {
+			return nil, errors.New("invalid coordinates")
+		}
+		query = query.Select(columns+", "+haversineSQL+" AS distance_km", *lat, *lat, *lng).
+			Order("distance_km ASC")
+	} else {
+		query = query.Select(columns).
+			Order("dish_places.is_signature DESC, places.rating_average DESC, places.name_en ASC")
+	}
+
+	var rows []dishPlaceRow
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve dish places: %w", err)
+	}
+
+	if len(rows) == 0 {
+		return []dto.DishPlaceResponse{}, nil
+	}
+
+	placeIDs := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		placeIDs[i] = row.PlaceID
+	}
+
+	placesByID, err := loadListPlacesByIDs(placeIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dish places: %w", err)
+	}
+
+	// Keep the ordering from the first query
+	response := make([]dto.DishPlaceResponse, 0, len(rows))
+	for _, row := range rows {
+		place, ok := placesByID[row.PlaceID]
+		if !ok {
+			continue
+		}
+		item := mapPlaceToListResponse(place)
+		item.DistanceKm = row.DistanceKm
+		response = append(response, dto.DishPlaceResponse{
+			Place:       item,
+			NoteAr:      row.NoteAr,
+			NoteEn:      row.NoteEn,
+			PriceMin:    row.PriceMin,
+			PriceMax:    row.PriceMax,
+			IsSignature: row.IsSignature,
+			UpdatedAt:   row.UpdatedAt,
+		})
+	}
+
+	return response, nil
+}
+
+// GetPlaceDishes returns the active dishes served at a published place, signature dishes first
+func GetPlaceDishes(placeID uuid.UUID) ([]dto.PlaceDishResponse, error) {
+	var place domain.Place
+	err := config.DB.Select("id").
+		Where("id = ? AND is_active = ?", placeID, true).
+		Scopes(publishedPlacesOnly).
+		First(&place).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("place not found")
+		}
+		return nil, fmt.Errorf("database error: %v", err)
+	}
+
+	var links []domain.DishPlace
+	err = config.DB.
+		Joins("JOIN dishes ON dishes.id = dish_places.dish_id AND dishes.deleted_at IS NULL").
+		Where("dish_places.place_id = ? AND dishes.is_active = ?", placeID, true).
+		Preload("Dish.Governate").
+		Preload("Dish.Images", func(db *gorm.DB) *gorm.DB {
+			return db.Order("display_order ASC, created_at ASC")
+		}).
+		Preload("Dish.Tags", orderDishTags).
+		Order("dish_places.is_signature DESC, dishes.sort_order ASC, dishes.name_en ASC").
+		Find(&links).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve place dishes: %w", err)
+	}
+
+	response := make([]dto.PlaceDishResponse, len(links))
+	for i, link := range links {
+		response[i] = dto.PlaceDishResponse{
+			Dish:        *ConvertDishToResponse(link.Dish),
+			NoteAr:      link.NoteAr,
+			NoteEn:      link.NoteEn,
+			PriceMin:    link.PriceMin,
+			PriceMax:    link.PriceMax,
+			IsSignature: link.IsSignature,
+		}
+	}
+
+	return response, nil
+}
+
+// SaveDishPlace creates the link between a dish and a place, or replaces its details
+func SaveDishPlace(dishID, placeID uuid.UUID, req dto.SaveDishPlaceRequest, userID uuid.UUID) (*domain.DishPlace, error) {
+	if req.PriceMin != nil && req.PriceMax != nil && *req.PriceMax < *req.PriceMin {
+		return nil, errors.New("invalid price range: price_max is below price_min")
+	}
+
+	var count int64
+	if err := config.DB.Model(&domain.Dish{}).Where("id = ?", dishID).Count(&count).Error; err != nil {
+		return nil, fmt.Errorf("database error: %v", err)
+	}
+	if count == 0 {
+		return nil, errors.New("dish not found")
+	}
+	if err := config.DB.Model(&domain.Place{}).Where("id = ?", placeID).Count(&count).Error; err != nil {
+		return nil, fmt.Errorf("database error: %v", err)
+	}
+	if count == 0 {
+		return nil, errors.New("place not found")
+	}
+
+	link := domain.DishPlace{
+		DishID:      dishID,
+		PlaceID:     placeID,
+		NoteAr:      req.NoteAr,
+		NoteEn:      req.NoteEn,
+		PriceMin:    req.PriceMin,
+		PriceMax:    req.PriceMax,
+		IsSignature: req.IsSignature,
+		CreatedBy:   &userID,
+	}
+
+	err := config.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "dish_id"}, {Name: "place_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"note_ar", "note_en", "price_min", "price_max", "is_signature", "updated_at"}),
+	}).Create(&link).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to save dish place: %w", err)
+	}
+
+	if err := config.DB.Where("dish_id = ? AND place_id = ?", dishID, placeID).First(&link).Error; err != nil {
+		return nil, fmt.Errorf("failed to load dish place: %w", err)
+	}
+
+	return &link, nil
+}
+
+// DeleteDishPlace removes the link between a dish and a place
+func DeleteDishPlace(dishID, placeID uuid.UUID) error {
+	result := config.DB.Where("dish_id = ? AND place_id = ?", dishID, placeID).Delete(&domain.DishPlace{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete dish place: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("dish place not found")
+	}
+	return nil
+}
+
+// GetDishByIDLocalized retrieves a dish in one language, with the published places serving it
+func GetDishByIDLocalized(dishID string, lang string) (*dto.DishResponseLocalized, error) {
+	id, err := uuid.Parse(dishID)
+	if err != nil {
+		return nil, errors.New("invalid dish ID format")
+	}
+
+	var dish domain.Dish
+	err = config.DB.
+		Preload("Governate").
+		Preload("Images", func(db *gorm.DB) *gorm.DB {
+			return db.Order("display_order ASC, created_at ASC")
+		}).
+		Preload("Tags", orderDishTags).
+		Preload("ServedAt", func(db *gorm.DB) *gorm.DB {
+			return db.Joins("JOIN places ON places.id = dish_places.place_id AND places.deleted_at IS NULL").
+				Where("places.is_active = ?", true).
+				Scopes(publishedPlacesOnly).
+				Order("dish_places.is_signature DESC, places.rating_average DESC").
+				Limit(maxDishPlaces)
+		}).
+		Preload("ServedAt.Place").
+		Where("id = ? AND deleted_at IS NULL", id).
+		First(&dish).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("dish not found")
+		}
+		return nil, fmt.Errorf("database error: %v", err)
+	}
+
+	return ConvertDishToLocalizedResponse(dish, lang), nil
+}
+
+func ensureActiveDish(dishID uuid.UUID) error {
+	var count int64
+	if err := config.DB.Model(&domain.Dish{}).Where("id = ? AND is_active = ?", dishID, true).Count(&count).Error; err != nil {
+		return fmt.Errorf("database error: %v", err)
+	}
+	if count == 0 {
+		return errors.New("dish not found")
+	}
+	return nil
+}
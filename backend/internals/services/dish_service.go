@@ -99,6 +99,19 @@ func CreateDish(req dto.CreateDishRequest, userID uuid.UUID) (*dto.DishResponse,
 		}
 	}
 
+	// Assign dietary tags, allergens and ingredients
+	if len(req.TagIDs) > 0 {
+		tags, err := findDishTags(tx, req.TagIDs)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		if err := tx.Model(&dish).Association("Tags").Replace(tags); err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to assign dish tags: %v", err)
+		}
+	}
+
 	// Commit transaction
 	if err := tx.Commit().Error; err != nil {
 		return nil, fmt.Errorf("failed to commit transaction: %v", err)
@@ -121,6 +134,7 @@ func GetDishByID(dishID string) (*dto.DishResponse, error) {
 		Preload("Images", func(db *gorm.DB) *gorm.DB {
 			return db.Order("display_order ASC, created_at ASC")
 		}).
+		Preload("Tags", orderDishTags).
 		Where("id = ? AND deleted_at IS NULL", id).
 		First(&dish).Error
 
@@ -164,6 +178,7 @@ func GetDishes(filters dto.DishFilters) (*dto.DishListResponse, error) {
 		query = query.Where("LOWER(name_ar) LIKE ? OR LOWER(name_en) LIKE ? OR LOWER(description_ar) LIKE ? OR LOWER(description_en) LIKE ?", 
 			searchTerm, searchTerm, searchTerm, searchTerm)
 	}
+	query = applyDishTagFilters(query, filters.IncludeTags, filters.ExcludeTags)
 
 	// Count total
 	if err := query.Count(&total).Error; err != nil {
@@ -179,6 +194,7 @@ func GetDishes(filters dto.DishFilters) (*dto.DishListResponse, error) {
 		Preload("Images", func(db *gorm.DB) *gorm.DB {
 			return db.Order("display_order ASC, created_at ASC")
 		}).
+		Preload("Tags", orderDishTags).
 		Order(orderClause).
 		Offset(offset).
 		Limit(filters.PageSize).
@@ -271,8 +287,24 @@ func UpdateDish(dishID string, req dto.UpdateDishRequest, userID uuid.UUID) (*dt
 	}
 
 	// Save changes
-	if err := config.DB.Save(&dish).Error; err != nil {
-		return nil, fmt.Errorf("failed to update dish: %v", err)
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&dish).Error; err != nil {
+			return fmt.Errorf("failed to update dish: %v", err)
+		}
+		if req.TagIDs == nil {
+			return nil
+		}
+		tags, err := findDishTags(tx, *req.TagIDs)
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&dish).Association("Tags").Replace(tags); err != nil {
+			return fmt.Errorf("failed to update dish tags: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return GetDishByID(dishID)
@@ -463,6 +495,12 @@ func ConvertDishToResponse(dish domain.Dish) *dto.DishResponse {
 		response.Images[i] = *ConvertDishImageToResponse(img)
 	}
 
+	// Add tags
+	response.Tags = make([]dto.DishTagResponse, len(dish.Tags))
+	for i, tag := range dish.Tags {
+		response.Tags[i] = mapDishTagToResponse(tag)
+	}
+
 	return response
 }
 
@@ -527,5 +565,36 @@ func ConvertDishToLocalizedResponse(dish domain.Dish, lang string) *dto.DishResp
 		}
 	}
 
+	// Add tags
+	response.Tags = make([]dto.DishTagLocalized, len(dish.Tags))
+	for i, tag := range dish.Tags {
+		response.Tags[i] = dto.DishTagLocalized{
+			ID:   tag.ID,
+			Type: tag.Type,
+			Slug: tag.Slug,
+			Name: tag.GetName(lang),
+			Icon: tag.Icon,
+		}
+	}
+
+	// Add places serving the dish, when loaded
+	response.WhereToEat = make([]dto.DishPlaceLocalized, 0, len(dish.ServedAt))
+	for _, link := range dish.ServedAt {
+		if link.Place.ID == uuid.Nil {
+			continue
+		}
+		response.WhereToEat = append(response.WhereToEat, dto.DishPlaceLocalized{
+			PlaceID:     link.PlaceID,
+			Name:        link.Place.GetName(lang),
+			Slug:        link.Place.GetSlug(lang),
+			Note:        link.GetNote(lang),
+			PriceMin:    link.PriceMin,
+			PriceMax:    link.PriceMax,
+			IsSignature: link.IsSignature,
+			Latitude:    link.Place.Latitude,
+			Longitude:   link.Place.Longitude,
+		})
+	}
+
 	return response
 }
\ No newline at end of file
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"almlah/config"
+	"almlah/internals/domain"
+	"almlah/internals/dto"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// orderDishTags sorts dish tags for display: dietary tags, then allergens, then ingredients
+func orderDishTags(db *gorm.DB) *gorm.DB {
+	return db.Order(fmt.Sprintf("CASE dish_tags.type WHEN '%s' THEN 0 WHEN '%s' THEN 1 ELSE 2 END, dish_tags.sort_order ASC, dish_tags.name_en ASC",
+		domain.DishTagTypeDietary, domain.DishTagTypeAllergen))
+}
+
+// GetDishTags returns the tag vocabulary, optionally of one type
+func GetDishTags(tagType string) ([]dto.DishTagResponse, error) {
+	query := config.DB.Model(&domain.DishTag{}).Scopes(orderDishTags)
+	if tagType != "" {
+		query = query.Where("type = ?", tagType)
+	}
+
+	var tags []domain.DishTag
+	if err := query.Find(&tags).Error; err != nil {
+		return nil, fmt.Errorf("failed to retrieve dish tags: %v", err)
+	}
+
+	response := make([]dto.DishTagResponse, len(tags))
+	for i, tag := range tags {
+		response[i] = mapDishTagToResponse(tag)
+	}
+	return response, nil
+}
+
+// CreateDishTag adds a dietary tag, allergen or ingredient to the vocabulary
+func CreateDishTag(req dto.CreateDishTagRequest) (*dto.DishTagResponse, error) {
+	slug := strings.ToLower(strings.TrimSpace(req.Slug))
+	if err := ensureDishTagSlugFree(slug, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	tag := domain.DishTag{
+		Type:      req.Type,
+		Slug:      slug,
+		NameAr:    req.NameAr,
+		NameEn:    req.NameEn,
+		Icon:      req.Icon,
+		SortOrder: req.SortOrder,
+	}
+	if err := config.DB.Create(&tag).Error; err != nil {
+		return nil, fmt.Errorf("failed to create dish tag: %v", err)
+	}
+
+	response := mapDishTagToResponse(tag)
+	return &response, nil
+}
+
+// UpdateDishTag changes the given fields of a tag
+func UpdateDishTag(id uuid.UUID, req dto.UpdateDishTagRequest) (*dto.DishTagResponse, error) {
+	var tag domain.DishTag
+	if err := config.DB.First(&tag, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("dish tag not found")
+		}
+		return nil, fmt.Errorf("database error: %v", err)
+	}
+
+	if req.Slug != nil {
+		slug := strings.ToLower(strings.TrimSpace(*req.Slug))
+		if err := ensureDishTagSlugFree(slug, id); err != nil {
+			return nil, err
+		}
+		tag.Slug = slug
+	}
+	if req.Type != nil {
+		tag.Type = *req.Type
+	}
+	if req.NameAr != nil && *req.NameAr != "" {
+		tag.NameAr = *req.NameAr
+	}
+	if req.NameEn != nil && *req.NameEn != "" {
+		tag.NameEn = *req.NameEn
+	}
+	if req.Icon != nil {
+		tag.Icon = *req.Icon
+	}
+	if req.SortOrder != nil {
+		tag.SortOrder = *req.SortOrder
+	}
+
+	if err := config.DB.Save(&tag).Error; err != nil {
+		return nil, fmt.Errorf("failed to update dish tag: %v", err)
+	}
+
+	response := mapDishTagToResponse(tag)
+	return &response, nil
+}
+
+// DeleteDishTag removes a tag from the vocabulary and from every dish
+func DeleteDishTag(id uuid.UUID) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM dish_tag_links WHERE dish_tag_id = ?", id).Error; err != nil {
+			return fmt.Errorf("failed to unlink dish tag: %v", err)
+		}
+		result := tx.Delete(&domain.DishTag{}, "id = ?", id)
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete dish tag: %v", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("dish tag not found")
+		}
+		return nil
+	})
+}
+
+// findDishTags loads the tags with the given IDs, failing if any of them doesn't exist
+func findDishTags(tx *gorm.DB, tagIDs []uuid.UUID) ([]domain.DishTag, error) {
+	if len(tagIDs) == 0 {
+		return []domain.DishTag{}, nil
+	}
+
+	var tags []domain.DishTag
+	if err := tx.Where("id IN ?", tagIDs).Find(&tags).Error; err != nil {
+		return nil, fmt.Errorf("database error: %v", err)
+	}
+
+	found := make(map[uuid.UUID]bool, len(tags))
+	for _, tag := range tags {
+		found[tag.ID] = true
+	}
+	for _, id := range tagIDs {
+		if !found[id] {
+			return nil, fmt.Errorf("invalid dish tag ID: %s", id)
+		}
+	}
+	return tags, nil
+}
+
+// applyDishTagFilters keeps dishes having every included tag and none of the excluded ones.
+// A dish without an allergen tag has no known allergen, it isn't guaranteed to be free of it.
+func applyDishTagFilters(query *gorm.DB, include, exclude []string) *gorm.DB {
+	if include = normalizeTagSlugs(include); len(include) > 0 {
+		query = query.Where(`(SELECT COUNT(DISTINCT dish_tags.slug) FROM dish_tag_links
+			JOIN dish_tags ON dish_tags.id = dish_tag_links.dish_tag_id
+			WHERE dish_tag_links.dish_id = dishes.id AND dish_tags.slug IN ?) = ?`, include, len(include))
+	}
+	if exclude = normalizeTagSlugs(exclude); len(exclude) > 0 {
+		query = query.Where(`NOT EXISTS (SELECT 1 FROM dish_tag_links
+			JOIN dish_tags ON dish_tags.id = dish_tag_links.dish_tag_id
+			WHERE dish_tag_links.dish_id = dishes.id AND dish_tags.slug IN ?)`, exclude)
+	}
+	return query
+}
+
+func normalizeTagSlugs(slugs []string) []string {
+	seen := make(map[string]bool, len(slugs))
+	normalized := make([]string, 0, len(slugs))
+	for _, slug := range slugs {
+		slug = strings.ToLower(strings.TrimSpace(slug))
+		if slug == "" || seen[slug] {
+			continue
+		}
+		seen[slug] = true
+		normalized = append(normalized, slug)
+	}
+	return normalized
+}
+
+func ensureDishTagSlugFree(slug string, exceptID uuid.UUID) error {
+	var count int64
+	if err := config.DB.Model(&domain.DishTag{}).Where("slug = ? AND id != ?", slug, exceptID).Count(&count).Error; err != nil {
+		return fmt.Errorf("database error: %v", err)
+	}
+	if count > 0 {
+		return errors.New("dish tag with this slug already exists")
+	}
+	return nil
+}
+
+func mapDishTagToResponse(tag domain.DishTag) dto.DishTagResponse {
+	return dto.DishTagResponse{
+		ID:        tag.ID,
+		Type:      tag.Type,
+		Slug:      tag.Slug,
+		NameAr:    tag.NameAr,
+		NameEn:    tag.NameEn,
+		Icon:      tag.Icon,
+		SortOrder: tag.SortOrder,
+	}
+}
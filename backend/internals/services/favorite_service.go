@@ -0,0 +1,464 @@
+// services/favorite_service.go
+package services
+
+import (
+	"almlah/config"
+	"almlah/internals/domain"
+	"almlah/internals/dto"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// maxFavoriteStatusPlaces is how many places can be checked in one favorite status request
+const maxFavoriteStatusPlaces = 100
+
+// AddFavorite favorites a published place. Favoriting it again is a no-op.
+func AddFavorite(userID uuid.UUID, placeID uuid.UUID) (*dto.FavoriteResponse, error) {
+	var favorite domain.UserFavorite
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		favorite, err = ensureFavorite(tx, userID, placeID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return getFavoriteResponse(favorite.ID)
+}
+
+// RemoveFavorite unfavorites a place, which also takes it out of the user's collections
+func RemoveFavorite(userID uuid.UUID, placeID uuid.UUID) error {
+	result := config.DB.Where("user_id = ? AND place_id = ?", userID, placeID).Delete(&domain.UserFavorite{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to remove favorite: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("favorite not found")
+	}
+	return nil
+}
+
+// GetUserFavorites lists the published places a user favorited, most recent first. With a
+// collection ID only the places in that collection are listed.
+func GetUserFavorites(userID uuid.UUID, collectionID *uuid.UUID, page, limit int) (*dto.PaginationResponse, error) {
+	if collectionID != nil {
+		if _, err := findFavoriteCollection(config.DB, userID, *collectionID); err != nil {
+			return nil, err
+		}
+	}
+
+	favorites := func() *gorm.DB {
+		query := config.DB.Model(&domain.UserFavorite{}).
+			Joins("JOIN places ON places.id = user_favorites.place_id AND places.deleted_at IS NULL").
+			Scopes(publishedPlacesOnly).
+			Where("user_favorites.user_id = ?", userID)
+		if collectionID != nil {
+			query = query.
+				Joins("JOIN favorite_collection_places ON favorite_collection_places.favorite_id = user_favorites.id").
+				Where("favorite_collection_places.collection_id = ?", *collectionID)
+		}
+		return query
+	}
+
+	var total int64
+	if err := favorites().Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count favorites: %w", err)
+	}
+
+	var rows []domain.UserFavorite
+	err := favorites().
+		Preload("Place.Categories").
+		Preload("Place.Images").
+		Preload("Place.Governate").
+		Preload("Place.Wilayah").
+		Order("user_favorites.added_at DESC, user_favorites.id").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load favorites: %w", err)
+	}
+
+	response, err := mapFavoritesToResponse(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.PaginationResponse{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+		Data:       response,
+	}, nil
+}
+
+// GetFavoriteStatus reports for each of the given places whether the user favorited it
+func GetFavoriteStatus(userID uuid.UUID, placeIDs []uuid.UUID) (*dto.FavoriteStatusResponse, error) {
+	if len(placeIDs) > maxFavoriteStatusPlaces {
+		return nil, fmt.Errorf("invalid request: at most %d places can be checked at once", maxFavoriteStatusPlaces)
+	}
+
+	favorited, err := favoritedPlaceIDs(userID, placeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	status := make(map[uuid.UUID]bool, len(placeIDs))
+	for _, placeID := range placeIDs {
+		status[placeID] = favorited[placeID]
+	}
+	return &dto.FavoriteStatusResponse{Favorites: status}, nil
+}
+
+// MarkFavoritePlaces returns a copy of places with IsFavorited set for the user, looked up
+// in a single query. The given slice is left untouched since it may still be cached.
+func MarkFavoritePlaces(places []dto.PlaceListResponse, userID uuid.UUID) ([]dto.PlaceListResponse, error) {
+	if len(places) == 0 {
+		return places, nil
+	}
+
+	placeIDs := make([]uuid.UUID, len(places))
+	for i, place := range places {
+		placeIDs[i] = place.ID
+	}
+
+	favorited, err := favoritedPlaceIDs(userID, placeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	marked := make([]dto.PlaceListResponse, len(places))
+	for i, place := range places {
+		isFavorited := favorited[place.ID]
+		place.IsFavorited = &isFavorited
+		marked[i] = place
+	}
+	return marked, nil
+}
+
+// Favorite collections
+
+// CreateFavoriteCollection creates a named collection. Names are unique per user.
+func CreateFavoriteCollection(userID uuid.UUID, req dto.CreateFavoriteCollectionRequest) (*dto.FavoriteCollectionResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.New("invalid collection: name is required")
+	}
+	if err := checkFavoriteCollectionName(userID, name, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	collection := domain.FavoriteCollection{
+		UserID:      userID,
+		Name:        name,
+		Description: strings.TrimSpace(req.Description),
+	}
+	if err := config.DB.Omit(clause.Associations).Create(&collection).Error; err != nil {
+		return nil, fmt.Errorf("failed to create collection: %w", err)
+	}
+
+	response := mapFavoriteCollectionToResponse(collection, 0)
+	return &response, nil
+}
+
+// GetFavoriteCollections lists a user's collections with the number of places in each
+func GetFavoriteCollections(userID uuid.UUID) ([]dto.FavoriteCollectionResponse, error) {
+	var collections []domain.FavoriteCollection
+	if err := config.DB.Where("user_id = ?", userID).Order("name ASC").Find(&collections).Error; err != nil {
+		return nil, fmt.Errorf("failed to load collections: %w", err)
+	}
+
+	counts, err := favoriteCollectionPlaceCounts(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.FavoriteCollectionResponse, 0, len(collections))
+	for _, collection := range collections {
+		response = append(response, mapFavoriteCollectionToResponse(collection, counts[collection.ID]))
+	}
+	return response, nil
+}
+
+// UpdateFavoriteCollection renames a collection or changes its description
+func UpdateFavoriteCollection(userID uuid.UUID, collectionID uuid.UUID, req dto.UpdateFavoriteCollectionRequest) (*dto.FavoriteCollectionResponse, error) {
+	collection, err := findFavoriteCollection(config.DB, userID, collectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := map[string]interface{}{}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, errors.New("invalid collection: name is required")
+		}
+		if err := checkFavoriteCollectionName(userID, name, collectionID); err != nil {
+			return nil, err
+		}
+		updates["name"] = name
+	}
+	if req.Description != nil {
+		updates["description"] = strings.TrimSpace(*req.Description)
+	}
+
+	if len(updates) > 0 {
+		if err := config.DB.Model(&collection).Updates(updates).Error; err != nil {
+			return nil, fmt.Errorf("failed to update collection: %w", err)
+		}
+		if collection, err = findFavoriteCollection(config.DB, userID, collectionID); err != nil {
+			return nil, err
+		}
+	}
+
+	counts, err := favoriteCollectionPlaceCounts(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := mapFavoriteCollectionToResponse(collection, counts[collectionID])
+	return &response, nil
+}
+
+// DeleteFavoriteCollection removes a collection. The places in it stay favorited.
+func DeleteFavoriteCollection(userID uuid.UUID, collectionID uuid.UUID) error {
+	result := config.DB.Where("id = ? AND user_id = ?", collectionID, userID).Delete(&domain.FavoriteCollection{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete collection: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("collection not found")
+	}
+	return nil
+}
+
+// AddPlaceToCollection puts a place in one of the user's collections, favoriting it first
+// if needed. Adding it again is a no-op.
+func AddPlaceToCollection(userID uuid.UUID, collectionID uuid.UUID, placeID uuid.UUID) (*dto.FavoriteResponse, error) {
+	var favorite domain.UserFavorite
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		if _, err := findFavoriteCollection(tx, userID, collectionID); err != nil {
+			return err
+		}
+
+		var err error
+		favorite, err = ensureFavorite(tx, userID, placeID)
+		if err != nil {
+			return err
+		}
+
+		item := domain.FavoriteCollectionPlace{CollectionID: collectionID, FavoriteID: favorite.ID}
+		return tx.Omit(clause.Associations).
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&item).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return getFavoriteResponse(favorite.ID)
+}
+
+// RemovePlaceFromCollection takes a place out of a collection. It stays favorited.
+func RemovePlaceFromCollection(userID uuid.UUID, collectionID uuid.UUID, placeID uuid.UUID) error {
+	if _, err := findFavoriteCollection(config.DB, userID, collectionID); err != nil {
+		return err
+	}
+
+	result := config.DB.
+		Where("collection_id = ? AND favorite_id IN (?)", collectionID,
+			config.DB.Model(&domain.UserFavorite{}).Select("id").Where("user_id = ? AND place_id = ?", userID, placeID)).
+		Delete(&domain.FavoriteCollectionPlace{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to remove place from collection: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("place not found in collection")
+	}
+	return nil
+}
+
+// ensureFavorite returns the user's favorite for a published place, creating it if needed
+func ensureFavorite(tx *gorm.DB, userID uuid.UUID, placeID uuid.UUID) (domain.UserFavorite, error) {
+	var favorite domain.UserFavorite
+
+	var place domain.Place
+	if err := tx.Select("id").Scopes(publishedPlacesOnly).First(&place, "id = ?", placeID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return favorite, errors.New("place not found")
+		}
+		return favorite, err
+	}
+
+	favorite = domain.UserFavorite{UserID: userID, PlaceID: placeID}
+	err := tx.Omit(clause.Associations).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "place_id"}},
+			DoNothing: true,
+		}).
+		Create(&favorite).Error
+	if err != nil {
+		return favorite, fmt.Errorf("failed to add favorite: %w", err)
+	}
+
+	// On conflict the generated ID wasn't stored, read back the existing row
+	if err := tx.Where("user_id = ? AND place_id = ?", userID, placeID).First(&favorite).Error; err != nil {
+		return favorite, err
+	}
+	return favorite, nil
+}
+
+func getFavoriteResponse(favoriteID uuid.UUID) (*dto.FavoriteResponse, error) {
+	var favorite domain.UserFavorite
+	err := config.DB.
+		Preload("Place.Categories").
+		Preload("Place.Images").
+		Preload("Place.Governate").
+		Preload("Place.Wilayah").
+		First(&favorite, "id = ?", favoriteID).Error
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := mapFavoritesToResponse([]domain.UserFavorite{favorite})
+	if err != nil {
+		return nil, err
+	}
+	return &response[0], nil
+}
+
+// favoritedPlaceIDs returns which of the given places the user favorited
+func favoritedPlaceIDs(userID uuid.UUID, placeIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	favorited := make(map[uuid.UUID]bool)
+	if len(placeIDs) == 0 {
+		return favorited, nil
+	}
+
+	var ids []uuid.UUID
+	err := config.DB.Model(&domain.UserFavorite{}).
+		Where("user_id = ? AND place_id IN ?", userID, placeIDs).
+		Pluck("place_id", &ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load favorites: %w", err)
+	}
+
+	for _, id := range ids {
+		favorited[id] = true
+	}
+	return favorited, nil
+}
+
+func findFavoriteCollection(db *gorm.DB, userID uuid.UUID, collectionID uuid.UUID) (domain.FavoriteCollection, error) {
+	var collection domain.FavoriteCollection
+	if err := db.Where("id = ? AND user_id = ?", collectionID, userID).First(&collection).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return collection, errors.New("collection not found")
+		}
+		return collection, err
+	}
+	return collection, nil
+}
+
+// checkFavoriteCollectionName rejects a name the user already gave another collection,
+// ignoring case
+func checkFavoriteCollectionName(userID uuid.UUID, name string, excludeID uuid.UUID) error {
+	var count int64
+	err := config.DB.Model(&domain.FavoriteCollection{}).
+		Where("user_id = ? AND LOWER(name) = LOWER(?) AND id <> ?", userID, name, excludeID).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("you have already created a collection named %q", name)
+	}
+	return nil
+}
+
+// favoriteCollectionPlaceCounts counts the published places in each of a user's collections
+func favoriteCollectionPlaceCounts(userID uuid.UUID) (map[uuid.UUID]int, error) {
+	var rows []struct {
+		CollectionID uuid.UUID
+		Count        int
+	}
+	err := config.DB.Model(&domain.FavoriteCollectionPlace{}).
+		Select("favorite_collection_places.collection_id, COUNT(*) AS count").
+		Joins("JOIN favorite_collections ON favorite_collections.id = favorite_collection_places.collection_id").
+		Joins("JOIN user_favorites ON user_favorites.id = favorite_collection_places.favorite_id").
+		Joins("JOIN places ON places.id = user_favorites.place_id AND places.deleted_at IS NULL").
+		Scopes(publishedPlacesOnly).
+		Where("favorite_collections.user_id = ?", userID).
+		Group("favorite_collection_places.collection_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count collection places: %w", err)
+	}
+
+	counts := make(map[uuid.UUID]int, len(rows))
+	for _, row := range rows {
+		counts[row.CollectionID] = row.Count
+	}
+	return counts, nil
+}
+
+// mapFavoritesToResponse maps favorites with their place preloaded, loading the collections
+// they belong to in one query
+func mapFavoritesToResponse(favorites []domain.UserFavorite) ([]dto.FavoriteResponse, error) {
+	response := make([]dto.FavoriteResponse, 0, len(favorites))
+	if len(favorites) == 0 {
+		return response, nil
+	}
+
+	favoriteIDs := make([]uuid.UUID, len(favorites))
+	for i, favorite := range favorites {
+		favoriteIDs[i] = favorite.ID
+	}
+
+	var items []domain.FavoriteCollectionPlace
+	if err := config.DB.Where("favorite_id IN ?", favoriteIDs).Order("added_at ASC").Find(&items).Error; err != nil {
+		return nil, fmt.Errorf("failed to load collections: %w", err)
+	}
+	collections := make(map[uuid.UUID][]uuid.UUID)
+	for _, item := range items {
+		collections[item.FavoriteID] = append(collections[item.FavoriteID], item.CollectionID)
+	}
+
+	for _, favorite := range favorites {
+		place := mapPlaceToListResponse(favorite.Place)
+		isFavorited := true
+		place.IsFavorited = &isFavorited
+
+		collectionIDs := collections[favorite.ID]
+		if collectionIDs == nil {
+			collectionIDs = []uuid.UUID{}
+		}
+
+		response = append(response, dto.FavoriteResponse{
+			ID:            favorite.ID,
+			UserID:        favorite.UserID,
+			PlaceID:       favorite.PlaceID,
+			AddedAt:       favorite.AddedAt,
+			Place:         place,
+			CollectionIDs: collectionIDs,
+		})
+	}
+	return response, nil
+}
+
+func mapFavoriteCollectionToResponse(collection domain.FavoriteCollection, placeCount int) dto.FavoriteCollectionResponse {
+	return dto.FavoriteCollectionResponse{
+		ID:          collection.ID,
+		Name:        collection.Name,
+		Description: collection.Description,
+		PlaceCount:  placeCount,
+		CreatedAt:   collection.CreatedAt,
+		UpdatedAt:   collection.UpdatedAt,
+	}
+}
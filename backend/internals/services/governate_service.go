@@ -7,6 +7,7 @@ import (
 	"almlah/internals/domain"
 	"almlah/internals/dto"
 	"errors"
+	"fmt"
 
 	"github.com/google/uuid"
 )
@@ -102,6 +103,9 @@ func UpdateGovernate(id uuid.UUID, req dto.UpdateGovernateRequest, userID uuid.U
 		return nil, errors.New("governate not found")
 	}
 
+	// Search documents copy the names, the places are reindexed when they change
+	renamed := (req.NameAr != "" && req.NameAr != governate.NameAr) || (req.NameEn != "" && req.NameEn != governate.NameEn)
+
 	// Update fields if provided
 	if req.NameAr != "" {
 		governate.NameAr = req.NameAr
@@ -148,6 +152,12 @@ func UpdateGovernate(id uuid.UUID, req dto.UpdateGovernateRequest, userID uuid.U
 		return nil, err
 	}
 
+	if renamed {
+		if _, err := RefreshGovernateSearchDocuments(governate.ID); err != nil {
+			fmt.Printf("⚠️ Failed to reindex places of governate %s: %v\n", id, err)
+		}
+	}
+
 	return GetGovernateByID(governate.ID)
 }
 
@@ -216,6 +226,6 @@ func getWilayahCountForGovernate(governateID uuid.UUID) int {
 
 func getPlaceCountForGovernate(governateID uuid.UUID) int {
 	var count int64
-	config.DB.Model(&domain.Place{}).Where("governate_id = ?", governateID).Count(&count)
+	config.DB.Model(&domain.Place{}).Where("governate_id = ?", governateID).Scopes(publishedPlacesOnly).Count(&count)
 	return int(count)
 }
\ No newline at end of file
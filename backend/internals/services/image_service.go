@@ -879,6 +879,18 @@ func DeletePlaceWithSupabaseCleanup(placeID uuid.UUID, userID uuid.UUID) error {
 		return fmt.Errorf("failed to delete place categories: %v", err)
 	}
 
+	// Unlink the place from travel advice
+	if err := tx.Exec("DELETE FROM advice_places WHERE place_id = ?", placeID).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to unlink place from advice: %v", err)
+	}
+
+	// Unlink the dishes the place serves
+	if err := tx.Exec("DELETE FROM dish_places WHERE place_id = ?", placeID).Error; err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to unlink place from dishes: %v", err)
+	}
+
 	// Delete the place itself (hard delete)
 	if err := tx.Unscoped().Delete(&domain.Place{}, "id = ?", placeID).Error; err != nil {
 		tx.Rollback()
@@ -926,13 +938,6 @@ func DeletePlaceContentSectionWithSupabaseCleanup(sectionID uuid.UUID, userID uu
 		return fmt.Errorf("insufficient permissions to delete this content section")
 	}
 
-	tx := config.DB.Begin()
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-		}
-	}()
-
 	var supabaseURLsToDelete []string
 	for _, img := range section.Images {
 		if img.ImageURL != "" && supabaseService.IsSupabaseURL(img.ImageURL) {
@@ -942,18 +947,19 @@ func DeletePlaceContentSectionWithSupabaseCleanup(sectionID uuid.UUID, userID uu
 
 	fmt.Printf("🗂️ Found %d Supabase URLs to delete for content section\n", len(supabaseURLsToDelete))
 
-	if err := tx.Unscoped().Where("section_id = ?", sectionID).Delete(&domain.PlaceContentSectionImage{}).Error; err != nil {
-		tx.Rollback()
-		return fmt.Errorf("failed to delete section images: %v", err)
-	}
-
-	if err := tx.Unscoped().Delete(&domain.PlaceContentSection{}, "id = ?", sectionID).Error; err != nil {
-		tx.Rollback()
-		return fmt.Errorf("failed to delete content section: %v", err)
-	}
+	summary := "Removed content section: " + section.TitleEn
+	err = recordPlaceEdit(section.PlaceID, userID, domain.RevisionChangeContentSection, summary, func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("section_id = ?", sectionID).Delete(&domain.PlaceContentSectionImage{}).Error; err != nil {
+			return fmt.Errorf("failed to delete section images: %v", err)
+		}
 
-	if err := tx.Commit().Error; err != nil {
-		return fmt.Errorf("failed to commit transaction: %v", err)
+		if err := tx.Unscoped().Delete(&domain.PlaceContentSection{}, "id = ?", sectionID).Error; err != nil {
+			return fmt.Errorf("failed to delete content section: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	fmt.Printf("✅ Successfully deleted content section %s from database\n", sectionID)
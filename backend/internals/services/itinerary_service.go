@@ -0,0 +1,916 @@
+// services/itinerary_service.go
+package services
+
+import (
+	"almlah/config"
+	"almlah/internals/domain"
+	"almlah/internals/dto"
+	"almlah/internals/utils"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Limits on the size of an itinerary
+const (
+	maxItineraryDays        = 30
+	maxItineraryStopsPerDay = 30
+)
+
+// Travel estimates are computed offline: the straight line between two places is
+// stretched by a road factor and driven at an average speed
+const (
+	defaultItineraryRoadFactor      = 1.3
+	defaultItineraryAverageSpeedKmh = 80.0
+)
+
+// itineraryRoadFactor is how much longer the road is than the straight line, from
+// ITINERARY_ROAD_FACTOR
+func itineraryRoadFactor() float64 {
+	factor, err := strconv.ParseFloat(getEnvWithDefault("ITINERARY_ROAD_FACTOR", ""), 64)
+	if err != nil || factor < 1 {
+		return defaultItineraryRoadFactor
+	}
+	return factor
+}
+
+// itineraryAverageSpeed is the average driving speed in km/h, from ITINERARY_AVERAGE_SPEED_KMH
+func itineraryAverageSpeed() float64 {
+	speed, err := strconv.ParseFloat(getEnvWithDefault("ITINERARY_AVERAGE_SPEED_KMH", ""), 64)
+	if err != nil || speed <= 0 {
+		return defaultItineraryAverageSpeedKmh
+	}
+	return speed
+}
+
+// Itinerary CRUD Operations
+
+// CreateItinerary starts a trip plan with the requested number of empty days
+func CreateItinerary(userID uuid.UUID, req dto.CreateItineraryRequest) (*dto.ItineraryResponse, error) {
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		return nil, errors.New("invalid itinerary: title is required")
+	}
+	startDate, err := parseItineraryDate(req.StartDate)
+	if err != nil {
+		return nil, err
+	}
+	if req.Days > maxItineraryDays {
+		return nil, fmt.Errorf("invalid itinerary: a trip can have at most %d days", maxItineraryDays)
+	}
+
+	itinerary := domain.Itinerary{
+		UserID:      userID,
+		Title:       title,
+		Description: strings.TrimSpace(req.Description),
+		StartDate:   startDate,
+	}
+	for day := 1; day <= req.Days; day++ {
+		itinerary.Days = append(itinerary.Days, domain.ItineraryDay{DayNumber: day})
+	}
+
+	if err := config.DB.Omit("User").Create(&itinerary).Error; err != nil {
+		return nil, fmt.Errorf("failed to create itinerary: %w", err)
+	}
+
+	return GetItinerary(itinerary.ID, userID)
+}
+
+// GetUserItineraries lists a user's itineraries, most recently changed first
+func GetUserItineraries(userID uuid.UUID, page, limit int) (*dto.PaginationResponse, error) {
+	var total int64
+	if err := config.DB.Model(&domain.Itinerary{}).Where("user_id = ?", userID).Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count itineraries: %w", err)
+	}
+
+	var itineraries []domain.Itinerary
+	err := config.DB.Where("user_id = ?", userID).
+		Order("updated_at DESC, id").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&itineraries).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load itineraries: %w", err)
+	}
+
+	ids := make([]uuid.UUID, len(itineraries))
+	for i, itinerary := range itineraries {
+		ids[i] = itinerary.ID
+	}
+
+	var counts []struct {
+		ItineraryID uuid.UUID
+		Days        int
+		Stops       int
+	}
+	if len(ids) > 0 {
+		err = config.DB.Model(&domain.ItineraryDay{}).
+			Select("itinerary_days.itinerary_id, COUNT(DISTINCT itinerary_days.id) AS days, COUNT(itinerary_stops.id) AS stops").
+			Joins("LEFT JOIN itinerary_stops ON itinerary_stops.day_id = itinerary_days.id").
+			Where("itinerary_days.itinerary_id IN ?", ids).
+			Group("itinerary_days.itinerary_id").
+			Scan(&counts).Error
+		if err != nil {
+			return nil, fmt.Errorf("failed to count itinerary days: %w", err)
+		}
+	}
+	dayCounts := make(map[uuid.UUID]int, len(counts))
+	stopCounts := make(map[uuid.UUID]int, len(counts))
+	for _, count := range counts {
+		dayCounts[count.ItineraryID] = count.Days
+		stopCounts[count.ItineraryID] = count.Stops
+	}
+
+	summaries := make([]dto.ItinerarySummaryResponse, 0, len(itineraries))
+	for _, itinerary := range itineraries {
+		summaries = append(summaries, dto.ItinerarySummaryResponse{
+			ID:          itinerary.ID,
+			Title:       itinerary.Title,
+			Description: itinerary.Description,
+			StartDate:   formatItineraryDate(itinerary.StartDate, 0),
+			DayCount:    dayCounts[itinerary.ID],
+			StopCount:   stopCounts[itinerary.ID],
+			IsShared:    itinerary.ShareToken != nil,
+			CreatedAt:   itinerary.CreatedAt,
+			UpdatedAt:   itinerary.UpdatedAt,
+		})
+	}
+
+	return &dto.PaginationResponse{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+		Data:       summaries,
+	}, nil
+}
+
+// GetItinerary returns one of the user's own itineraries with travel estimates
+func GetItinerary(itineraryID uuid.UUID, userID uuid.UUID) (*dto.ItineraryResponse, error) {
+	itinerary, err := loadItinerary(config.DB.Where("id = ? AND user_id = ?", itineraryID, userID))
+	if err != nil {
+		return nil, err
+	}
+	return mapItineraryToResponse(itinerary, userID), nil
+}
+
+// GetSharedItinerary returns an itinerary through its share link, read-only. viewerID is
+// uuid.Nil for anonymous visitors.
+func GetSharedItinerary(token string, viewerID uuid.UUID) (*dto.ItineraryResponse, error) {
+	if token == "" {
+		return nil, errors.New("itinerary not found")
+	}
+	itinerary, err := loadItinerary(config.DB.Where("share_token = ?", token))
+	if err != nil {
+		return nil, err
+	}
+	return mapItineraryToResponse(itinerary, viewerID), nil
+}
+
+// UpdateItinerary changes the title, description or start date of an itinerary
+func UpdateItinerary(itineraryID uuid.UUID, userID uuid.UUID, req dto.UpdateItineraryRequest) (*dto.ItineraryResponse, error) {
+	updates := map[string]interface{}{}
+	if req.Title != nil {
+		title := strings.TrimSpace(*req.Title)
+		if title == "" {
+			return nil, errors.New("invalid itinerary: title is required")
+		}
+		updates["title"] = title
+	}
+	if req.Description != nil {
+		updates["description"] = strings.TrimSpace(*req.Description)
+	}
+	if req.StartDate != nil {
+		startDate, err := parseItineraryDate(req.StartDate)
+		if err != nil {
+			return nil, err
+		}
+		updates["start_date"] = startDate
+	}
+
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		itinerary, err := lockOwnedItinerary(tx, itineraryID, userID)
+		if err != nil {
+			return err
+		}
+		if len(updates) == 0 {
+			return nil
+		}
+		return tx.Model(&itinerary).Updates(updates).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return GetItinerary(itineraryID, userID)
+}
+
+// DeleteItinerary removes an itinerary with its days and stops
+func DeleteItinerary(itineraryID uuid.UUID, userID uuid.UUID) error {
+	result := config.DB.Where("id = ? AND user_id = ?", itineraryID, userID).Delete(&domain.Itinerary{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete itinerary: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("itinerary not found")
+	}
+	return nil
+}
+
+// DuplicateItinerary copies one of the user's itineraries. The copy isn't shared.
+func DuplicateItinerary(itineraryID uuid.UUID, userID uuid.UUID) (*dto.ItineraryResponse, error) {
+	source, err := loadItinerary(config.DB.Where("id = ? AND user_id = ?", itineraryID, userID))
+	if err != nil {
+		return nil, err
+	}
+	return copyItinerary(source, userID)
+}
+
+// DuplicateSharedItinerary copies an itinerary shared with the user into their own
+func DuplicateSharedItinerary(token string, userID uuid.UUID) (*dto.ItineraryResponse, error) {
+	if token == "" {
+		return nil, errors.New("itinerary not found")
+	}
+	source, err := loadItinerary(config.DB.Where("share_token = ?", token))
+	if err != nil {
+		return nil, err
+	}
+	return copyItinerary(source, userID)
+}
+
+// ShareItinerary creates the read-only share link of an itinerary. An itinerary that is
+// already shared keeps its link.
+func ShareItinerary(itineraryID uuid.UUID, userID uuid.UUID) (*dto.ItineraryResponse, error) {
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		itinerary, err := lockOwnedItinerary(tx, itineraryID, userID)
+		if err != nil {
+			return err
+		}
+		if itinerary.ShareToken != nil {
+			return nil
+		}
+
+		token, err := generateRandomToken()
+		if err != nil {
+			return fmt.Errorf("failed to generate share link: %w", err)
+		}
+		return tx.Model(&itinerary).Update("share_token", token).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return GetItinerary(itineraryID, userID)
+}
+
+// UnshareItinerary revokes the share link. Sharing again creates a new link.
+func UnshareItinerary(itineraryID uuid.UUID, userID uuid.UUID) (*dto.ItineraryResponse, error) {
+	result := config.DB.Model(&domain.Itinerary{}).
+		Where("id = ? AND user_id = ?", itineraryID, userID).
+		Update("share_token", nil)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to revoke share link: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, errors.New("itinerary not found")
+	}
+
+	return GetItinerary(itineraryID, userID)
+}
+
+// Day Management
+
+// AddItineraryDay appends a day to the end of the trip
+func AddItineraryDay(itineraryID uuid.UUID, userID uuid.UUID, req dto.ItineraryDayRequest) (*dto.ItineraryResponse, error) {
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		itinerary, err := lockOwnedItinerary(tx, itineraryID, userID)
+		if err != nil {
+			return err
+		}
+
+		var days int64
+		if err := tx.Model(&domain.ItineraryDay{}).Where("itinerary_id = ?", itineraryID).Count(&days).Error; err != nil {
+			return err
+		}
+		if days >= maxItineraryDays {
+			return fmt.Errorf("invalid day: a trip can have at most %d days", maxItineraryDays)
+		}
+
+		day := domain.ItineraryDay{
+			ItineraryID: itineraryID,
+			DayNumber:   int(days) + 1,
+			Title:       strings.TrimSpace(req.Title),
+			Notes:       strings.TrimSpace(req.Notes),
+		}
+		if err := tx.Create(&day).Error; err != nil {
+			return fmt.Errorf("failed to add day: %w", err)
+		}
+		return touchItinerary(tx, itinerary)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return GetItinerary(itineraryID, userID)
+}
+
+// UpdateItineraryDay changes the title and notes of a day
+func UpdateItineraryDay(itineraryID uuid.UUID, dayID uuid.UUID, userID uuid.UUID, req dto.ItineraryDayRequest) (*dto.ItineraryResponse, error) {
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		itinerary, err := lockOwnedItinerary(tx, itineraryID, userID)
+		if err != nil {
+			return err
+		}
+
+		result := tx.Model(&domain.ItineraryDay{}).
+			Where("id = ? AND itinerary_id = ?", dayID, itineraryID).
+			Updates(map[string]interface{}{
+				"title": strings.TrimSpace(req.Title),
+				"notes": strings.TrimSpace(req.Notes),
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to update day: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("day not found")
+		}
+		return touchItinerary(tx, itinerary)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return GetItinerary(itineraryID, userID)
+}
+
+// DeleteItineraryDay removes a day with its stops; the days after it move up by one
+func DeleteItineraryDay(itineraryID uuid.UUID, dayID uuid.UUID, userID uuid.UUID) (*dto.ItineraryResponse, error) {
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		itinerary, err := lockOwnedItinerary(tx, itineraryID, userID)
+		if err != nil {
+			return err
+		}
+
+		day, err := findItineraryDay(tx, itineraryID, dayID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Delete(&day).Error; err != nil {
+			return fmt.Errorf("failed to delete day: %w", err)
+		}
+
+		err = tx.Model(&domain.ItineraryDay{}).
+			Where("itinerary_id = ? AND day_number > ?", itineraryID, day.DayNumber).
+			Update("day_number", gorm.Expr("day_number - 1")).Error
+		if err != nil {
+			return fmt.Errorf("failed to renumber days: %w", err)
+		}
+		return touchItinerary(tx, itinerary)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return GetItinerary(itineraryID, userID)
+}
+
+// Stop Management
+
+// AddItineraryStop adds a place, dish or note to a day, at the requested position or last
+func AddItineraryStop(itineraryID uuid.UUID, dayID uuid.UUID, userID uuid.UUID, req dto.CreateItineraryStopRequest) (*dto.ItineraryResponse, error) {
+	stop := domain.ItineraryStop{
+		DayID:           dayID,
+		StopType:        req.StopType,
+		Note:            strings.TrimSpace(req.Note),
+		DurationMinutes: req.DurationMinutes,
+	}
+
+	switch req.StopType {
+	case domain.ItineraryStopPlace:
+		if req.PlaceID == nil {
+			return nil, errors.New("invalid stop: place_id is required for place stops")
+		}
+		var place domain.Place
+		if err := config.DB.Select("id").Scopes(publishedPlacesOnly).First(&place, "id = ?", *req.PlaceID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil, errors.New("place not found")
+			}
+			return nil, err
+		}
+		stop.PlaceID = req.PlaceID
+	case domain.ItineraryStopDish:
+		if req.DishID == nil {
+			return nil, errors.New("invalid stop: dish_id is required for dish stops")
+		}
+		var dish domain.Dish
+		if err := config.DB.Select("id").Where("is_active = ?", true).First(&dish, "id = ?", *req.DishID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return nil, errors.New("dish not found")
+			}
+			return nil, err
+		}
+		stop.DishID = req.DishID
+	default:
+		if stop.Note == "" {
+			return nil, errors.New("invalid stop: note is required for note stops")
+		}
+	}
+
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		itinerary, err := lockOwnedItinerary(tx, itineraryID, userID)
+		if err != nil {
+			return err
+		}
+		if _, err := findItineraryDay(tx, itineraryID, dayID); err != nil {
+			return err
+		}
+
+		var stops int64
+		if err := tx.Model(&domain.ItineraryStop{}).Where("day_id = ?", dayID).Count(&stops).Error; err != nil {
+			return err
+		}
+		if stops >= maxItineraryStopsPerDay {
+			return fmt.Errorf("invalid stop: a day can have at most %d stops", maxItineraryStopsPerDay)
+		}
+
+		stop.SortOrder = int(stops) + 1
+		if req.Position != nil && *req.Position < stop.SortOrder {
+			stop.SortOrder = *req.Position
+			err := tx.Model(&domain.ItineraryStop{}).
+				Where("day_id = ? AND sort_order >= ?", dayID, stop.SortOrder).
+				Update("sort_order", gorm.Expr("sort_order + 1")).Error
+			if err != nil {
+				return fmt.Errorf("failed to make room for stop: %w", err)
+			}
+		}
+
+		if err := tx.Omit(clause.Associations).Create(&stop).Error; err != nil {
+			return fmt.Errorf("failed to add stop: %w", err)
+		}
+		return touchItinerary(tx, itinerary)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return GetItinerary(itineraryID, userID)
+}
+
+// UpdateItineraryStop changes the note or planned duration of a stop
+func UpdateItineraryStop(itineraryID uuid.UUID, stopID uuid.UUID, userID uuid.UUID, req dto.UpdateItineraryStopRequest) (*dto.ItineraryResponse, error) {
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		itinerary, err := lockOwnedItinerary(tx, itineraryID, userID)
+		if err != nil {
+			return err
+		}
+		stop, err := findItineraryStop(tx, itineraryID, stopID)
+		if err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{}
+		if req.Note != nil {
+			note := strings.TrimSpace(*req.Note)
+			if note == "" && stop.StopType == domain.ItineraryStopNote {
+				return errors.New("invalid stop: note is required for note stops")
+			}
+			updates["note"] = note
+		}
+		if req.DurationMinutes != nil {
+			updates["duration_minutes"] = *req.DurationMinutes
+		}
+		if len(updates) == 0 {
+			return nil
+		}
+
+		if err := tx.Model(&stop).Updates(updates).Error; err != nil {
+			return fmt.Errorf("failed to update stop: %w", err)
+		}
+		return touchItinerary(tx, itinerary)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return GetItinerary(itineraryID, userID)
+}
+
+// DeleteItineraryStop removes a stop from its day
+func DeleteItineraryStop(itineraryID uuid.UUID, stopID uuid.UUID, userID uuid.UUID) (*dto.ItineraryResponse, error) {
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		itinerary, err := lockOwnedItinerary(tx, itineraryID, userID)
+		if err != nil {
+			return err
+		}
+		stop, err := findItineraryStop(tx, itineraryID, stopID)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&stop).Error; err != nil {
+			return fmt.Errorf("failed to delete stop: %w", err)
+		}
+		if err := renumberItineraryStops(tx, stop.DayID); err != nil {
+			return err
+		}
+		return touchItinerary(tx, itinerary)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return GetItinerary(itineraryID, userID)
+}
+
+// ReorderItineraryStops sets the visiting order of a day. Every stop of the day has to be
+// listed; stops listed from other days of the itinerary are moved to this day.
+func ReorderItineraryStops(itineraryID uuid.UUID, dayID uuid.UUID, userID uuid.UUID, req dto.ReorderItineraryStopsRequest) (*dto.ItineraryResponse, error) {
+	if len(req.StopIDs) > maxItineraryStopsPerDay {
+		return nil, fmt.Errorf("invalid order: a day can have at most %d stops", maxItineraryStopsPerDay)
+	}
+
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		itinerary, err := lockOwnedItinerary(tx, itineraryID, userID)
+		if err != nil {
+			return err
+		}
+		if _, err := findItineraryDay(tx, itineraryID, dayID); err != nil {
+			return err
+		}
+
+		var stops []domain.ItineraryStop
+		err = tx.Joins("JOIN itinerary_days ON itinerary_days.id = itinerary_stops.day_id").
+			Where("itinerary_days.itinerary_id = ?", itineraryID).
+			Find(&stops).Error
+		if err != nil {
+			return err
+		}
+		stopsByID := make(map[uuid.UUID]domain.ItineraryStop, len(stops))
+		for _, stop := range stops {
+			stopsByID[stop.ID] = stop
+		}
+
+		listed := make(map[uuid.UUID]bool, len(req.StopIDs))
+		sourceDays := make(map[uuid.UUID]bool)
+		for _, id := range req.StopIDs {
+			stop, ok := stopsByID[id]
+			if !ok {
+				return errors.New("stop not found")
+			}
+			if listed[id] {
+				return errors.New("invalid order: a stop is listed more than once")
+			}
+			listed[id] = true
+			if stop.DayID != dayID {
+				sourceDays[stop.DayID] = true
+			}
+		}
+		for _, stop := range stops {
+			if stop.DayID == dayID && !listed[stop.ID] {
+				return errors.New("invalid order: every stop of the day must be listed")
+			}
+		}
+
+		for i, id := range req.StopIDs {
+			err := tx.Model(&domain.ItineraryStop{}).
+				Where("id = ?", id).
+				Updates(map[string]interface{}{"day_id": dayID, "sort_order": i + 1}).Error
+			if err != nil {
+				return fmt.Errorf("failed to update stop order: %w", err)
+			}
+		}
+		for sourceDayID := range sourceDays {
+			if err := renumberItineraryStops(tx, sourceDayID); err != nil {
+				return err
+			}
+		}
+		return touchItinerary(tx, itinerary)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return GetItinerary(itineraryID, userID)
+}
+
+// OptimizeItineraryDay reorders the places of a day for the shortest drive, starting from
+// the day's first place. Dish and note stops stay right after the place they follow, and
+// stops before the first place stay at the start.
+func OptimizeItineraryDay(itineraryID uuid.UUID, dayID uuid.UUID, userID uuid.UUID) (*dto.ItineraryResponse, error) {
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		itinerary, err := lockOwnedItinerary(tx, itineraryID, userID)
+		if err != nil {
+			return err
+		}
+		if _, err := findItineraryDay(tx, itineraryID, dayID); err != nil {
+			return err
+		}
+
+		var stops []domain.ItineraryStop
+		err = tx.Preload("Place", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "latitude", "longitude")
+		}).
+			Where("day_id = ?", dayID).
+			Order("sort_order ASC, created_at ASC").
+			Find(&stops).Error
+		if err != nil {
+			return err
+		}
+
+		// Split the day into a leading group and one group per located place
+		var leading []domain.ItineraryStop
+		var groups [][]domain.ItineraryStop
+		var points []utils.GeoPoint
+		for _, stop := range stops {
+			if point, ok := itineraryStopPoint(stop); ok {
+				groups = append(groups, []domain.ItineraryStop{stop})
+				points = append(points, point)
+			} else if len(groups) == 0 {
+				leading = append(leading, stop)
+			} else {
+				groups[len(groups)-1] = append(groups[len(groups)-1], stop)
+			}
+		}
+		if len(points) < 3 {
+			return nil // Nothing to gain with fewer than three places
+		}
+
+		ordered := leading
+		for _, i := range utils.OptimizeRouteOrder(points) {
+			ordered = append(ordered, groups[i]...)
+		}
+		for i, stop := range ordered {
+			if err := tx.Model(&domain.ItineraryStop{}).Where("id = ?", stop.ID).Update("sort_order", i+1).Error; err != nil {
+				return fmt.Errorf("failed to update stop order: %w", err)
+			}
+		}
+		return touchItinerary(tx, itinerary)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return GetItinerary(itineraryID, userID)
+}
+
+// Helper functions
+
+// lockOwnedItinerary locks an itinerary of the user so concurrent edits of its days and
+// stops are applied one at a time. Other users' itineraries are reported as not found.
+func lockOwnedItinerary(tx *gorm.DB, itineraryID uuid.UUID, userID uuid.UUID) (domain.Itinerary, error) {
+	var itinerary domain.Itinerary
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND user_id = ?", itineraryID, userID).
+		First(&itinerary).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return itinerary, errors.New("itinerary not found")
+		}
+		return itinerary, err
+	}
+	return itinerary, nil
+}
+
+func findItineraryDay(tx *gorm.DB, itineraryID uuid.UUID, dayID uuid.UUID) (domain.ItineraryDay, error) {
+	var day domain.ItineraryDay
+	if err := tx.Where("id = ? AND itinerary_id = ?", dayID, itineraryID).First(&day).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return day, errors.New("day not found")
+		}
+		return day, err
+	}
+	return day, nil
+}
+
+func findItineraryStop(tx *gorm.DB, itineraryID uuid.UUID, stopID uuid.UUID) (domain.ItineraryStop, error) {
+	var stop domain.ItineraryStop
+	err := tx.Joins("JOIN itinerary_days ON itinerary_days.id = itinerary_stops.day_id").
+		Where("itinerary_stops.id = ? AND itinerary_days.itinerary_id = ?", stopID, itineraryID).
+		First(&stop).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return stop, errors.New("stop not found")
+		}
+		return stop, err
+	}
+	return stop, nil
+}
+
+// renumberItineraryStops closes the gaps in the sort order of a day
+func renumberItineraryStops(tx *gorm.DB, dayID uuid.UUID) error {
+	var ids []uuid.UUID
+	err := tx.Model(&domain.ItineraryStop{}).
+		Where("day_id = ?", dayID).
+		Order("sort_order ASC, created_at ASC").
+		Pluck("id", &ids).Error
+	if err != nil {
+		return err
+	}
+	for i, id := range ids {
+		if err := tx.Model(&domain.ItineraryStop{}).Where("id = ?", id).Update("sort_order", i+1).Error; err != nil {
+			return fmt.Errorf("failed to renumber stops: %w", err)
+		}
+	}
+	return nil
+}
+
+func touchItinerary(tx *gorm.DB, itinerary domain.Itinerary) error {
+	return tx.Model(&itinerary).Update("updated_at", time.Now()).Error
+}
+
+// loadItinerary loads the itinerary matched by query with its days, stops, places and dishes
+func loadItinerary(query *gorm.DB) (domain.Itinerary, error) {
+	var itinerary domain.Itinerary
+	err := query.
+		Preload("User").
+		Preload("Days", func(db *gorm.DB) *gorm.DB {
+			return db.Order("day_number ASC")
+		}).
+		Preload("Days.Stops", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sort_order ASC, created_at ASC")
+		}).
+		Preload("Days.Stops.Place.Categories").
+		Preload("Days.Stops.Place.Images").
+		Preload("Days.Stops.Place.Governate").
+		Preload("Days.Stops.Place.Wilayah").
+		Preload("Days.Stops.Dish.Images").
+		Preload("Days.Stops.Dish.Governate").
+		First(&itinerary).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return itinerary, errors.New("itinerary not found")
+		}
+		return itinerary, err
+	}
+	return itinerary, nil
+}
+
+// copyItinerary saves a copy of source, with its days and stops, owned by userID
+func copyItinerary(source domain.Itinerary, userID uuid.UUID) (*dto.ItineraryResponse, error) {
+	itinerary := domain.Itinerary{
+		UserID:      userID,
+		Title:       source.Title,
+		Description: source.Description,
+		StartDate:   source.StartDate,
+	}
+	if len(itinerary.Title) <= 193 {
+		itinerary.Title += " (copy)"
+	}
+
+	for _, sourceDay := range source.Days {
+		day := domain.ItineraryDay{
+			DayNumber: sourceDay.DayNumber,
+			Title:     sourceDay.Title,
+			Notes:     sourceDay.Notes,
+		}
+		for _, sourceStop := range sourceDay.Stops {
+			day.Stops = append(day.Stops, domain.ItineraryStop{
+				StopType:        sourceStop.StopType,
+				PlaceID:         sourceStop.PlaceID,
+				DishID:          sourceStop.DishID,
+				Note:            sourceStop.Note,
+				DurationMinutes: sourceStop.DurationMinutes,
+				SortOrder:       sourceStop.SortOrder,
+			})
+		}
+		itinerary.Days = append(itinerary.Days, day)
+	}
+
+	err := config.DB.Omit("User", "Days.Stops.Place", "Days.Stops.Dish").Create(&itinerary).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to duplicate itinerary: %w", err)
+	}
+
+	return GetItinerary(itinerary.ID, userID)
+}
+
+// itineraryStopPoint returns the coordinates of a place stop, if the place has them
+func itineraryStopPoint(stop domain.ItineraryStop) (utils.GeoPoint, bool) {
+	if stop.Place == nil {
+		return utils.GeoPoint{}, false
+	}
+	lat, lng := stop.Place.Latitude, stop.Place.Longitude
+	if (lat == 0 && lng == 0) || !utils.ValidCoordinates(lat, lng) {
+		return utils.GeoPoint{}, false
+	}
+	return utils.GeoPoint{Latitude: lat, Longitude: lng}, true
+}
+
+// estimateDrive estimates the road distance (km, one decimal) and driving time (minutes)
+// between two points
+func estimateDrive(from, to utils.GeoPoint) (float64, int) {
+	distance := utils.HaversineKm(from.Latitude, from.Longitude, to.Latitude, to.Longitude) * itineraryRoadFactor()
+	minutes := int(math.Round(distance / itineraryAverageSpeed() * 60))
+	return math.Round(distance*10) / 10, minutes
+}
+
+func parseItineraryDate(value *string) (*time.Time, error) {
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse(dateLayout, *value)
+	if err != nil {
+		return nil, errors.New("invalid start_date, expected YYYY-MM-DD")
+	}
+	return &date, nil
+}
+
+// formatItineraryDate returns the date offset days after start, or nil without a start date
+func formatItineraryDate(start *time.Time, offset int) *string {
+	if start == nil {
+		return nil
+	}
+	date := start.AddDate(0, 0, offset).Format(dateLayout)
+	return &date
+}
+
+// mapItineraryToResponse maps an itinerary loaded by loadItinerary. The share link is only
+// included for its owner.
+func mapItineraryToResponse(itinerary domain.Itinerary, viewerID uuid.UUID) *dto.ItineraryResponse {
+	isOwner := itinerary.UserID == viewerID
+	response := &dto.ItineraryResponse{
+		ID:          itinerary.ID,
+		Title:       itinerary.Title,
+		Description: itinerary.Description,
+		StartDate:   formatItineraryDate(itinerary.StartDate, 0),
+		Owner: dto.UserInfo{
+			ID:        itinerary.User.ID,
+			Username:  itinerary.User.Username,
+			FirstName: itinerary.User.FirstName,
+			LastName:  itinerary.User.LastName,
+		},
+		IsOwner:   isOwner,
+		Days:      make([]dto.ItineraryDayResponse, 0, len(itinerary.Days)),
+		CreatedAt: itinerary.CreatedAt,
+		UpdatedAt: itinerary.UpdatedAt,
+	}
+
+	if isOwner && itinerary.ShareToken != nil {
+		shareURL := fmt.Sprintf("%s/en/itineraries/shared/%s",
+			getEnvWithDefault("FRONTEND_URL", "http://localhost:3000"), *itinerary.ShareToken)
+		response.ShareToken = itinerary.ShareToken
+		response.ShareURL = &shareURL
+	}
+
+	for _, day := range itinerary.Days {
+		dayResponse := dto.ItineraryDayResponse{
+			ID:        day.ID,
+			DayNumber: day.DayNumber,
+			Date:      formatItineraryDate(itinerary.StartDate, day.DayNumber-1),
+			Title:     day.Title,
+			Notes:     day.Notes,
+			Stops:     make([]dto.ItineraryStopResponse, 0, len(day.Stops)),
+		}
+
+		// Travel is estimated between consecutive stops that have coordinates
+		var previousID uuid.UUID
+		var previous *utils.GeoPoint
+		for _, stop := range day.Stops {
+			stopResponse := dto.ItineraryStopResponse{
+				ID:              stop.ID,
+				StopType:        stop.StopType,
+				SortOrder:       stop.SortOrder,
+				Note:            stop.Note,
+				DurationMinutes: stop.DurationMinutes,
+			}
+			if stop.Place != nil {
+				place := mapPlaceToListResponse(*stop.Place)
+				stopResponse.Place = &place
+			}
+			if stop.Dish != nil {
+				stopResponse.Dish = ConvertDishToResponse(*stop.Dish)
+			}
+
+			if point, ok := itineraryStopPoint(stop); ok {
+				if previous != nil {
+					distance, minutes := estimateDrive(*previous, point)
+					stopResponse.TravelFromPrevious = &dto.TravelEstimateResponse{
+						FromStopID:      previousID,
+						DistanceKm:      distance,
+						DurationMinutes: minutes,
+					}
+					dayResponse.DistanceKm += distance
+					dayResponse.DrivingMinutes += minutes
+				}
+				previousID, previous = stop.ID, &point
+			}
+
+			dayResponse.Stops = append(dayResponse.Stops, stopResponse)
+		}
+
+		dayResponse.DistanceKm = math.Round(dayResponse.DistanceKm*10) / 10
+		response.DistanceKm += dayResponse.DistanceKm
+		response.DrivingMinutes += dayResponse.DrivingMinutes
+		response.Days = append(response.Days, dayResponse)
+	}
+	response.DistanceKm = math.Round(response.DistanceKm*10) / 10
+
+	return response
+}
@@ -0,0 +1,219 @@
+package services
+
+import (
+	"almlah/config"
+	"almlah/internals/domain"
+	"almlah/internals/dto"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DuplicateList deep-copies a list with its sections, items and images into a new draft.
+// The copy has no schedule and belongs to the user making it.
+func (s *ListService) DuplicateList(id uuid.UUID, req dto.DuplicateListRequest, userID uuid.UUID) (*dto.ListResponse, error) {
+	var copyID uuid.UUID
+
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		var source domain.List
+		if err := tx.First(&source, "id = ?", id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("list not found")
+			}
+			return fmt.Errorf("failed to fetch list: %w", err)
+		}
+
+		slug, err := listCopySlug(tx, source.Slug, req.Slug)
+		if err != nil {
+			return err
+		}
+
+		list := domain.List{
+			TitleAr:       source.TitleAr,
+			TitleEn:       source.TitleEn,
+			Slug:          slug,
+			DescriptionAr: source.DescriptionAr,
+			DescriptionEn: source.DescriptionEn,
+			FeaturedImage: source.FeaturedImage,
+			Status:        domain.ListStatusDraft,
+			CreatedBy:     userID,
+		}
+		if req.TitleAr != "" {
+			list.TitleAr = req.TitleAr
+		}
+		if req.TitleEn != "" {
+			list.TitleEn = req.TitleEn
+		}
+		if err := tx.Create(&list).Error; err != nil {
+			return fmt.Errorf("failed to create list: %w", err)
+		}
+		copyID = list.ID
+
+		sectionIDs, err := copyListSections(tx, source.ID, list.ID)
+		if err != nil {
+			return err
+		}
+		return copyListItems(tx, source.ID, list.ID, sectionIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetListByID(copyID)
+}
+
+// copyListSections copies the sections of a list and their images, returning the new
+// section ID of every copied section
+func copyListSections(tx *gorm.DB, sourceID, targetID uuid.UUID) (map[uuid.UUID]uuid.UUID, error) {
+	var sections []domain.ListSection
+	if err := tx.Where("list_id = ?", sourceID).Order("sort_order ASC").Find(&sections).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch sections: %w", err)
+	}
+
+	newIDs := make(map[uuid.UUID]uuid.UUID, len(sections))
+	if len(sections) == 0 {
+		return newIDs, nil
+	}
+
+	oldIDs := make([]uuid.UUID, len(sections))
+	copies := make([]domain.ListSection, len(sections))
+	for i, section := range sections {
+		oldIDs[i] = section.ID
+		newIDs[section.ID] = uuid.New()
+		copies[i] = domain.ListSection{
+			ID:            newIDs[section.ID],
+			ListID:        targetID,
+			TitleAr:       section.TitleAr,
+			TitleEn:       section.TitleEn,
+			DescriptionAr: section.DescriptionAr,
+			DescriptionEn: section.DescriptionEn,
+			SortOrder:     section.SortOrder,
+		}
+	}
+	if err := tx.Create(&copies).Error; err != nil {
+		return nil, fmt.Errorf("failed to copy sections: %w", err)
+	}
+
+	var images []domain.ListSectionImage
+	if err := tx.Where("section_id IN ?", oldIDs).Find(&images).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch section images: %w", err)
+	}
+	if len(images) > 0 {
+		imageCopies := make([]domain.ListSectionImage, len(images))
+		for i, image := range images {
+			imageCopies[i] = domain.ListSectionImage{
+				SectionID: newIDs[image.SectionID],
+				ImageURL:  image.ImageURL,
+				AltTextAr: image.AltTextAr,
+				AltTextEn: image.AltTextEn,
+				SortOrder: image.SortOrder,
+			}
+		}
+		if err := tx.Create(&imageCopies).Error; err != nil {
+			return nil, fmt.Errorf("failed to copy section images: %w", err)
+		}
+	}
+
+	return newIDs, nil
+}
+
+// copyListItems copies the items of a list and their images, moving each item into the
+// copy of its section
+func copyListItems(tx *gorm.DB, sourceID, targetID uuid.UUID, sectionIDs map[uuid.UUID]uuid.UUID) error {
+	var items []domain.ListItem
+	if err := tx.Where("list_id = ?", sourceID).Order("sort_order ASC").Find(&items).Error; err != nil {
+		return fmt.Errorf("failed to fetch list items: %w", err)
+	}
+	if len(items) == 0 {
+		return nil
+	}
+
+	oldIDs := make([]uuid.UUID, len(items))
+	newIDs := make(map[uuid.UUID]uuid.UUID, len(items))
+	copies := make([]domain.ListItem, len(items))
+	for i, item := range items {
+		oldIDs[i] = item.ID
+		newIDs[item.ID] = uuid.New()
+		copies[i] = domain.ListItem{
+			ID:        newIDs[item.ID],
+			ListID:    targetID,
+			PlaceID:   item.PlaceID,
+			ContentAr: item.ContentAr,
+			ContentEn: item.ContentEn,
+			SortOrder: item.SortOrder,
+			ItemType:  item.ItemType,
+		}
+		// Items of a deleted section are already outside any section
+		if item.SectionID != nil {
+			if sectionID, ok := sectionIDs[*item.SectionID]; ok {
+				copies[i].SectionID = &sectionID
+			}
+		}
+	}
+	if err := tx.Create(&copies).Error; err != nil {
+		return fmt.Errorf("failed to copy list items: %w", err)
+	}
+
+	var images []domain.ListItemImage
+	if err := tx.Where("list_item_id IN ?", oldIDs).Find(&images).Error; err != nil {
+		return fmt.Errorf("failed to fetch list item images: %w", err)
+	}
+	if len(images) == 0 {
+		return nil
+	}
+
+	imageCopies := make([]domain.ListItemImage, len(images))
+	for i, image := range images {
+		imageCopies[i] = domain.ListItemImage{
+			ListItemID: newIDs[image.ListItemID],
+			ImageURL:   image.ImageURL,
+			AltTextAr:  image.AltTextAr,
+			AltTextEn:  image.AltTextEn,
+			SortOrder:  image.SortOrder,
+		}
+	}
+	if err := tx.Create(&imageCopies).Error; err != nil {
+		return fmt.Errorf("failed to copy list item images: %w", err)
+	}
+	return nil
+}
+
+// listCopySlug returns the requested slug if it's free, otherwise "<slug>-copy", then
+// "<slug>-copy-2", "<slug>-copy-3"... Deleted lists still hold their slug.
+func listCopySlug(tx *gorm.DB, sourceSlug, requested string) (string, error) {
+	if requested != "" {
+		taken, err := isListSlugTaken(tx, requested)
+		if err != nil {
+			return "", err
+		}
+		if taken {
+			return "", errors.New("slug already exists")
+		}
+		return requested, nil
+	}
+
+	base := sourceSlug + "-copy"
+	for n := 1; ; n++ {
+		candidate := base
+		if n > 1 {
+			candidate = fmt.Sprintf("%s-%d", base, n)
+		}
+		taken, err := isListSlugTaken(tx, candidate)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return candidate, nil
+		}
+	}
+}
+
+func isListSlugTaken(tx *gorm.DB, slug string) (bool, error) {
+	var count int64
+	if err := tx.Unscoped().Model(&domain.List{}).Where("slug = ?", slug).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check slug: %w", err)
+	}
+	return count > 0, nil
+}
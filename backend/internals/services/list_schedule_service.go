@@ -0,0 +1,184 @@
+package services
+
+import (
+	"almlah/config"
+	"almlah/internals/cache"
+	"almlah/internals/domain"
+	"almlah/internals/dto"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ListCacheKeyPrefix prefixes every cached public list response
+const ListCacheKeyPrefix = "http_cache:lists:"
+
+const (
+	// listSchedulerLockID is the Postgres advisory lock held while applying list schedules,
+	// so only one API instance does it at a time
+	listSchedulerLockID int64 = 7239001
+
+	listScheduleActionPublish   = "publish"
+	listScheduleActionUnpublish = "unpublish"
+)
+
+type scheduledListTransition struct {
+	ID   uuid.UUID
+	Slug string
+}
+
+// SetListSchedule replaces the publish and unpublish times of a list
+func (s *ListService) SetListSchedule(id uuid.UUID, req dto.SetListScheduleRequest) (*dto.ListResponse, error) {
+	if err := validateListSchedule(req.PublishAt, req.UnpublishAt); err != nil {
+		return nil, err
+	}
+
+	var list domain.List
+	if err := config.DB.First(&list, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("list not found")
+		}
+		return nil, fmt.Errorf("failed to fetch list: %w", err)
+	}
+
+	list.PublishAt = req.PublishAt
+	list.UnpublishAt = req.UnpublishAt
+	if err := config.DB.Model(&list).Select("publish_at", "unpublish_at").Updates(&list).Error; err != nil {
+		return nil, fmt.Errorf("failed to update list schedule: %w", err)
+	}
+
+	return s.convertToListResponse(list), nil
+}
+
+// GetScheduledListChanges returns the transitions the scheduler will make, soonest first.
+// Only published lists are archived, so an unpublish time is reported when the list is
+// published or will be published before it.
+func (s *ListService) GetScheduledListChanges() ([]dto.ScheduledListChange, error) {
+	var lists []domain.List
+	err := config.DB.
+		Where("(publish_at IS NOT NULL AND status <> @published) OR (unpublish_at IS NOT NULL AND (status = @published OR publish_at < unpublish_at))",
+			map[string]interface{}{"published": domain.ListStatusPublished}).
+		Find(&lists).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch scheduled lists: %w", err)
+	}
+
+	changes := make([]dto.ScheduledListChange, 0, len(lists))
+	for _, list := range lists {
+		change := dto.ScheduledListChange{
+			ListID:  list.ID,
+			TitleAr: list.TitleAr,
+			TitleEn: list.TitleEn,
+			Slug:    list.Slug,
+			Status:  list.Status,
+		}
+		publishPending := list.PublishAt != nil && list.Status != domain.ListStatusPublished
+		if publishPending {
+			change.Action = listScheduleActionPublish
+			change.At = *list.PublishAt
+			changes = append(changes, change)
+		}
+		if list.UnpublishAt != nil && (list.Status == domain.ListStatusPublished ||
+			publishPending && list.PublishAt.Before(*list.UnpublishAt)) {
+			change.Action = listScheduleActionUnpublish
+			change.At = *list.UnpublishAt
+			changes = append(changes, change)
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].At.Before(changes[j].At)
+	})
+
+	return changes, nil
+}
+
+// ApplyListSchedules publishes and archives the lists whose time has come. Each time is
+// cleared by the transition that uses it, so running it again, or on another instance,
+// changes nothing.
+func ApplyListSchedules() (int, error) {
+	var transitioned []scheduledListTransition
+	now := time.Now()
+
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		var locked bool
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", listSchedulerLockID).Scan(&locked).Error; err != nil {
+			return fmt.Errorf("failed to take list scheduler lock: %w", err)
+		}
+		if !locked {
+			// Another instance is applying the schedules right now
+			return nil
+		}
+
+		var published []scheduledListTransition
+		err := tx.Raw(`UPDATE lists SET status = ?, publish_at = NULL, updated_at = ?
+			WHERE publish_at <= ? AND status <> ? AND deleted_at IS NULL
+			RETURNING id, slug`,
+			domain.ListStatusPublished, now, now, domain.ListStatusPublished).Scan(&published).Error
+		if err != nil {
+			return fmt.Errorf("failed to publish scheduled lists: %w", err)
+		}
+
+		var unpublished []scheduledListTransition
+		err = tx.Raw(`UPDATE lists SET status = ?, unpublish_at = NULL, updated_at = ?
+			WHERE unpublish_at <= ? AND status = ? AND deleted_at IS NULL
+			RETURNING id, slug`,
+			domain.ListStatusArchived, now, now, domain.ListStatusPublished).Scan(&unpublished).Error
+		if err != nil {
+			return fmt.Errorf("failed to unpublish scheduled lists: %w", err)
+		}
+
+		transitioned = append(published, unpublished...)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if len(transitioned) > 0 {
+		for _, list := range transitioned {
+			log.Printf("List scheduler changed the status of list %s (%s)", list.Slug, list.ID)
+		}
+		InvalidateListCaches()
+	}
+
+	return len(transitioned), nil
+}
+
+// StartListScheduler applies list schedules now and then every LIST_SCHEDULER_INTERVAL
+// (default 30s) until the process exits
+func StartListScheduler() {
+	interval, err := time.ParseDuration(getEnvWithDefault("LIST_SCHEDULER_INTERVAL", "30s"))
+	if err != nil || interval <= 0 {
+		interval = 30 * time.Second
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if _, err := ApplyListSchedules(); err != nil {
+				log.Printf("Warning: Failed to apply list schedules: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// InvalidateListCaches drops every cached public list response
+func InvalidateListCaches() {
+	cache.InvalidatePattern(ListCacheKeyPrefix + "*")
+}
+
+func validateListSchedule(publishAt, unpublishAt *time.Time) error {
+	if publishAt != nil && unpublishAt != nil && !unpublishAt.After(*publishAt) {
+		return errors.New("invalid schedule: unpublish_at must be after publish_at")
+	}
+	return nil
+}
@@ -25,6 +25,10 @@ func (s *ListService) CreateList(req dto.CreateListRequest, createdBy uuid.UUID)
 		return nil, errors.New("slug already exists")
 	}
 
+	if err := validateListSchedule(req.PublishAt, req.UnpublishAt); err != nil {
+		return nil, err
+	}
+
 	list := domain.List{
 		TitleAr:       req.TitleAr,
 		TitleEn:       req.TitleEn,
@@ -33,6 +37,8 @@ func (s *ListService) CreateList(req dto.CreateListRequest, createdBy uuid.UUID)
 		DescriptionEn: req.DescriptionEn,
 		FeaturedImage: req.FeaturedImage,
 		Status:        req.Status,
+		PublishAt:     req.PublishAt,
+		UnpublishAt:   req.UnpublishAt,
 		CreatedBy:     createdBy,
 	}
 
@@ -147,6 +153,18 @@ func (s *ListService) GetListByID(id uuid.UUID) (*dto.ListResponse, error) {
 	return s.convertToListResponseWithItems(list), nil
 }
 
+// GetListStatus returns the status of a list without loading its content
+func (s *ListService) GetListStatus(id uuid.UUID) (string, error) {
+	var list domain.List
+	if err := config.DB.Select("id", "status").First(&list, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", errors.New("list not found")
+		}
+		return "", fmt.Errorf("failed to fetch list: %w", err)
+	}
+	return list.Status, nil
+}
+
 func (s *ListService) UpdateList(id uuid.UUID, req dto.UpdateListRequest) (*dto.ListResponse, error) {
 	var list domain.List
 	if err := config.DB.First(&list, "id = ?", id).Error; err != nil {
@@ -343,6 +361,8 @@ func (s *ListService) convertToListResponse(list domain.List) *dto.ListResponse
 		FeaturedImage: list.FeaturedImage,
 		Status:        list.Status,
 		SortOrder:     list.SortOrder,
+		PublishAt:     list.PublishAt,
+		UnpublishAt:   list.UnpublishAt,
 		CreatedBy:     list.CreatedBy,
 		CreatedAt:     list.CreatedAt,
 		UpdatedAt:     list.UpdatedAt,
@@ -391,6 +411,8 @@ func (s *ListService) convertToListSummaryResponse(list domain.List) dto.ListSum
 		DescriptionEn: list.DescriptionEn,
 		FeaturedImage: list.FeaturedImage,
 		Status:        list.Status,
+		PublishAt:     list.PublishAt,
+		UnpublishAt:   list.UnpublishAt,
 		ItemCount:     int(itemCount),
 		CreatedAt:     list.CreatedAt,
 	}
@@ -0,0 +1,265 @@
+package services
+
+import (
+	"almlah/config"
+	"almlah/internals/domain"
+	"almlah/internals/dto"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// orderTemplateSections sorts template sections in the order lists get them
+func orderTemplateSections(db *gorm.DB) *gorm.DB {
+	return db.Order("sort_order ASC")
+}
+
+// GetListTemplates returns every list template by name
+func (s *ListService) GetListTemplates() ([]dto.ListTemplateResponse, error) {
+	var templates []domain.ListTemplate
+	if err := config.DB.Preload("Sections", orderTemplateSections).Order("name_en ASC").Find(&templates).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch list templates: %w", err)
+	}
+
+	response := make([]dto.ListTemplateResponse, len(templates))
+	for i, template := range templates {
+		response[i] = mapListTemplateToResponse(template)
+	}
+	return response, nil
+}
+
+// GetListTemplateByID returns a list template with its sections
+func (s *ListService) GetListTemplateByID(id uuid.UUID) (*dto.ListTemplateResponse, error) {
+	template, err := findListTemplate(config.DB, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := mapListTemplateToResponse(*template)
+	return &response, nil
+}
+
+// CreateListTemplate saves a new skeleton of sections
+func (s *ListService) CreateListTemplate(req dto.CreateListTemplateRequest, userID uuid.UUID) (*dto.ListTemplateResponse, error) {
+	template := domain.ListTemplate{
+		NameAr:        req.NameAr,
+		NameEn:        req.NameEn,
+		DescriptionAr: req.DescriptionAr,
+		DescriptionEn: req.DescriptionEn,
+		CreatedBy:     userID,
+		Sections:      buildTemplateSections(req.Sections),
+	}
+	if err := config.DB.Create(&template).Error; err != nil {
+		return nil, fmt.Errorf("failed to create list template: %w", err)
+	}
+
+	return s.GetListTemplateByID(template.ID)
+}
+
+// UpdateListTemplate changes the given fields of a template. Sent sections replace all of its sections.
+func (s *ListService) UpdateListTemplate(id uuid.UUID, req dto.UpdateListTemplateRequest) (*dto.ListTemplateResponse, error) {
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		template, err := findListTemplate(tx, id)
+		if err != nil {
+			return err
+		}
+
+		if req.NameAr != nil && *req.NameAr != "" {
+			template.NameAr = *req.NameAr
+		}
+		if req.NameEn != nil && *req.NameEn != "" {
+			template.NameEn = *req.NameEn
+		}
+		if req.DescriptionAr != nil {
+			template.DescriptionAr = *req.DescriptionAr
+		}
+		if req.DescriptionEn != nil {
+			template.DescriptionEn = *req.DescriptionEn
+		}
+		if err := tx.Omit("Sections").Save(template).Error; err != nil {
+			return fmt.Errorf("failed to update list template: %w", err)
+		}
+
+		if req.Sections == nil {
+			return nil
+		}
+		if err := tx.Where("template_id = ?", id).Delete(&domain.ListTemplateSection{}).Error; err != nil {
+			return fmt.Errorf("failed to replace template sections: %w", err)
+		}
+		sections := buildTemplateSections(*req.Sections)
+		if len(sections) == 0 {
+			return nil
+		}
+		for i := range sections {
+			sections[i].TemplateID = id
+		}
+		if err := tx.Create(&sections).Error; err != nil {
+			return fmt.Errorf("failed to replace template sections: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetListTemplateByID(id)
+}
+
+// DeleteListTemplate removes a template. Lists made from it keep their sections.
+func (s *ListService) DeleteListTemplate(id uuid.UUID) error {
+	result := config.DB.Delete(&domain.ListTemplate{}, "id = ?", id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete list template: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("list template not found")
+	}
+	return nil
+}
+
+// SaveListAsTemplate makes a template from the sections of a list, without their items or images
+func (s *ListService) SaveListAsTemplate(listID uuid.UUID, req dto.SaveListAsTemplateRequest, userID uuid.UUID) (*dto.ListTemplateResponse, error) {
+	var list domain.List
+	err := config.DB.
+		Preload("ListSections", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sort_order ASC")
+		}).
+		First(&list, "id = ?", listID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("list not found")
+		}
+		return nil, fmt.Errorf("failed to fetch list: %w", err)
+	}
+
+	sections := make([]dto.ListTemplateSectionRequest, len(list.ListSections))
+	for i, section := range list.ListSections {
+		sections[i] = dto.ListTemplateSectionRequest{
+			TitleAr:       section.TitleAr,
+			TitleEn:       section.TitleEn,
+			DescriptionAr: section.DescriptionAr,
+			DescriptionEn: section.DescriptionEn,
+		}
+	}
+
+	return s.CreateListTemplate(dto.CreateListTemplateRequest{
+		NameAr:        req.NameAr,
+		NameEn:        req.NameEn,
+		DescriptionAr: req.DescriptionAr,
+		DescriptionEn: req.DescriptionEn,
+		Sections:      sections,
+	}, userID)
+}
+
+// CreateListFromTemplate starts a new draft list with the sections of a template
+func (s *ListService) CreateListFromTemplate(templateID uuid.UUID, req dto.CreateListFromTemplateRequest, userID uuid.UUID) (*dto.ListResponse, error) {
+	var listID uuid.UUID
+
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		template, err := findListTemplate(tx, templateID)
+		if err != nil {
+			return err
+		}
+
+		taken, err := isListSlugTaken(tx, req.Slug)
+		if err != nil {
+			return err
+		}
+		if taken {
+			return errors.New("slug already exists")
+		}
+
+		list := domain.List{
+			TitleAr:       req.TitleAr,
+			TitleEn:       req.TitleEn,
+			Slug:          req.Slug,
+			DescriptionAr: req.DescriptionAr,
+			DescriptionEn: req.DescriptionEn,
+			FeaturedImage: req.FeaturedImage,
+			Status:        domain.ListStatusDraft,
+			CreatedBy:     userID,
+		}
+		if err := tx.Create(&list).Error; err != nil {
+			return fmt.Errorf("failed to create list: %w", err)
+		}
+		listID = list.ID
+
+		if len(template.Sections) == 0 {
+			return nil
+		}
+		sections := make([]domain.ListSection, len(template.Sections))
+		for i, section := range template.Sections {
+			sections[i] = domain.ListSection{
+				ListID:        list.ID,
+				TitleAr:       section.TitleAr,
+				TitleEn:       section.TitleEn,
+				DescriptionAr: section.DescriptionAr,
+				DescriptionEn: section.DescriptionEn,
+				SortOrder:     section.SortOrder,
+			}
+		}
+		if err := tx.Create(&sections).Error; err != nil {
+			return fmt.Errorf("failed to create sections: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetListByID(listID)
+}
+
+func findListTemplate(tx *gorm.DB, id uuid.UUID) (*domain.ListTemplate, error) {
+	var template domain.ListTemplate
+	if err := tx.Preload("Sections", orderTemplateSections).First(&template, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("list template not found")
+		}
+		return nil, fmt.Errorf("failed to fetch list template: %w", err)
+	}
+	return &template, nil
+}
+
+// buildTemplateSections numbers the sections from 1 in the order they were sent
+func buildTemplateSections(requests []dto.ListTemplateSectionRequest) []domain.ListTemplateSection {
+	sections := make([]domain.ListTemplateSection, len(requests))
+	for i, req := range requests {
+		sections[i] = domain.ListTemplateSection{
+			TitleAr:       req.TitleAr,
+			TitleEn:       req.TitleEn,
+			DescriptionAr: req.DescriptionAr,
+			DescriptionEn: req.DescriptionEn,
+			SortOrder:     i + 1,
+		}
+	}
+	return sections
+}
+
+func mapListTemplateToResponse(template domain.ListTemplate) dto.ListTemplateResponse {
+	sections := make([]dto.ListTemplateSectionResponse, len(template.Sections))
+	for i, section := range template.Sections {
+		sections[i] = dto.ListTemplateSectionResponse{
+			ID:            section.ID,
+			TitleAr:       section.TitleAr,
+			TitleEn:       section.TitleEn,
+			DescriptionAr: section.DescriptionAr,
+			DescriptionEn: section.DescriptionEn,
+			SortOrder:     section.SortOrder,
+		}
+	}
+
+	return dto.ListTemplateResponse{
+		ID:            template.ID,
+		NameAr:        template.NameAr,
+		NameEn:        template.NameEn,
+		DescriptionAr: template.DescriptionAr,
+		DescriptionEn: template.DescriptionEn,
+		CreatedBy:     template.CreatedBy,
+		CreatedAt:     template.CreatedAt,
+		UpdatedAt:     template.UpdatedAt,
+		Sections:      sections,
+	}
+}
@@ -0,0 +1,116 @@
+// services/nearby_places_service.go
+package services
+
+import (
+	"almlah/config"
+	"almlah/internals/domain"
+	"almlah/internals/dto"
+	"almlah/internals/utils"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// haversineSQL computes the great-circle distance (km) between places.latitude/longitude
+// and a point passed as (lat, lat, lng) bind parameters. LEAST guards ASIN against
+// floating point values slightly above 1.
+var haversineSQL = fmt.Sprintf(`%f * 2 * ASIN(LEAST(1, SQRT(
+	POWER(SIN(RADIANS(places.latitude - ?) / 2), 2) +
+	COS(RADIANS(?)) * COS(RADIANS(places.latitude)) *
+	POWER(SIN(RADIANS(places.longitude - ?) / 2), 2)
+)))`, utils.EarthRadiusKm)
+
+// ErrInvalidNearbyParams is wrapped by the errors GetNearbyPlaces returns for bad input
+var ErrInvalidNearbyParams = errors.New("invalid nearby search")
+
+type placeDistance struct {
+	ID         uuid.UUID
+	DistanceKm float64
+}
+
+// withinBoundingBox keeps places inside box. It matches the expression of the GiST index
+// idx_places_location, so the prefilter is an index scan on both coordinates.
+func withinBoundingBox(box utils.BoundingBox) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("point(places.longitude, places.latitude) <@ box(point(?, ?), point(?, ?))",
+			box.MinLng, box.MinLat, box.MaxLng, box.MaxLat)
+	}
+}
+
+// GetNearbyPlaces returns active places within radiusKm of the given point, closest first.
+// With OpenAt only places open at that time are returned, before the limit is applied.
+func GetNearbyPlaces(params dto.NearbyPlacesParams) ([]dto.PlaceListResponse, error) {
+	if math.IsNaN(params.RadiusKm) || math.IsInf(params.RadiusKm, 0) {
+		return nil, fmt.Errorf("%w: radius_km must be a finite number", ErrInvalidNearbyParams)
+	}
+	params.SetDefaults()
+
+	if !utils.ValidCoordinates(params.Latitude, params.Longitude) {
+		return nil, fmt.Errorf("%w: coordinates are out of range", ErrInvalidNearbyParams)
+	}
+
+	// Cheap bounding box prefilter, exact distance computed after
+	box := utils.BoundingBoxAround(params.Latitude, params.Longitude, params.RadiusKm)
+
+	candidates := config.DB.Model(&domain.Place{}).
+		Select("places.id, "+haversineSQL+" AS distance_km", params.Latitude, params.Latitude, params.Longitude).
+		Where("places.is_active = ?", true).
+		Scopes(publishedPlacesOnly, withinBoundingBox(box))
+
+	if params.OpenAt != nil {
+		candidates = candidates.Scopes(placesOpenAt(*params.OpenAt))
+	}
+
+	if params.CategoryID != nil {
+		candidates = candidates.
+			Joins("JOIN place_categories ON place_categories.place_id = places.id").
+			Where("place_categories.category_id = ? AND place_categories.deleted_at IS NULL", *params.CategoryID)
+	}
+
+	var distances []placeDistance
+	err := config.DB.Table("(?) AS nearby", candidates).
+		Select("nearby.id, nearby.distance_km").
+		Where("nearby.distance_km <= ?", params.RadiusKm).
+		Order("nearby.distance_km ASC").
+		Limit(params.Limit).
+		Scan(&distances).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to search nearby places: %w", err)
+	}
+
+	if len(distances) == 0 {
+		return []dto.PlaceListResponse{}, nil
+	}
+
+	placeIDs := make([]uuid.UUID, len(distances))
+	for i, d := range distances {
+		placeIDs[i] = d.ID
+	}
+
+	placesByID, err := loadListPlacesByIDs(placeIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load nearby places: %w", err)
+	}
+
+	// Keep the distance ordering from the first query
+	response := make([]dto.PlaceListResponse, 0, len(distances))
+	for _, d := range distances {
+		place, ok := placesByID[d.ID]
+		if !ok {
+			continue
+		}
+		item := mapPlaceToListResponse(place)
+		distance := d.DistanceKm
+		item.DistanceKm = &distance
+		if params.OpenAt != nil {
+			isOpen := true
+			item.IsOpen = &isOpen
+		}
+		response = append(response, item)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,119 @@
+// services/notification_service.go
+package services
+
+import (
+	"almlah/config"
+	"almlah/internals/domain"
+	"almlah/internals/dto"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// createNotification stores an in-app notice for a user as part of the given transaction
+func createNotification(tx *gorm.DB, notification domain.Notification) error {
+	if err := tx.Omit(clause.Associations).Create(&notification).Error; err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+	return nil
+}
+
+// GetUserNotifications lists the user's notifications, newest first, with the number
+// still unread
+func GetUserNotifications(userID uuid.UUID, unreadOnly bool, page, limit int) (*dto.NotificationListResponse, error) {
+	notifications := func() *gorm.DB {
+		query := config.DB.Model(&domain.Notification{}).Where("user_id = ?", userID)
+		if unreadOnly {
+			query = query.Where("read_at IS NULL")
+		}
+		return query
+	}
+
+	var total int64
+	if err := notifications().Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count notifications: %w", err)
+	}
+
+	var unread int64
+	err := config.DB.Model(&domain.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&unread).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+
+	var rows []domain.Notification
+	err = notifications().
+		Order("created_at DESC, id").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load notifications: %w", err)
+	}
+
+	response := make([]dto.NotificationResponse, len(rows))
+	for i, notification := range rows {
+		response[i] = mapNotificationToResponse(notification)
+	}
+
+	return &dto.NotificationListResponse{
+		PaginationResponse: dto.PaginationResponse{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+			Data:       response,
+		},
+		UnreadCount: unread,
+	}, nil
+}
+
+// MarkNotificationRead marks one of the user's notifications as read
+func MarkNotificationRead(userID, notificationID uuid.UUID) error {
+	var notification domain.Notification
+	err := config.DB.Select("id", "read_at").
+		First(&notification, "id = ? AND user_id = ?", notificationID, userID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("notification not found")
+		}
+		return fmt.Errorf("failed to fetch notification: %w", err)
+	}
+	if notification.ReadAt != nil {
+		return nil
+	}
+
+	if err := config.DB.Model(&notification).Update("read_at", time.Now()).Error; err != nil {
+		return fmt.Errorf("failed to mark notification as read: %w", err)
+	}
+	return nil
+}
+
+// MarkAllNotificationsRead marks every unread notification of the user as read
+func MarkAllNotificationsRead(userID uuid.UUID) error {
+	err := config.DB.Model(&domain.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", time.Now()).Error
+	if err != nil {
+		return fmt.Errorf("failed to mark notifications as read: %w", err)
+	}
+	return nil
+}
+
+func mapNotificationToResponse(notification domain.Notification) dto.NotificationResponse {
+	return dto.NotificationResponse{
+		ID:         notification.ID,
+		Type:       notification.Type,
+		EntityType: notification.EntityType,
+		EntityID:   notification.EntityID,
+		PlaceID:    notification.PlaceID,
+		Message:    notification.Message,
+		ReadAt:     notification.ReadAt,
+		CreatedAt:  notification.CreatedAt,
+	}
+}
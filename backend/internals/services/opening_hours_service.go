@@ -0,0 +1,460 @@
+// services/opening_hours_service.go
+package services
+
+import (
+	"almlah/config"
+	"almlah/internals/domain"
+	"almlah/internals/dto"
+	"almlah/internals/utils"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	minutesPerDay = 24 * 60
+	dateLayout    = "2006-01-02"
+)
+
+var weekdayNamesAr = [7]string{"الأحد", "الإثنين", "الثلاثاء", "الأربعاء", "الخميس", "الجمعة", "السبت"}
+
+// openingInterval is an interval in minutes since local midnight. closes may exceed
+// minutesPerDay for intervals that run past midnight.
+type openingInterval struct {
+	opens  int
+	closes int
+}
+
+// parseClock parses "HH:MM" into minutes since midnight. "24:00" is accepted as end of day.
+// Hours must be zero-padded, stored times are compared as strings.
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err == nil && len(value) == len("15:04") {
+		return t.Hour()*60 + t.Minute(), nil
+	}
+	if value == "24:00" {
+		return minutesPerDay, nil
+	}
+	return 0, fmt.Errorf("invalid time %q, expected HH:MM", value)
+}
+
+// parseInterval validates an opening interval and normalizes overnight intervals
+func parseInterval(opensAt, closesAt string) (openingInterval, error) {
+	opens, err := parseClock(opensAt)
+	if err != nil {
+		return openingInterval{}, err
+	}
+	closes, err := parseClock(closesAt)
+	if err != nil {
+		return openingInterval{}, err
+	}
+	if opens == minutesPerDay {
+		return openingInterval{}, fmt.Errorf("opening time cannot be 24:00")
+	}
+	if opens == closes {
+		return openingInterval{}, fmt.Errorf("interval %s-%s is empty, use 00:00-24:00 for all day", opensAt, closesAt)
+	}
+	if closes < opens {
+		closes += minutesPerDay // Overnight, closes the next day
+	}
+	return openingInterval{opens: opens, closes: closes}, nil
+}
+
+// intervalsForDate returns the intervals that start on the given local date, preferring
+// exceptions over the weekly schedule
+func intervalsForDate(weekly []domain.PlaceOpeningHours, exceptions []domain.PlaceHoursException, date time.Time) []openingInterval {
+	day := date.Format(dateLayout)
+
+	var intervals []openingInterval
+	for _, exception := range exceptions {
+		if !exception.CoversDate(day) {
+			continue
+		}
+		if exception.IsClosed {
+			return nil
+		}
+		for _, i := range exception.Intervals {
+			if parsed, err := parseInterval(i.OpensAt, i.ClosesAt); err == nil {
+				intervals = append(intervals, parsed)
+			}
+		}
+		return intervals
+	}
+
+	for _, hours := range weekly {
+		if hours.Weekday != int(date.Weekday()) {
+			continue
+		}
+		if parsed, err := parseInterval(hours.OpensAt, hours.ClosesAt); err == nil {
+			intervals = append(intervals, parsed)
+		}
+	}
+	return intervals
+}
+
+// isOpenAt evaluates a schedule at the given instant in Asia/Muscat time, including
+// overnight intervals that started the previous day
+func isOpenAt(weekly []domain.PlaceOpeningHours, exceptions []domain.PlaceHoursException, at time.Time) bool {
+	local := at.In(utils.MuscatLocation())
+	minute := local.Hour()*60 + local.Minute()
+
+	for _, interval := range intervalsForDate(weekly, exceptions, local) {
+		if minute >= interval.opens && minute < interval.closes {
+			return true
+		}
+	}
+
+	yesterday := local.AddDate(0, 0, -1)
+	for _, interval := range intervalsForDate(weekly, exceptions, yesterday) {
+		if interval.closes > minutesPerDay && minute < interval.closes-minutesPerDay {
+			return true
+		}
+	}
+
+	return false
+}
+
+// openOnDaySQL is true when an interval of the place on the @date day matches the interval
+// condition. Exceptions covering the day replace the weekly schedule, a closed one wins.
+func openOnDaySQL(date, weekday, interval string) string {
+	return fmt.Sprintf(`CASE WHEN EXISTS (SELECT 1 FROM place_hours_exceptions e
+			WHERE e.place_id = places.id AND e.start_date <= @%[1]s AND e.end_date >= @%[1]s)
+		THEN NOT EXISTS (SELECT 1 FROM place_hours_exceptions e
+				WHERE e.place_id = places.id AND e.start_date <= @%[1]s AND e.end_date >= @%[1]s AND e.is_closed)
+			AND EXISTS (SELECT 1 FROM place_hours_exceptions e
+				JOIN place_hours_exception_intervals i ON i.exception_id = e.id
+				WHERE e.place_id = places.id AND e.start_date <= @%[1]s AND e.end_date >= @%[1]s AND %[3]s)
+		ELSE EXISTS (SELECT 1 FROM place_opening_hours i
+			WHERE i.place_id = places.id AND i.weekday = @%[2]s AND %[3]s)
+		END`, date, weekday, interval)
+}
+
+// placesOpenAt keeps the places open at the given time, the SQL form of isOpenAt. "HH:MM"
+// times compare as strings, an interval closing before it opens runs overnight.
+func placesOpenAt(at time.Time) func(*gorm.DB) *gorm.DB {
+	local := at.In(utils.MuscatLocation())
+	yesterday := local.AddDate(0, 0, -1)
+
+	condition := "((" + openOnDaySQL("open_today", "open_weekday",
+		"i.opens_at <= @open_clock AND (i.closes_at > @open_clock OR i.closes_at < i.opens_at)") +
+		") OR (" + openOnDaySQL("open_yesterday", "open_yesterday_weekday",
+		"i.closes_at < i.opens_at AND i.closes_at > @open_clock") + "))"
+
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(condition, map[string]interface{}{
+			"open_today":             local.Format(dateLayout),
+			"open_weekday":           int(local.Weekday()),
+			"open_yesterday":         yesterday.Format(dateLayout),
+			"open_yesterday_weekday": int(yesterday.Weekday()),
+			"open_clock":             local.Format("15:04"),
+		})
+	}
+}
+
+// GetPlaceOpeningHours returns the weekly schedule, current/upcoming exceptions and the
+// open status at the given time
+func GetPlaceOpeningHours(placeID uuid.UUID, at time.Time) (*dto.OpeningHoursResponse, error) {
+	var weekly []domain.PlaceOpeningHours
+	if err := config.DB.Where("place_id = ?", placeID).Order("weekday, opens_at").Find(&weekly).Error; err != nil {
+		return nil, fmt.Errorf("failed to load opening hours: %w", err)
+	}
+
+	// Yesterday is included so overnight exception intervals are evaluated correctly
+	from := at.In(utils.MuscatLocation()).AddDate(0, 0, -1).Format(dateLayout)
+	var exceptions []domain.PlaceHoursException
+	err := config.DB.Preload("Intervals").
+		Where("place_id = ? AND end_date >= ?", placeID, from).
+		Order("start_date").
+		Find(&exceptions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load opening hours exceptions: %w", err)
+	}
+
+	response := &dto.OpeningHoursResponse{
+		Timezone:    utils.MuscatLocation().String(),
+		HasSchedule: len(weekly) > 0,
+		Weekly:      make([]dto.DailyHoursResponse, 7),
+		Exceptions:  []dto.HoursExceptionResponse{},
+	}
+
+	for day := 0; day < 7; day++ {
+		response.Weekly[day] = dto.DailyHoursResponse{
+			Weekday:   day,
+			DayNameAr: weekdayNamesAr[day],
+			DayNameEn: time.Weekday(day).String(),
+			Intervals: []dto.OpeningIntervalResponse{},
+		}
+	}
+	for _, hours := range weekly {
+		response.Weekly[hours.Weekday].Intervals = append(response.Weekly[hours.Weekday].Intervals, dto.OpeningIntervalResponse{
+			OpensAt:  hours.OpensAt,
+			ClosesAt: hours.ClosesAt,
+		})
+	}
+
+	// Without a weekly schedule the status is only known on days covered by an exception
+	statusKnown := response.HasSchedule
+	today := at.In(utils.MuscatLocation()).Format(dateLayout)
+	for _, exception := range exceptions {
+		if exception.EndDate.Format(dateLayout) < today {
+			continue
+		}
+		if exception.CoversDate(today) {
+			statusKnown = true
+		}
+		response.Exceptions = append(response.Exceptions, mapHoursExceptionToResponse(exception))
+	}
+
+	if statusKnown {
+		open := isOpenAt(weekly, exceptions, at)
+		response.IsOpenNow = &open
+	}
+
+	return response, nil
+}
+
+// FilterPlacesOpenAt keeps the places that are open at the given time and marks them as open,
+// using the same SQL condition as the paginated listings. Places without any schedule are
+// excluded since their status is unknown.
+func FilterPlacesOpenAt(places []dto.PlaceListResponse, at time.Time) ([]dto.PlaceListResponse, error) {
+	if len(places) == 0 {
+		return places, nil
+	}
+
+	placeIDs := make([]uuid.UUID, len(places))
+	for i, place := range places {
+		placeIDs[i] = place.ID
+	}
+
+	var openIDs []uuid.UUID
+	err := config.DB.Model(&domain.Place{}).
+		Where("places.id IN ?", placeIDs).
+		Scopes(placesOpenAt(at)).
+		Pluck("places.id", &openIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to check opening hours: %w", err)
+	}
+
+	isOpenByID := make(map[uuid.UUID]bool, len(openIDs))
+	for _, id := range openIDs {
+		isOpenByID[id] = true
+	}
+
+	open := make([]dto.PlaceListResponse, 0, len(openIDs))
+	for _, place := range places {
+		if isOpenByID[place.ID] {
+			isOpen := true
+			place.IsOpen = &isOpen
+			open = append(open, place)
+		}
+	}
+
+	return open, nil
+}
+
+// UpdatePlaceOpeningHours replaces the weekly schedule of a place
+func UpdatePlaceOpeningHours(placeID uuid.UUID, req dto.UpdateOpeningHoursRequest, userID uuid.UUID) (*dto.OpeningHoursResponse, error) {
+	canModify, err := canUserModifyPlace(placeID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canModify {
+		return nil, errors.New("unauthorized: you don't have permission to modify this place")
+	}
+
+	var hours []domain.PlaceOpeningHours
+	for _, w := range req.Weekly {
+		if _, err := parseInterval(w.OpensAt, w.ClosesAt); err != nil {
+			return nil, fmt.Errorf("%s: %w", time.Weekday(w.Weekday), err)
+		}
+		hours = append(hours, domain.PlaceOpeningHours{
+			PlaceID:  placeID,
+			Weekday:  w.Weekday,
+			OpensAt:  w.OpensAt,
+			ClosesAt: w.ClosesAt,
+		})
+	}
+	if err := checkOverlappingWeeklyHours(hours); err != nil {
+		return nil, err
+	}
+
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("place_id = ?", placeID).Delete(&domain.PlaceOpeningHours{}).Error; err != nil {
+			return fmt.Errorf("failed to clear opening hours: %w", err)
+		}
+		if len(hours) > 0 {
+			if err := tx.Omit("Place").Create(&hours).Error; err != nil {
+				return fmt.Errorf("failed to save opening hours: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return GetPlaceOpeningHours(placeID, time.Now())
+}
+
+// CreatePlaceHoursException adds a dated exception (holiday, Ramadan hours...) to a place
+func CreatePlaceHoursException(placeID uuid.UUID, req dto.HoursExceptionRequest, userID uuid.UUID) (*dto.HoursExceptionResponse, error) {
+	canModify, err := canUserModifyPlace(placeID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canModify {
+		return nil, errors.New("unauthorized: you don't have permission to modify this place")
+	}
+
+	exception := domain.PlaceHoursException{PlaceID: placeID}
+	if err := applyHoursExceptionRequest(&exception, req); err != nil {
+		return nil, err
+	}
+
+	if err := config.DB.Omit("Place").Create(&exception).Error; err != nil {
+		return nil, fmt.Errorf("failed to create opening hours exception: %w", err)
+	}
+
+	response := mapHoursExceptionToResponse(exception)
+	return &response, nil
+}
+
+// UpdatePlaceHoursException replaces an exception's dates and intervals
+func UpdatePlaceHoursException(placeID, exceptionID uuid.UUID, req dto.HoursExceptionRequest, userID uuid.UUID) (*dto.HoursExceptionResponse, error) {
+	canModify, err := canUserModifyPlace(placeID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canModify {
+		return nil, errors.New("unauthorized: you don't have permission to modify this place")
+	}
+
+	var exception domain.PlaceHoursException
+	if err := config.DB.Where("id = ? AND place_id = ?", exceptionID, placeID).First(&exception).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("opening hours exception not found")
+		}
+		return nil, err
+	}
+
+	if err := applyHoursExceptionRequest(&exception, req); err != nil {
+		return nil, err
+	}
+
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("exception_id = ?", exception.ID).Delete(&domain.PlaceHoursExceptionInterval{}).Error; err != nil {
+			return err
+		}
+		return tx.Omit("Place").Session(&gorm.Session{FullSaveAssociations: true}).Save(&exception).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update opening hours exception: %w", err)
+	}
+
+	response := mapHoursExceptionToResponse(exception)
+	return &response, nil
+}
+
+// DeletePlaceHoursException removes an exception and its intervals
+func DeletePlaceHoursException(placeID, exceptionID uuid.UUID, userID uuid.UUID) error {
+	canModify, err := canUserModifyPlace(placeID, userID)
+	if err != nil {
+		return err
+	}
+	if !canModify {
+		return errors.New("unauthorized: you don't have permission to modify this place")
+	}
+
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND place_id = ?", exceptionID, placeID).Delete(&domain.PlaceHoursException{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("opening hours exception not found")
+		}
+		return tx.Where("exception_id = ?", exceptionID).Delete(&domain.PlaceHoursExceptionInterval{}).Error
+	})
+}
+
+func applyHoursExceptionRequest(exception *domain.PlaceHoursException, req dto.HoursExceptionRequest) error {
+	startDate, err := time.Parse(dateLayout, req.StartDate)
+	if err != nil {
+		return fmt.Errorf("invalid start_date, expected YYYY-MM-DD")
+	}
+	endDate, err := time.Parse(dateLayout, req.EndDate)
+	if err != nil {
+		return fmt.Errorf("invalid end_date, expected YYYY-MM-DD")
+	}
+	if endDate.Before(startDate) {
+		return errors.New("end_date must not be before start_date")
+	}
+	if !req.IsClosed && len(req.Intervals) == 0 {
+		return errors.New("an exception must either be closed or have opening intervals")
+	}
+
+	var intervals []domain.PlaceHoursExceptionInterval
+	if !req.IsClosed {
+		for _, i := range req.Intervals {
+			if _, err := parseInterval(i.OpensAt, i.ClosesAt); err != nil {
+				return err
+			}
+			intervals = append(intervals, domain.PlaceHoursExceptionInterval{
+				ExceptionID: exception.ID,
+				OpensAt:     i.OpensAt,
+				ClosesAt:    i.ClosesAt,
+			})
+		}
+	}
+
+	exception.NameAr = req.NameAr
+	exception.NameEn = req.NameEn
+	exception.StartDate = startDate
+	exception.EndDate = endDate
+	exception.IsClosed = req.IsClosed
+	exception.Intervals = intervals
+	return nil
+}
+
+// checkOverlappingWeeklyHours rejects schedules where two intervals on the same day overlap
+func checkOverlappingWeeklyHours(hours []domain.PlaceOpeningHours) error {
+	byDay := make(map[int][]openingInterval)
+	for _, h := range hours {
+		interval, _ := parseInterval(h.OpensAt, h.ClosesAt)
+		byDay[h.Weekday] = append(byDay[h.Weekday], interval)
+	}
+
+	for day, intervals := range byDay {
+		sort.Slice(intervals, func(i, j int) bool { return intervals[i].opens < intervals[j].opens })
+		for i := 1; i < len(intervals); i++ {
+			if intervals[i].opens < intervals[i-1].closes {
+				return fmt.Errorf("%s has overlapping opening intervals", time.Weekday(day))
+			}
+		}
+	}
+	return nil
+}
+
+func mapHoursExceptionToResponse(exception domain.PlaceHoursException) dto.HoursExceptionResponse {
+	intervals := []dto.OpeningIntervalResponse{}
+	for _, i := range exception.Intervals {
+		intervals = append(intervals, dto.OpeningIntervalResponse{
+			OpensAt:  i.OpensAt,
+			ClosesAt: i.ClosesAt,
+		})
+	}
+
+	return dto.HoursExceptionResponse{
+		ID:        exception.ID,
+		NameAr:    exception.NameAr,
+		NameEn:    exception.NameEn,
+		StartDate: exception.StartDate.Format(dateLayout),
+		EndDate:   exception.EndDate.Format(dateLayout),
+		IsClosed:  exception.IsClosed,
+		Intervals: intervals,
+	}
+}
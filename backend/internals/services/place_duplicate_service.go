@@ -0,0 +1,412 @@
+// services/place_duplicate_service.go
+package services
+
+import (
+	"almlah/config"
+	"almlah/internals/domain"
+	"almlah/internals/dto"
+	"almlah/internals/utils"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Duplicate detection: places within duplicateRadiusKm whose names are similar enough are
+// candidates. Strength combines name similarity (70%) and closeness (30%).
+const (
+	duplicateRadiusKm        = 1.0
+	duplicateMinNameScore    = 0.5
+	duplicateWarnThreshold   = 0.6
+	duplicateRejectThreshold = 0.85
+	duplicateMaxCandidates   = 5
+)
+
+// DuplicatePlaceError is returned by CreatePlace when a very likely duplicate already exists
+type DuplicatePlaceError struct {
+	Candidates []dto.DuplicateCandidate
+}
+
+func (e *DuplicatePlaceError) Error() string {
+	names := make([]string, 0, len(e.Candidates))
+	for _, candidate := range e.Candidates {
+		names = append(names, candidate.NameEn)
+	}
+	return fmt.Sprintf("possible duplicate of existing place: %s", strings.Join(names, ", "))
+}
+
+// FindDuplicatePlaces returns existing places near the coordinates with a similar Arabic or
+// English name, strongest match first
+func FindDuplicatePlaces(nameAr, nameEn string, lat, lng float64, excludeID *uuid.UUID) ([]dto.DuplicateCandidate, error) {
+	candidates := []dto.DuplicateCandidate{}
+	// Places without coordinates can't be compared by distance
+	if (lat == 0 && lng == 0) || !utils.ValidCoordinates(lat, lng) {
+		return candidates, nil
+	}
+
+	box := utils.BoundingBoxAround(lat, lng, duplicateRadiusKm)
+	query := config.DB.Select("id", "name_ar", "name_en", "slug", "status", "latitude", "longitude").
+		Scopes(withinBoundingBox(box))
+	if excludeID != nil {
+		query = query.Where("id <> ?", *excludeID)
+	}
+
+	var nearby []domain.Place
+	if err := query.Find(&nearby).Error; err != nil {
+		return nil, fmt.Errorf("failed to look for duplicate places: %w", err)
+	}
+
+	for _, place := range nearby {
+		if candidate, ok := matchDuplicatePlace(nameAr, nameEn, lat, lng, place); ok {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Strength > candidates[j].Strength })
+	if len(candidates) > duplicateMaxCandidates {
+		candidates = candidates[:duplicateMaxCandidates]
+	}
+	return candidates, nil
+}
+
+// matchDuplicatePlace scores a place against the names and coordinates of a new place and
+// reports whether it is close and similar enough to be a duplicate candidate
+func matchDuplicatePlace(nameAr, nameEn string, lat, lng float64, place domain.Place) (dto.DuplicateCandidate, bool) {
+	distance := utils.HaversineKm(lat, lng, place.Latitude, place.Longitude)
+	if distance > duplicateRadiusKm {
+		return dto.DuplicateCandidate{}, false
+	}
+
+	nameScore := utils.NameSimilarity(nameEn, place.NameEn)
+	if score := utils.NameSimilarity(nameAr, place.NameAr); score > nameScore {
+		nameScore = score
+	}
+	if nameScore < duplicateMinNameScore {
+		return dto.DuplicateCandidate{}, false
+	}
+
+	strength := 0.7*nameScore + 0.3*(1-distance/duplicateRadiusKm)
+	if strength < duplicateWarnThreshold {
+		return dto.DuplicateCandidate{}, false
+	}
+
+	return dto.DuplicateCandidate{
+		PlaceID:        place.ID,
+		NameAr:         place.NameAr,
+		NameEn:         place.NameEn,
+		Slug:           place.Slug,
+		Status:         place.Status,
+		DistanceKm:     distance,
+		NameSimilarity: nameScore,
+		Strength:       strength,
+		Blocking:       strength >= duplicateRejectThreshold,
+	}, true
+}
+
+// checkDuplicatesBeforeCreate rejects a new place when a blocking duplicate exists, unless a
+// place manager explicitly asked to ignore duplicates. Weaker candidates are returned as warnings.
+func checkDuplicatesBeforeCreate(req dto.CreatePlaceRequest, userID uuid.UUID) ([]dto.DuplicateCandidate, error) {
+	candidates, err := FindDuplicatePlaces(req.NameAr, req.NameEn, req.Latitude, req.Longitude, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 || !candidates[0].Blocking {
+		return candidates, nil
+	}
+
+	if req.IgnoreDuplicates {
+		permission, err := CheckUserPermission(userID, "can_manage_place")
+		if err != nil {
+			return nil, err
+		}
+		if permission.HasPermission {
+			return candidates, nil
+		}
+	}
+
+	var blocking []dto.DuplicateCandidate
+	for _, candidate := range candidates {
+		if candidate.Blocking {
+			blocking = append(blocking, candidate)
+		}
+	}
+	return nil, &DuplicatePlaceError{Candidates: blocking}
+}
+
+// MergePlaces moves everything attached to the duplicate onto the surviving place, then
+// soft-deletes the duplicate. Its ID and slugs keep resolving to the survivor.
+func MergePlaces(survivorID, duplicateID uuid.UUID, userID uuid.UUID) (*dto.PlaceMergeResponse, error) {
+	if survivorID == duplicateID {
+		return nil, errors.New("a place cannot be merged into itself")
+	}
+
+	response := &dto.PlaceMergeResponse{MergedPlaceID: duplicateID}
+	var duplicate domain.Place
+	var droppedImageURLs []string
+
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		// Lock both places in a fixed order so concurrent merges can't deadlock
+		var places []domain.Place
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ?", []uuid.UUID{survivorID, duplicateID}).
+			Order("id").
+			Find(&places).Error
+		if err != nil {
+			return err
+		}
+		var survivorFound bool
+		for _, place := range places {
+			if place.ID == survivorID {
+				survivorFound = true
+			} else {
+				duplicate = place
+			}
+		}
+		if !survivorFound {
+			return errors.New("place not found")
+		}
+		if duplicate.ID == uuid.Nil {
+			return errors.New("duplicate place not found")
+		}
+
+		if err := ensureBaselineRevision(tx, survivorID); err != nil {
+			return fmt.Errorf("failed to record baseline revision: %w", err)
+		}
+
+		// Images: the survivor keeps its primary image if it has one
+		var primaryImages int64
+		if err := tx.Model(&domain.PlaceImage{}).Where("place_id = ? AND is_primary = ?", survivorID, true).Count(&primaryImages).Error; err != nil {
+			return err
+		}
+		if primaryImages > 0 {
+			if err := tx.Model(&domain.PlaceImage{}).Where("place_id = ?", duplicateID).Update("is_primary", false).Error; err != nil {
+				return fmt.Errorf("failed to move images: %w", err)
+			}
+		}
+		result := tx.Model(&domain.PlaceImage{}).Where("place_id = ?", duplicateID).Update("place_id", survivorID)
+		if result.Error != nil {
+			return fmt.Errorf("failed to move images: %w", result.Error)
+		}
+		response.ImagesMoved = result.RowsAffected
+
+		// A user keeps one review per place; where they reviewed both, the survivor's review stays
+		var droppedReviews []uuid.UUID
+		err = tx.Model(&domain.Review{}).
+			Where("place_id = ? AND user_id IN (?)", duplicateID,
+				tx.Model(&domain.Review{}).Select("user_id").Where("place_id = ?", survivorID)).
+			Pluck("id", &droppedReviews).Error
+		if err != nil {
+			return fmt.Errorf("failed to move reviews: %w", err)
+		}
+		if len(droppedReviews) > 0 {
+			if err := tx.Where("id IN ?", droppedReviews).Delete(&domain.Review{}).Error; err != nil {
+				return fmt.Errorf("failed to move reviews: %w", err)
+			}
+			if droppedImageURLs, err = deleteReviewImageRows(tx, droppedReviews); err != nil {
+				return err
+			}
+		}
+		result = tx.Model(&domain.Review{}).Where("place_id = ?", duplicateID).Update("place_id", survivorID)
+		if result.Error != nil {
+			return fmt.Errorf("failed to move reviews: %w", result.Error)
+		}
+		response.ReviewsMoved = result.RowsAffected
+		if err := RefreshPlaceRatingStats(tx, survivorID); err != nil {
+			return err
+		}
+		if err := RefreshPlaceRatingStats(tx, duplicateID); err != nil {
+			return err
+		}
+
+		// Users who favorited both places keep a single favorite, which takes over the
+		// collections the duplicate's favorite was in
+		if err := tx.Exec(`INSERT INTO favorite_collection_places (collection_id, favorite_id, added_at)
+			SELECT fcp.collection_id, kept.id, fcp.added_at
+			FROM favorite_collection_places fcp
+			JOIN user_favorites dropped ON dropped.id = fcp.favorite_id AND dropped.place_id = ?
+			JOIN user_favorites kept ON kept.user_id = dropped.user_id AND kept.place_id = ?
+			ON CONFLICT DO NOTHING`, duplicateID, survivorID).Error; err != nil {
+			return fmt.Errorf("failed to move favorite collections: %w", err)
+		}
+		err = tx.Where("place_id = ? AND user_id IN (?)", duplicateID,
+			tx.Model(&domain.UserFavorite{}).Select("user_id").Where("place_id = ?", survivorID)).
+			Delete(&domain.UserFavorite{}).Error
+		if err != nil {
+			return fmt.Errorf("failed to move favorites: %w", err)
+		}
+		result = tx.Model(&domain.UserFavorite{}).Where("place_id = ?", duplicateID).Update("place_id", survivorID)
+		if result.Error != nil {
+			return fmt.Errorf("failed to move favorites: %w", result.Error)
+		}
+		response.FavoritesMoved = result.RowsAffected
+
+		result = tx.Exec(`INSERT INTO place_properties (place_id, property_id, added_at)
+			SELECT ?, property_id, added_at FROM place_properties WHERE place_id = ?
+			ON CONFLICT DO NOTHING`, survivorID, duplicateID)
+		if result.Error != nil {
+			return fmt.Errorf("failed to merge properties: %w", result.Error)
+		}
+		response.PropertiesAdded = result.RowsAffected
+		if err := tx.Where("place_id = ?", duplicateID).Delete(&domain.PlaceProperty{}).Error; err != nil {
+			return fmt.Errorf("failed to merge properties: %w", err)
+		}
+
+		result = tx.Exec(`INSERT INTO place_categories (place_id, category_id, created_at, updated_at)
+			SELECT ?, category_id, NOW(), NOW() FROM place_categories WHERE place_id = ? AND deleted_at IS NULL
+			ON CONFLICT (place_id, category_id) DO UPDATE SET deleted_at = NULL, updated_at = NOW()
+			WHERE place_categories.deleted_at IS NOT NULL`, survivorID, duplicateID)
+		if result.Error != nil {
+			return fmt.Errorf("failed to merge categories: %w", result.Error)
+		}
+		response.CategoriesAdded = result.RowsAffected
+		if err := tx.Exec("DELETE FROM place_categories WHERE place_id = ?", duplicateID).Error; err != nil {
+			return fmt.Errorf("failed to merge categories: %w", err)
+		}
+
+		// Content sections of the duplicate go after the survivor's own sections
+		var maxSortOrder int
+		err = tx.Model(&domain.PlaceContentSection{}).
+			Where("place_id = ?", survivorID).
+			Select("COALESCE(MAX(sort_order), 0)").
+			Scan(&maxSortOrder).Error
+		if err != nil {
+			return err
+		}
+		result = tx.Model(&domain.PlaceContentSection{}).Where("place_id = ?", duplicateID).Updates(map[string]interface{}{
+			"place_id":   survivorID,
+			"sort_order": gorm.Expr("sort_order + ?", maxSortOrder),
+		})
+		if result.Error != nil {
+			return fmt.Errorf("failed to move content sections: %w", result.Error)
+		}
+		response.ContentSectionsMoved = result.RowsAffected
+
+		if err := tx.Exec(`INSERT INTO advice_places (advice_id, place_id)
+			SELECT advice_id, ? FROM advice_places WHERE place_id = ?
+			ON CONFLICT DO NOTHING`, survivorID, duplicateID).Error; err != nil {
+			return fmt.Errorf("failed to move advice links: %w", err)
+		}
+		if err := tx.Exec("DELETE FROM advice_places WHERE place_id = ?", duplicateID).Error; err != nil {
+			return fmt.Errorf("failed to move advice links: %w", err)
+		}
+
+		// The survivor keeps its own dish notes when both places list the same dish
+		if err := tx.Exec(`INSERT INTO dish_places (dish_id, place_id, note_ar, note_en, price_min, price_max, is_signature, created_by, created_at, updated_at)
+			SELECT dish_id, ?, note_ar, note_en, price_min, price_max, is_signature, created_by, created_at, updated_at
+			FROM dish_places WHERE place_id = ?
+			ON CONFLICT DO NOTHING`, survivorID, duplicateID).Error; err != nil {
+			return fmt.Errorf("failed to move dish links: %w", err)
+		}
+		if err := tx.Exec("DELETE FROM dish_places WHERE place_id = ?", duplicateID).Error; err != nil {
+			return fmt.Errorf("failed to move dish links: %w", err)
+		}
+
+		result = tx.Model(&domain.ListItem{}).Where("place_id = ?", duplicateID).Update("place_id", survivorID)
+		if result.Error != nil {
+			return fmt.Errorf("failed to move list items: %w", result.Error)
+		}
+		response.ListItemsMoved = result.RowsAffected
+
+		result = tx.Model(&domain.ItineraryStop{}).Where("place_id = ?", duplicateID).Update("place_id", survivorID)
+		if result.Error != nil {
+			return fmt.Errorf("failed to move itinerary stops: %w", result.Error)
+		}
+		response.ItineraryStopsMoved = result.RowsAffected
+
+		// Grants the user already holds on the survivor are dropped
+		err = tx.Where("place_id = ? AND (user_id, permission_id) IN (?)", duplicateID,
+			tx.Model(&domain.PlacePermission{}).Select("user_id", "permission_id").Where("place_id = ?", survivorID)).
+			Delete(&domain.PlacePermission{}).Error
+		if err != nil {
+			return fmt.Errorf("failed to move place permissions: %w", err)
+		}
+		result = tx.Model(&domain.PlacePermission{}).Where("place_id = ?", duplicateID).Update("place_id", survivorID)
+		if result.Error != nil {
+			return fmt.Errorf("failed to move place permissions: %w", result.Error)
+		}
+		response.PermissionsMoved = result.RowsAffected
+
+		// Old slugs of the duplicate now lead to the survivor
+		for language, slug := range map[string]string{"en": duplicate.Slug, "ar": duplicate.SlugAr} {
+			if slug == "" {
+				continue
+			}
+			history := domain.PlaceSlugHistory{PlaceID: survivorID, Slug: slug, Language: language}
+			if err := tx.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(&history).Error; err != nil {
+				return fmt.Errorf("failed to keep duplicate slugs: %w", err)
+			}
+		}
+		if err := tx.Model(&domain.PlaceSlugHistory{}).Where("place_id = ?", duplicateID).Update("place_id", survivorID).Error; err != nil {
+			return fmt.Errorf("failed to keep duplicate slugs: %w", err)
+		}
+
+		// Places merged into the duplicate earlier now point straight at the survivor
+		if err := tx.Unscoped().Model(&domain.Place{}).Where("merged_into_id = ?", duplicateID).Update("merged_into_id", survivorID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("place_id = ?", duplicateID).Delete(&domain.PlaceSearchDocument{}).Error; err != nil {
+			return err
+		}
+
+		err = tx.Model(&duplicate).Updates(map[string]interface{}{
+			"slug":           "",
+			"slug_ar":        "",
+			"is_active":      false,
+			"merged_into_id": survivorID,
+		}).Error
+		if err != nil {
+			return fmt.Errorf("failed to mark duplicate as merged: %w", err)
+		}
+		if err := tx.Delete(&duplicate).Error; err != nil {
+			return err
+		}
+
+		summary := fmt.Sprintf("Merged duplicate place %s (%s)", duplicate.NameEn, duplicateID)
+		return recordPlaceRevision(tx, survivorID, userID, domain.RevisionChangeMerge, summary)
+	})
+	if err != nil {
+		return nil, err
+	}
+	go deleteReviewImageFiles(droppedImageURLs)
+
+	if err := RefreshPlaceSearchDocument(survivorID); err != nil {
+		fmt.Printf("⚠️ Failed to index place %s for search: %v\n", survivorID, err)
+	}
+
+	place, err := GetPlaceByID(survivorID)
+	if err != nil {
+		return nil, err
+	}
+	response.Place = place
+	return response, nil
+}
+
+// GetPlaceRedirect returns the place a merged duplicate was folded into
+func GetPlaceRedirect(placeID uuid.UUID) (*dto.PlaceRedirectResponse, error) {
+	var merged domain.Place
+	err := config.DB.Unscoped().
+		Select("id", "merged_into_id").
+		Where("id = ? AND merged_into_id IS NOT NULL", placeID).
+		First(&merged).Error
+	if err != nil {
+		return nil, errors.New("place not found")
+	}
+
+	var survivor domain.Place
+	err = config.DB.Select("id", "slug").
+		Scopes(publishedPlacesOnly).
+		First(&survivor, "id = ?", *merged.MergedIntoID).Error
+	if err != nil {
+		return nil, errors.New("place not found")
+	}
+
+	return &dto.PlaceRedirectResponse{
+		PlaceID: survivor.ID,
+		Slug:    survivor.Slug,
+	}, nil
+}
@@ -0,0 +1,362 @@
+// services/place_export_service.go
+package services
+
+import (
+	"almlah/config"
+	"almlah/internals/domain"
+	"almlah/internals/dto"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Supported export formats
+const (
+	ExportFormatGeoJSON = "geojson"
+	ExportFormatKML     = "kml"
+)
+
+// Places are loaded this many at a time so an export never holds the whole catalog in memory
+const placeExportBatchSize = 200
+
+// PlaceExportScope limits an export to one governate, wilayah or list. The zero value exports
+// every active published place.
+type PlaceExportScope struct {
+	GovernateID *uuid.UUID
+	WilayahID   *uuid.UUID
+	ListID      *uuid.UUID
+}
+
+// PlaceExportTitle checks that the scope exists and returns the name of the exported document.
+// Call it before streaming starts, errors can't change the response status afterwards.
+func PlaceExportTitle(scope PlaceExportScope) (string, error) {
+	switch {
+	case scope.ListID != nil:
+		// Whether the list may be read, e.g. with a preview link, is checked by the caller
+		var list domain.List
+		if err := config.DB.First(&list, "id = ?", *scope.ListID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return "", errors.New("list not found")
+			}
+			return "", err
+		}
+		return list.TitleEn, nil
+
+	case scope.WilayahID != nil:
+		var wilayah domain.Wilayah
+		if err := config.DB.First(&wilayah, "id = ?", *scope.WilayahID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return "", errors.New("wilayah not found")
+			}
+			return "", err
+		}
+		return wilayah.NameEn, nil
+
+	case scope.GovernateID != nil:
+		var governate domain.Governate
+		if err := config.DB.First(&governate, "id = ?", *scope.GovernateID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return "", errors.New("governate not found")
+			}
+			return "", err
+		}
+		return governate.NameEn, nil
+
+	default:
+		return "Almlah places", nil
+	}
+}
+
+// ExportPlaces streams the places in scope to w as a GeoJSON FeatureCollection or a KML document
+func ExportPlaces(w io.Writer, scope PlaceExportScope, format string, title string) error {
+	var writer placeExportWriter
+	switch format {
+	case ExportFormatGeoJSON:
+		writer = &geoJSONExportWriter{w: w}
+	case ExportFormatKML:
+		writer = &kmlExportWriter{w: w, encoder: xml.NewEncoder(w)}
+	default:
+		return fmt.Errorf("unsupported export format: %s", format)
+	}
+
+	if err := writer.begin(title); err != nil {
+		return err
+	}
+
+	frontendURL := strings.TrimSuffix(getEnvWithDefault("FRONTEND_URL", "http://localhost:3000"), "/")
+	next, err := placeExportBatches(scope)
+	if err != nil {
+		return fmt.Errorf("failed to load places: %w", err)
+	}
+	for more := true; more; {
+		var places []domain.Place
+		places, more, err = next()
+		if err != nil {
+			return fmt.Errorf("failed to load places: %w", err)
+		}
+
+		for _, place := range places {
+			if err := writer.write(placeExportFeature(place, frontendURL)); err != nil {
+				return err
+			}
+		}
+		// Send each batch to the client as soon as it is written
+		if flusher, ok := w.(interface{ Flush() error }); ok {
+			if err := flusher.Flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return writer.end()
+}
+
+// placeExportBatches returns a function that loads the next batch of places in scope each
+// time it is called, and whether another batch may follow. Batches continue after the last
+// place loaded instead of using an offset, so each one costs the same however far into the
+// export it is.
+func placeExportBatches(scope PlaceExportScope) (func() ([]domain.Place, bool, error), error) {
+	load := func(query *gorm.DB) ([]domain.Place, error) {
+		var places []domain.Place
+		err := query.Preload("Categories").
+			Preload("Images").
+			Preload("Governate").
+			Preload("Wilayah").
+			Find(&places).Error
+		return places, err
+	}
+
+	if scope.ListID == nil {
+		var last *domain.Place
+		return func() ([]domain.Place, bool, error) {
+			query := placeExportQuery(scope).Order("places.name_en ASC, places.id ASC").Limit(placeExportBatchSize)
+			if last != nil {
+				query = query.Where("(places.name_en, places.id) > (?, ?)", last.NameEn, last.ID)
+			}
+			places, err := load(query)
+			if len(places) > 0 {
+				last = &places[len(places)-1]
+			}
+			return places, len(places) == placeExportBatchSize, err
+		}, nil
+	}
+
+	// List exports keep the list's order. A list is small enough to read the IDs of its
+	// places up front, the places themselves are still loaded a batch at a time.
+	var placeIDs []uuid.UUID
+	err := placeExportQuery(scope).
+		Joins(`JOIN (SELECT place_id, MIN(sort_order) AS sort_order FROM list_items
+			WHERE list_id = ? AND place_id IS NOT NULL AND deleted_at IS NULL
+			GROUP BY place_id) AS export_items ON export_items.place_id = places.id`, *scope.ListID).
+		Order("export_items.sort_order ASC, places.id ASC").
+		Pluck("places.id", &placeIDs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return func() ([]domain.Place, bool, error) {
+		batch := placeIDs
+		if len(batch) > placeExportBatchSize {
+			batch = batch[:placeExportBatchSize]
+		}
+		placeIDs = placeIDs[len(batch):]
+		if len(batch) == 0 {
+			return nil, false, nil
+		}
+
+		places, err := load(placeExportQuery(scope).Where("places.id IN ?", batch))
+		if err != nil {
+			return nil, false, err
+		}
+		position := make(map[uuid.UUID]int, len(batch))
+		for i, id := range batch {
+			position[id] = i
+		}
+		sort.Slice(places, func(i, j int) bool { return position[places[i].ID] < position[places[j].ID] })
+		return places, len(placeIDs) > 0, nil
+	}, nil
+}
+
+// placeExportQuery selects active published places with the same filters as
+// GetPlacesByGovernate and GetPlacesByWilayah
+func placeExportQuery(scope PlaceExportScope) *gorm.DB {
+	query := config.DB.Model(&domain.Place{}).
+		Where("places.is_active = ?", true).
+		Scopes(publishedPlacesOnly)
+
+	if scope.GovernateID != nil {
+		query = query.Where("places.governate_id = ?", *scope.GovernateID)
+	}
+	if scope.WilayahID != nil {
+		query = query.Where("places.wilayah_id = ?", *scope.WilayahID)
+	}
+	return query
+}
+
+func placeExportFeature(place domain.Place, frontendURL string) dto.PlaceExportFeature {
+	properties := dto.PlaceExportProperties{
+		NameAr: place.NameAr,
+		NameEn: place.NameEn,
+		Slug:   place.Slug,
+		SlugAr: place.SlugAr,
+		URL:    fmt.Sprintf("%s/en/places/%s", frontendURL, place.ID),
+		URLAr:  fmt.Sprintf("%s/ar/places/%s", frontendURL, place.ID),
+	}
+	if place.Governate != nil {
+		properties.GovernateAr = place.Governate.NameAr
+		properties.GovernateEn = place.Governate.NameEn
+	}
+	if place.Wilayah != nil {
+		properties.WilayahAr = place.Wilayah.NameAr
+		properties.WilayahEn = place.Wilayah.NameEn
+	}
+
+	// The top level category describes the place best, a subcategory is only used without one
+	for i, category := range place.Categories {
+		if i == 0 || category.ParentID == nil {
+			properties.CategorySlug = category.Slug
+			properties.CategoryAr = category.NameAr
+			properties.CategoryEn = category.NameEn
+		}
+		if category.ParentID == nil {
+			break
+		}
+	}
+
+	// Fall back to the first image when none is marked primary
+	firstOrder := 0
+	for _, image := range place.Images {
+		if image.IsPrimary {
+			properties.ImageURL = image.ImageURL
+			break
+		}
+		if properties.ImageURL == "" || image.DisplayOrder < firstOrder {
+			properties.ImageURL = image.ImageURL
+			firstOrder = image.DisplayOrder
+		}
+	}
+
+	return dto.PlaceExportFeature{
+		Type: "Feature",
+		ID:   place.ID,
+		Geometry: dto.GeoJSONGeometry{
+			Type:        "Point",
+			Coordinates: []float64{place.Longitude, place.Latitude},
+		},
+		Properties: properties,
+	}
+}
+
+type placeExportWriter interface {
+	begin(title string) error
+	write(feature dto.PlaceExportFeature) error
+	end() error
+}
+
+// geoJSONExportWriter writes the FeatureCollection one feature at a time
+type geoJSONExportWriter struct {
+	w       io.Writer
+	written int
+}
+
+func (g *geoJSONExportWriter) begin(title string) error {
+	name, err := json.Marshal(title)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(g.w, `{"type":"FeatureCollection","name":%s,"features":[`, name)
+	return err
+}
+
+func (g *geoJSONExportWriter) write(feature dto.PlaceExportFeature) error {
+	data, err := json.Marshal(feature)
+	if err != nil {
+		return err
+	}
+	if g.written > 0 {
+		if _, err := io.WriteString(g.w, ","); err != nil {
+			return err
+		}
+	}
+	g.written++
+	_, err = g.w.Write(data)
+	return err
+}
+
+func (g *geoJSONExportWriter) end() error {
+	_, err := io.WriteString(g.w, "]}\n")
+	return err
+}
+
+// kmlExportWriter writes a KML document with one Placemark per place
+type kmlExportWriter struct {
+	w       io.Writer
+	encoder *xml.Encoder
+}
+
+type kmlPlacemark struct {
+	XMLName      xml.Name  `xml:"Placemark"`
+	ID           string    `xml:"id,attr"`
+	Name         string    `xml:"name"`
+	Description  string    `xml:"description,omitempty"`
+	ExtendedData []kmlData `xml:"ExtendedData>Data"`
+	Coordinates  string    `xml:"Point>coordinates"`
+}
+
+type kmlData struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value"`
+}
+
+func (k *kmlExportWriter) begin(title string) error {
+	if _, err := io.WriteString(k.w, xml.Header+`<kml xmlns="http://www.opengis.net/kml/2.2"><Document><name>`); err != nil {
+		return err
+	}
+	if err := xml.EscapeText(k.w, []byte(title)); err != nil {
+		return err
+	}
+	_, err := io.WriteString(k.w, "</name>")
+	return err
+}
+
+func (k *kmlExportWriter) write(feature dto.PlaceExportFeature) error {
+	properties := feature.Properties
+	placemark := kmlPlacemark{
+		ID:          "place-" + feature.ID.String(),
+		Name:        properties.NameEn,
+		Description: properties.URL,
+		Coordinates: fmt.Sprintf("%f,%f", feature.Geometry.Coordinates[0], feature.Geometry.Coordinates[1]),
+	}
+	for _, data := range []kmlData{
+		{Name: "name_ar", Value: properties.NameAr},
+		{Name: "name_en", Value: properties.NameEn},
+		{Name: "category", Value: properties.CategoryEn},
+		{Name: "category_ar", Value: properties.CategoryAr},
+		{Name: "governate", Value: properties.GovernateEn},
+		{Name: "wilayah", Value: properties.WilayahEn},
+		{Name: "image_url", Value: properties.ImageURL},
+		{Name: "url", Value: properties.URL},
+		{Name: "url_ar", Value: properties.URLAr},
+	} {
+		if data.Value != "" {
+			placemark.ExtendedData = append(placemark.ExtendedData, data)
+		}
+	}
+
+	if err := k.encoder.Encode(placemark); err != nil {
+		return err
+	}
+	return k.encoder.Flush()
+}
+
+func (k *kmlExportWriter) end() error {
+	_, err := io.WriteString(k.w, "</Document></kml>\n")
+	return err
+}
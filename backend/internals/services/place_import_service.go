@@ -0,0 +1,584 @@
+// services/place_import_service.go
+package services
+
+import (
+	"almlah/config"
+	"almlah/internals/domain"
+	"almlah/internals/dto"
+	"almlah/internals/utils"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Supported import file formats
+const (
+	ImportFormatCSV     = "csv"
+	ImportFormatGeoJSON = "geojson"
+)
+
+// importColumnAliases maps alternative column/property names to the canonical ones
+var importColumnAliases = map[string]string{
+	"ref":            "external_ref",
+	"external_id":    "external_ref",
+	"governate_slug": "governate",
+	"wilayah_slug":   "wilayah",
+	"category":       "categories",
+	"category_slugs": "categories",
+	"lat":            "latitude",
+	"lng":            "longitude",
+	"lon":            "longitude",
+}
+
+// importLookups resolves the slugs used in import files
+type importLookups struct {
+	governates map[string]uuid.UUID
+	wilayahs   map[string]domain.Wilayah
+	categories map[string]uuid.UUID
+}
+
+// importBatch keeps track of the rows of one import already accepted, so rows of the same
+// file are checked against each other even in a dry run, where none of them is saved
+type importBatch struct {
+	refs    map[string]int // Row that uses each external_ref
+	created []dto.PlaceImportRecord
+}
+
+// DetectImportFormat guesses the import format from a file name
+func DetectImportFormat(filename string) (string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return ImportFormatCSV, nil
+	case ".geojson", ".json":
+		return ImportFormatGeoJSON, nil
+	default:
+		return "", fmt.Errorf("unsupported import file %q, expected .csv or .geojson", filename)
+	}
+}
+
+// ParsePlaceImportFile reads the records of a CSV or GeoJSON import file
+func ParsePlaceImportFile(r io.Reader, format string) ([]dto.PlaceImportRecord, error) {
+	switch format {
+	case ImportFormatCSV:
+		return parsePlaceImportCSV(r)
+	case ImportFormatGeoJSON:
+		return parsePlaceImportGeoJSON(r)
+	default:
+		return nil, fmt.Errorf("unsupported import format: %s", format)
+	}
+}
+
+func parsePlaceImportCSV(r io.Reader) ([]dto.PlaceImportRecord, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	columns := make([]string, len(header))
+	for i, name := range header {
+		columns[i] = importColumnName(strings.TrimPrefix(name, "\uFEFF"))
+	}
+	if !containsString(columns, "name_en") || !containsString(columns, "name_ar") {
+		return nil, errors.New("CSV header must include name_ar and name_en columns")
+	}
+
+	var records []dto.PlaceImportRecord
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		record := dto.PlaceImportRecord{Row: line}
+		var latitude, longitude string
+		for i, value := range fields {
+			if i >= len(columns) {
+				break
+			}
+			value = strings.TrimSpace(value)
+			switch columns[i] {
+			case "latitude":
+				latitude = value
+			case "longitude":
+				longitude = value
+			case "categories":
+				record.CategorySlugs = splitImportList(value)
+			default:
+				setImportField(&record, columns[i], value)
+			}
+		}
+		setImportCoordinates(&record, latitude, longitude)
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func parsePlaceImportGeoJSON(r io.Reader) ([]dto.PlaceImportRecord, error) {
+	var collection dto.GeoJSONFeatureCollection
+	// Numbers in IDs and properties are kept as written, as float64 123456789 would
+	// print as 1.23456789e+08
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	if err := dec.Decode(&collection); err != nil {
+		return nil, fmt.Errorf("invalid GeoJSON: %w", err)
+	}
+	if collection.Type != "FeatureCollection" {
+		return nil, errors.New("GeoJSON must be a FeatureCollection")
+	}
+
+	records := make([]dto.PlaceImportRecord, 0, len(collection.Features))
+	for i, feature := range collection.Features {
+		record := dto.PlaceImportRecord{Row: i + 1}
+
+		for key, raw := range feature.Properties {
+			column := importColumnName(key)
+			if column == "categories" {
+				record.CategorySlugs = importStringList(raw)
+				continue
+			}
+			if raw == nil {
+				continue
+			}
+			setImportField(&record, column, strings.TrimSpace(fmt.Sprint(raw)))
+		}
+		if record.ExternalRef == "" && feature.ID != nil {
+			record.ExternalRef = strings.TrimSpace(fmt.Sprint(feature.ID))
+		}
+
+		switch {
+		case feature.Geometry == nil:
+			record.Errors = append(record.Errors, "feature has no geometry")
+		case feature.Geometry.Type != "Point" || len(feature.Geometry.Coordinates) < 2:
+			record.Errors = append(record.Errors, fmt.Sprintf("unsupported geometry %s, expected a Point", feature.Geometry.Type))
+		default:
+			record.Longitude = feature.Geometry.Coordinates[0]
+			record.Latitude = feature.Geometry.Coordinates[1]
+			record.HasCoordinates = true
+		}
+
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func importColumnName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if canonical, ok := importColumnAliases[name]; ok {
+		return canonical
+	}
+	return name
+}
+
+func setImportField(record *dto.PlaceImportRecord, column, value string) {
+	switch column {
+	case "external_ref":
+		record.ExternalRef = value
+	case "name_ar":
+		record.NameAr = value
+	case "name_en":
+		record.NameEn = value
+	case "description_ar":
+		record.DescriptionAr = value
+	case "description_en":
+		record.DescriptionEn = value
+	case "subtitle_ar":
+		record.SubtitleAr = value
+	case "subtitle_en":
+		record.SubtitleEn = value
+	case "governate":
+		record.GovernateSlug = strings.ToLower(value)
+	case "wilayah":
+		record.WilayahSlug = strings.ToLower(value)
+	case "phone":
+		record.Phone = value
+	case "email":
+		record.Email = value
+	case "website":
+		record.Website = value
+	}
+}
+
+func setImportCoordinates(record *dto.PlaceImportRecord, latitude, longitude string) {
+	if latitude == "" && longitude == "" {
+		return
+	}
+	lat, latErr := strconv.ParseFloat(latitude, 64)
+	lng, lngErr := strconv.ParseFloat(longitude, 64)
+	if latErr != nil || lngErr != nil {
+		record.Errors = append(record.Errors, fmt.Sprintf("invalid coordinates %q, %q", latitude, longitude))
+		return
+	}
+	record.Latitude = lat
+	record.Longitude = lng
+	record.HasCoordinates = true
+}
+
+// splitImportList splits a CSV cell holding several slugs separated by ; or |
+func splitImportList(value string) []string {
+	var items []string
+	for _, item := range strings.FieldsFunc(value, func(r rune) bool { return r == ';' || r == '|' }) {
+		if item = strings.ToLower(strings.TrimSpace(item)); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// importStringList reads a GeoJSON property that is either an array or a separated string
+func importStringList(raw interface{}) []string {
+	switch value := raw.(type) {
+	case []interface{}:
+		var items []string
+		for _, item := range value {
+			items = append(items, splitImportList(fmt.Sprint(item))...)
+		}
+		return items
+	case string:
+		return splitImportList(value)
+	default:
+		return nil
+	}
+}
+
+// ImportPlaces creates or updates a place per record, matching existing places on their
+// external reference. With dryRun nothing is written and the report shows what would happen.
+// Created places are drafts like any other new place and go live once a moderator approves them.
+func ImportPlaces(records []dto.PlaceImportRecord, userID uuid.UUID, dryRun bool) (*dto.PlaceImportReport, error) {
+	lookups, err := loadImportLookups()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &dto.PlaceImportReport{
+		DryRun:        dryRun,
+		CreatedStatus: domain.PlaceStatusDraft,
+		Total:         len(records),
+		Rows:          make([]dto.PlaceImportRowResult, 0, len(records)),
+	}
+
+	batch := &importBatch{refs: make(map[string]int)}
+	for _, record := range records {
+		result := importPlaceRecord(record, lookups, batch, userID, dryRun)
+		switch result.Action {
+		case dto.ImportActionCreate:
+			report.Created++
+		case dto.ImportActionUpdate:
+			report.Updated++
+		case dto.ImportActionUnchanged:
+			report.Unchanged++
+		default:
+			report.Failed++
+		}
+		report.Rows = append(report.Rows, result)
+	}
+
+	return report, nil
+}
+
+func loadImportLookups() (*importLookups, error) {
+	lookups := &importLookups{
+		governates: make(map[string]uuid.UUID),
+		wilayahs:   make(map[string]domain.Wilayah),
+		categories: make(map[string]uuid.UUID),
+	}
+
+	var governates []domain.Governate
+	if err := config.DB.Select("id", "slug").Find(&governates).Error; err != nil {
+		return nil, fmt.Errorf("failed to load governates: %w", err)
+	}
+	for _, governate := range governates {
+		lookups.governates[strings.ToLower(governate.Slug)] = governate.ID
+	}
+
+	var wilayahs []domain.Wilayah
+	if err := config.DB.Select("id", "slug", "governate_id").Find(&wilayahs).Error; err != nil {
+		return nil, fmt.Errorf("failed to load wilayahs: %w", err)
+	}
+	for _, wilayah := range wilayahs {
+		lookups.wilayahs[strings.ToLower(wilayah.Slug)] = wilayah
+	}
+
+	var categories []domain.Category
+	if err := config.DB.Select("id", "slug").Find(&categories).Error; err != nil {
+		return nil, fmt.Errorf("failed to load categories: %w", err)
+	}
+	for _, category := range categories {
+		lookups.categories[strings.ToLower(category.Slug)] = category.ID
+	}
+
+	return lookups, nil
+}
+
+func importPlaceRecord(record dto.PlaceImportRecord, lookups *importLookups, batch *importBatch, userID uuid.UUID, dryRun bool) dto.PlaceImportRowResult {
+	result := dto.PlaceImportRowResult{
+		Row:         record.Row,
+		ExternalRef: record.ExternalRef,
+		NameEn:      record.NameEn,
+	}
+	fail := func(messages ...string) dto.PlaceImportRowResult {
+		result.Action = dto.ImportActionError
+		result.Errors = append(result.Errors, messages...)
+		return result
+	}
+
+	problems := append([]string{}, record.Errors...)
+
+	if record.ExternalRef == "" {
+		problems = append(problems, "external_ref is required")
+	} else if len(record.ExternalRef) > 100 {
+		problems = append(problems, "external_ref must be at most 100 characters")
+	} else if row, seen := batch.refs[record.ExternalRef]; seen {
+		problems = append(problems, fmt.Sprintf("external_ref %s is already used on row %d", record.ExternalRef, row))
+	} else {
+		batch.refs[record.ExternalRef] = record.Row
+	}
+
+	for field, value := range map[string]string{"name_ar": record.NameAr, "name_en": record.NameEn} {
+		if length := len([]rune(value)); length < 2 || length > 200 {
+			problems = append(problems, fmt.Sprintf("%s must be between 2 and 200 characters", field))
+		}
+	}
+
+	if len(record.Errors) == 0 {
+		if !record.HasCoordinates {
+			problems = append(problems, "latitude and longitude are required")
+		} else if !utils.ValidCoordinates(record.Latitude, record.Longitude) || (record.Latitude == 0 && record.Longitude == 0) {
+			problems = append(problems, fmt.Sprintf("invalid coordinates %g, %g", record.Latitude, record.Longitude))
+		}
+	}
+
+	var governateID, wilayahID *uuid.UUID
+	if record.GovernateSlug != "" {
+		if id, ok := lookups.governates[record.GovernateSlug]; ok {
+			governateID = &id
+		} else {
+			problems = append(problems, fmt.Sprintf("unknown governate %q", record.GovernateSlug))
+		}
+	}
+	if record.WilayahSlug != "" {
+		if wilayah, ok := lookups.wilayahs[record.WilayahSlug]; !ok {
+			problems = append(problems, fmt.Sprintf("unknown wilayah %q", record.WilayahSlug))
+		} else if governateID != nil && wilayah.GovernateID != *governateID {
+			problems = append(problems, fmt.Sprintf("wilayah %q does not belong to governate %q", record.WilayahSlug, record.GovernateSlug))
+		} else {
+			wilayahID = &wilayah.ID
+			if governateID == nil && record.GovernateSlug == "" {
+				governateID = &wilayah.GovernateID
+			}
+		}
+	}
+
+	var categoryIDs []uuid.UUID
+	for _, slug := range record.CategorySlugs {
+		if id, ok := lookups.categories[slug]; ok {
+			categoryIDs = append(categoryIDs, id)
+		} else {
+			problems = append(problems, fmt.Sprintf("unknown category %q", slug))
+		}
+	}
+	if len(record.CategorySlugs) == 0 {
+		problems = append(problems, "at least one category is required")
+	}
+
+	if len(problems) > 0 {
+		return fail(problems...)
+	}
+
+	categoryIDs, err := ensureParentCategoriesIncluded(uniqueUUIDs(categoryIDs))
+	if err != nil {
+		return fail(err.Error())
+	}
+
+	var existing domain.Place
+	err = config.DB.Unscoped().Where("external_ref = ?", record.ExternalRef).First(&existing).Error
+	if err == nil {
+		return importUpdatePlace(result, existing, record, governateID, wilayahID, categoryIDs, userID, dryRun)
+	}
+	if err != gorm.ErrRecordNotFound {
+		return fail(fmt.Sprintf("failed to check external_ref: %v", err))
+	}
+
+	req := dto.CreatePlaceRequest{
+		NameAr:        record.NameAr,
+		NameEn:        record.NameEn,
+		DescriptionAr: record.DescriptionAr,
+		DescriptionEn: record.DescriptionEn,
+		SubtitleAr:    record.SubtitleAr,
+		SubtitleEn:    record.SubtitleEn,
+		Latitude:      record.Latitude,
+		Longitude:     record.Longitude,
+		Phone:         record.Phone,
+		Email:         record.Email,
+		Website:       record.Website,
+		ExternalRef:   record.ExternalRef,
+	}
+	if governateID != nil {
+		id := governateID.String()
+		req.GovernateID = &id
+	}
+	if wilayahID != nil {
+		id := wilayahID.String()
+		req.WilayahID = &id
+	}
+	for _, id := range categoryIDs {
+		req.CategoryIDs = append(req.CategoryIDs, id.String())
+	}
+
+	result.Action = dto.ImportActionCreate
+	if dryRun {
+		candidates, err := FindDuplicatePlaces(req.NameAr, req.NameEn, req.Latitude, req.Longitude, nil)
+		if err != nil {
+			return fail(err.Error())
+		}
+		addCandidate := func(candidate dto.DuplicateCandidate, message string) {
+			if candidate.Blocking {
+				result.Errors = append(result.Errors, "possible duplicate: "+message)
+			} else {
+				result.Warnings = append(result.Warnings, message)
+			}
+		}
+		for _, candidate := range candidates {
+			addCandidate(candidate, fmt.Sprintf("similar to %s (%s), %.2f km away", candidate.NameEn, candidate.PlaceID, candidate.DistanceKm))
+		}
+
+		// Earlier rows are not saved in a dry run, so they are compared here
+		for _, other := range batch.created {
+			place := domain.Place{NameAr: other.NameAr, NameEn: other.NameEn, Latitude: other.Latitude, Longitude: other.Longitude}
+			if candidate, ok := matchDuplicatePlace(req.NameAr, req.NameEn, req.Latitude, req.Longitude, place); ok {
+				addCandidate(candidate, fmt.Sprintf("similar to %s on row %d, %.2f km away", other.NameEn, other.Row, candidate.DistanceKm))
+			}
+		}
+
+		if len(result.Errors) > 0 {
+			result.Action = dto.ImportActionError
+			return result
+		}
+		batch.created = append(batch.created, record)
+		return result
+	}
+
+	place, err := CreatePlace(req, userID)
+	if err != nil {
+		return fail(err.Error())
+	}
+	result.PlaceID = &place.ID
+	for _, candidate := range place.DuplicateCandidates {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("similar to %s (%s), %.2f km away", candidate.NameEn, candidate.PlaceID, candidate.DistanceKm))
+	}
+	return result
+}
+
+// importUpdatePlace updates an already imported place. Like UpdatePlace, empty values in the
+// file leave the current value in place.
+func importUpdatePlace(result dto.PlaceImportRowResult, place domain.Place, record dto.PlaceImportRecord,
+	governateID, wilayahID *uuid.UUID, categoryIDs []uuid.UUID, userID uuid.UUID, dryRun bool) dto.PlaceImportRowResult {
+
+	result.PlaceID = &place.ID
+	if place.DeletedAt.Valid {
+		result.Action = dto.ImportActionError
+		result.Errors = append(result.Errors, "the place imported with this external_ref has been deleted")
+		return result
+	}
+
+	req := dto.UpdatePlaceRequest{}
+	changeString := func(field, current, value string, target *string) {
+		if value != "" && value != current {
+			*target = value
+			result.Changes = append(result.Changes, field)
+		}
+	}
+	changeString("name_ar", place.NameAr, record.NameAr, &req.NameAr)
+	changeString("name_en", place.NameEn, record.NameEn, &req.NameEn)
+	changeString("description_ar", place.DescriptionAr, record.DescriptionAr, &req.DescriptionAr)
+	changeString("description_en", place.DescriptionEn, record.DescriptionEn, &req.DescriptionEn)
+	changeString("subtitle_ar", place.SubtitleAr, record.SubtitleAr, &req.SubtitleAr)
+	changeString("subtitle_en", place.SubtitleEn, record.SubtitleEn, &req.SubtitleEn)
+	changeString("phone", place.Phone, record.Phone, &req.Phone)
+	changeString("email", place.Email, record.Email, &req.Email)
+	changeString("website", place.Website, record.Website, &req.Website)
+
+	if governateID != nil && (place.GovernateID == nil || *place.GovernateID != *governateID) {
+		req.GovernateID = governateID
+		result.Changes = append(result.Changes, "governate")
+	}
+	if wilayahID != nil && (place.WilayahID == nil || *place.WilayahID != *wilayahID) {
+		req.WilayahID = wilayahID
+		result.Changes = append(result.Changes, "wilayah")
+	}
+	if record.Latitude != place.Latitude || record.Longitude != place.Longitude {
+		req.Latitude = record.Latitude
+		req.Longitude = record.Longitude
+		result.Changes = append(result.Changes, "coordinates")
+	}
+
+	var currentCategories []uuid.UUID
+	err := config.DB.Table("place_categories").
+		Where("place_id = ? AND deleted_at IS NULL", place.ID).
+		Pluck("category_id", &currentCategories).Error
+	if err != nil {
+		result.Action = dto.ImportActionError
+		result.Errors = append(result.Errors, err.Error())
+		return result
+	}
+	if !equalUUIDSets(currentCategories, categoryIDs) {
+		req.CategoryIDs = categoryIDs
+		result.Changes = append(result.Changes, "categories")
+	}
+
+	if len(result.Changes) == 0 {
+		result.Action = dto.ImportActionUnchanged
+		return result
+	}
+
+	result.Action = dto.ImportActionUpdate
+	if dryRun {
+		return result
+	}
+
+	if _, err := UpdatePlace(place.ID, req, userID); err != nil {
+		result.Action = dto.ImportActionError
+		result.Errors = append(result.Errors, err.Error())
+	}
+	return result
+}
+
+func equalUUIDSets(a, b []uuid.UUID) bool {
+	a, b = uniqueUUIDs(a), uniqueUUIDs(b)
+	if len(a) != len(b) {
+		return false
+	}
+	set := make(map[uuid.UUID]bool, len(a))
+	for _, id := range a {
+		set[id] = true
+	}
+	for _, id := range b {
+		if !set[id] {
+			return false
+		}
+	}
+	return true
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,272 @@
+// services/place_moderation_service.go
+package services
+
+import (
+	"almlah/config"
+	"almlah/internals/domain"
+	"almlah/internals/dto"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// publishedPlacesOnly limits a query on the places table to places visible to the public
+func publishedPlacesOnly(db *gorm.DB) *gorm.DB {
+	return db.Where("places.status = ?", domain.PlaceStatusPublished)
+}
+
+// SubmitPlaceForReview moves a draft or rejected place into the moderation queue
+func SubmitPlaceForReview(placeID uuid.UUID, userID uuid.UUID, comment string) (*dto.PlaceResponse, error) {
+	canModify, err := canUserModifyPlace(placeID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canModify {
+		return nil, errors.New("unauthorized: you don't have permission to submit this place")
+	}
+
+	return transitionPlaceStatus(placeID, userID, domain.PlaceStatusPendingReview, comment,
+		domain.PlaceStatusDraft, domain.PlaceStatusRejected)
+}
+
+// ApprovePlace publishes a place that is pending review
+func ApprovePlace(placeID uuid.UUID, moderatorID uuid.UUID, comment string) (*dto.PlaceResponse, error) {
+	return transitionPlaceStatus(placeID, moderatorID, domain.PlaceStatusPublished, comment,
+		domain.PlaceStatusPendingReview)
+}
+
+// RejectPlace sends a pending place back to its author; a comment explaining why is required
+func RejectPlace(placeID uuid.UUID, moderatorID uuid.UUID, comment string) (*dto.PlaceResponse, error) {
+	if comment == "" {
+		return nil, errors.New("a comment is required when rejecting a place")
+	}
+	return transitionPlaceStatus(placeID, moderatorID, domain.PlaceStatusRejected, comment,
+		domain.PlaceStatusPendingReview)
+}
+
+// ArchivePlace takes a published place off the public site without deleting it
+func ArchivePlace(placeID uuid.UUID, moderatorID uuid.UUID, comment string) (*dto.PlaceResponse, error) {
+	return transitionPlaceStatus(placeID, moderatorID, domain.PlaceStatusArchived, comment,
+		domain.PlaceStatusPublished)
+}
+
+// transitionPlaceStatus changes the status of a place and records the moderation event
+func transitionPlaceStatus(placeID, actorID uuid.UUID, to string, comment string, allowedFrom ...string) (*dto.PlaceResponse, error) {
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		return changePlaceStatus(tx, placeID, actorID, to, comment, allowedFrom...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return GetPlaceByID(placeID)
+}
+
+// changePlaceStatus does the work of transitionPlaceStatus inside the caller's transaction
+func changePlaceStatus(tx *gorm.DB, placeID, actorID uuid.UUID, to string, comment string, allowedFrom ...string) error {
+	var place domain.Place
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&place, "id = ?", placeID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.New("place not found")
+		}
+		return err
+	}
+
+	allowed := false
+	for _, status := range allowedFrom {
+		if place.Status == status {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return fmt.Errorf("cannot move place from %s to %s", place.Status, to)
+	}
+
+	from := place.Status
+	if err := tx.Model(&place).Update("status", to).Error; err != nil {
+		return fmt.Errorf("failed to update place status: %w", err)
+	}
+
+	event := domain.PlaceModerationEvent{
+		PlaceID:    place.ID,
+		ActorID:    actorID,
+		FromStatus: from,
+		ToStatus:   to,
+		Comment:    comment,
+	}
+	if err := tx.Omit(clause.Associations).Create(&event).Error; err != nil {
+		return fmt.Errorf("failed to record moderation event: %w", err)
+	}
+
+	return nil
+}
+
+// canPublishPlaceEdits reports whether the user's edits to a published place may go live
+// without another review
+func canPublishPlaceEdits(userID uuid.UUID) (bool, error) {
+	var user domain.User
+	if err := config.DB.Preload("Roles.Permissions").First(&user, "id = ?", userID).Error; err != nil {
+		return false, errors.New("user not found")
+	}
+	return user.IsAdmin() || user.IsSuperAdmin() || user.HasPermission("can_moderate_place"), nil
+}
+
+// sendEditedPlaceToReview takes a published place edited by a contributor off the public
+// site until a moderator approves the changes
+func sendEditedPlaceToReview(tx *gorm.DB, placeID, userID uuid.UUID, comment string) error {
+	canPublish, err := canPublishPlaceEdits(userID)
+	if err != nil {
+		return err
+	}
+	if canPublish {
+		return nil
+	}
+
+	var place domain.Place
+	if err := tx.Select("id", "status").First(&place, "id = ?", placeID).Error; err != nil {
+		return err
+	}
+	if place.Status != domain.PlaceStatusPublished {
+		return nil
+	}
+	return changePlaceStatus(tx, placeID, userID, domain.PlaceStatusPendingReview, comment,
+		domain.PlaceStatusPublished)
+}
+
+// GetModerationQueue lists places in the given status (pending_review by default), oldest first
+func GetModerationQueue(status string, governateID *uuid.UUID, page, limit int) (*dto.PaginationResponse, error) {
+	if status == "" {
+		status = domain.PlaceStatusPendingReview
+	}
+	switch status {
+	case domain.PlaceStatusDraft, domain.PlaceStatusPendingReview, domain.PlaceStatusPublished,
+		domain.PlaceStatusRejected, domain.PlaceStatusArchived:
+	default:
+		return nil, fmt.Errorf("invalid status: %s", status)
+	}
+
+	queue := func() *gorm.DB {
+		query := config.DB.Model(&domain.Place{}).Where("status = ?", status)
+		if governateID != nil {
+			query = query.Where("governate_id = ?", *governateID)
+		}
+		return query
+	}
+
+	var total int64
+	if err := queue().Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count moderation queue: %w", err)
+	}
+
+	var places []domain.Place
+	err := queue().Preload("Categories").
+		Preload("Images").
+		Preload("Governate").
+		Preload("Wilayah").
+		Order("updated_at ASC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&places).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load moderation queue: %w", err)
+	}
+
+	placeIDs := make([]uuid.UUID, len(places))
+	for i, place := range places {
+		placeIDs[i] = place.ID
+	}
+	latestEvents, err := latestModerationEvents(placeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.ModerationQueueItem, 0, len(places))
+	for _, place := range places {
+		item := dto.ModerationQueueItem{
+			PlaceListResponse: mapPlaceToListResponse(place),
+			CreatedBy:         place.CreatedBy,
+			UpdatedAt:         place.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+		}
+		if event, ok := latestEvents[place.ID]; ok {
+			response := mapModerationEventToResponse(event)
+			item.LatestEvent = &response
+		}
+		items = append(items, item)
+	}
+
+	return &dto.PaginationResponse{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+		Data:       items,
+	}, nil
+}
+
+// GetPlaceModerationHistory returns all status changes of a place, newest first.
+// Only the place's editors and moderators may read reviewer comments.
+func GetPlaceModerationHistory(placeID uuid.UUID, userID uuid.UUID) ([]dto.PlaceModerationEventResponse, error) {
+	if err := checkPlaceHistoryAccess(placeID, userID, "moderation history"); err != nil {
+		return nil, err
+	}
+
+	var events []domain.PlaceModerationEvent
+	err := config.DB.Preload("Actor").
+		Where("place_id = ?", placeID).
+		Order("created_at DESC").
+		Find(&events).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load moderation history: %w", err)
+	}
+
+	response := make([]dto.PlaceModerationEventResponse, 0, len(events))
+	for _, event := range events {
+		response = append(response, mapModerationEventToResponse(event))
+	}
+	return response, nil
+}
+
+func latestModerationEvents(placeIDs []uuid.UUID) (map[uuid.UUID]domain.PlaceModerationEvent, error) {
+	latest := make(map[uuid.UUID]domain.PlaceModerationEvent)
+	if len(placeIDs) == 0 {
+		return latest, nil
+	}
+
+	var events []domain.PlaceModerationEvent
+	err := config.DB.Preload("Actor").
+		Where("place_id IN ?", placeIDs).
+		Order("created_at DESC").
+		Find(&events).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load moderation events: %w", err)
+	}
+
+	for _, event := range events {
+		if _, exists := latest[event.PlaceID]; !exists {
+			latest[event.PlaceID] = event
+		}
+	}
+	return latest, nil
+}
+
+func mapModerationEventToResponse(event domain.PlaceModerationEvent) dto.PlaceModerationEventResponse {
+	response := dto.PlaceModerationEventResponse{
+		ID:         event.ID,
+		FromStatus: event.FromStatus,
+		ToStatus:   event.ToStatus,
+		Comment:    event.Comment,
+		CreatedAt:  event.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if event.Actor.ID != uuid.Nil {
+		response.Actor = &dto.UserResponse{
+			ID:    event.Actor.ID,
+			Name:  event.Actor.GetFullName(),
+			Email: event.Actor.Email,
+		}
+	}
+	return response
+}
@@ -0,0 +1,134 @@
+// services/place_permission_service.go
+package services
+
+import (
+	"almlah/config"
+	"almlah/internals/domain"
+	"almlah/internals/dto"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// placeScopedPermissions are the permissions that can be granted on a single place
+var placeScopedPermissions = []string{"can_respond_review"}
+
+// GrantPlacePermission lets a user use a permission on one place. Granting it again is a no-op.
+func GrantPlacePermission(placeID uuid.UUID, req dto.GrantPlacePermissionRequest, grantedBy uuid.UUID) (*dto.PlacePermissionResponse, error) {
+	if !containsString(placeScopedPermissions, req.Permission) {
+		return nil, fmt.Errorf("invalid permission: %s cannot be granted on a place", req.Permission)
+	}
+
+	var place domain.Place
+	if err := config.DB.Select("id").First(&place, "id = ?", placeID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("place not found")
+		}
+		return nil, err
+	}
+
+	var user domain.User
+	if err := config.DB.Select("id").First(&user, "id = ?", req.UserID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+
+	var permission domain.Permission
+	if err := config.DB.Where("name = ? AND is_active = ?", req.Permission, true).First(&permission).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("permission not found")
+		}
+		return nil, err
+	}
+
+	grant := domain.PlacePermission{
+		PlaceID:      placeID,
+		UserID:       req.UserID,
+		PermissionID: permission.ID,
+		GrantedBy:    grantedBy,
+	}
+	err := config.DB.Omit(clause.Associations).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&grant).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to grant permission: %w", err)
+	}
+
+	err = config.DB.Preload("User").Preload("Permission").
+		Where("place_id = ? AND user_id = ? AND permission_id = ?", placeID, req.UserID, permission.ID).
+		First(&grant).Error
+	if err != nil {
+		return nil, err
+	}
+
+	response := mapPlacePermissionToResponse(grant)
+	return &response, nil
+}
+
+// RevokePlacePermission removes a permission granted on a place
+func RevokePlacePermission(placeID uuid.UUID, userID uuid.UUID, permissionName string) error {
+	result := config.DB.
+		Where("place_id = ? AND user_id = ? AND permission_id IN (?)", placeID, userID,
+			config.DB.Model(&domain.Permission{}).Select("id").Where("name = ?", permissionName)).
+		Delete(&domain.PlacePermission{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to revoke permission: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("permission grant not found")
+	}
+	return nil
+}
+
+// GetPlacePermissions lists the permissions granted on a place
+func GetPlacePermissions(placeID uuid.UUID) ([]dto.PlacePermissionResponse, error) {
+	var grants []domain.PlacePermission
+	err := config.DB.Preload("User").Preload("Permission").
+		Where("place_id = ?", placeID).
+		Order("created_at ASC").
+		Find(&grants).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load place permissions: %w", err)
+	}
+
+	response := make([]dto.PlacePermissionResponse, 0, len(grants))
+	for _, grant := range grants {
+		response = append(response, mapPlacePermissionToResponse(grant))
+	}
+	return response, nil
+}
+
+// hasPlacePermission reports whether a user was granted a permission on a place
+func hasPlacePermission(placeID uuid.UUID, userID uuid.UUID, permissionName string) (bool, error) {
+	var count int64
+	err := config.DB.Model(&domain.PlacePermission{}).
+		Joins("JOIN permissions ON permissions.id = place_permissions.permission_id").
+		Where("place_permissions.place_id = ? AND place_permissions.user_id = ?", placeID, userID).
+		Where("permissions.name = ? AND permissions.is_active = ? AND permissions.deleted_at IS NULL", permissionName, true).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func mapPlacePermissionToResponse(grant domain.PlacePermission) dto.PlacePermissionResponse {
+	return dto.PlacePermissionResponse{
+		ID:         grant.ID,
+		PlaceID:    grant.PlaceID,
+		Permission: grant.Permission.Name,
+		User: dto.UserInfo{
+			ID:        grant.User.ID,
+			Username:  grant.User.Username,
+			FirstName: grant.User.FirstName,
+			LastName:  grant.User.LastName,
+		},
+		GrantedBy: grant.GrantedBy,
+		CreatedAt: grant.CreatedAt,
+	}
+}
@@ -0,0 +1,221 @@
+// services/place_query_service.go
+package services
+
+import (
+	"almlah/config"
+	"almlah/internals/domain"
+	"almlah/internals/dto"
+	"almlah/internals/utils"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// placeSortColumns maps accepted sort_by values to ORDER BY expressions. Keep in sync with
+// the oneof validation of PlaceFilterRequest.SortBy.
+var placeSortColumns = map[string]string{
+	"newest":      "places.created_at",
+	"name":        "places.name_en",
+	"rating":      "places.rating_average",
+	"reviews":     "places.review_count",
+	"last_review": "places.last_review_at",
+}
+
+// placeFilterScope lets facet queries drop the filter of their own dimension
+type placeFilterScope struct {
+	skipCategories bool
+	skipProperties bool
+	skipLocation   bool
+}
+
+// QueryPlaces applies a PlaceFilterRequest and returns a page of places plus facet counts
+func QueryPlaces(req dto.PlaceFilterRequest) (*dto.PlaceQueryResponse, error) {
+	req.SetDefaults()
+
+	sortColumn, ok := placeSortColumns[req.SortBy]
+	if !ok {
+		return nil, fmt.Errorf("invalid sort_by: %s", req.SortBy)
+	}
+	// "newest" is always most recent first, other sorts follow sort_desc
+	direction := "ASC"
+	if req.SortDesc || req.SortBy == "newest" {
+		direction = "DESC"
+	}
+
+	// Resolved once so the count, the page and the facets use the same time
+	if openAt := req.OpenTime(); openAt != nil {
+		req.OpenAt = openAt
+	}
+
+	var total int64
+	if err := filteredPlacesQuery(req, placeFilterScope{}).Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count places: %w", err)
+	}
+
+	var placeIDs []uuid.UUID
+	err := filteredPlacesQuery(req, placeFilterScope{}).
+		Order(fmt.Sprintf("%s %s NULLS LAST", sortColumn, direction)).
+		Order("places.id").
+		Offset((req.Page-1)*req.Limit).
+		Limit(req.Limit).
+		Pluck("places.id", &placeIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to query places: %w", err)
+	}
+
+	places := make([]dto.PlaceListResponse, 0, len(placeIDs))
+	if len(placeIDs) > 0 {
+		placesByID, err := loadListPlacesByIDs(placeIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load places: %w", err)
+		}
+		for _, id := range placeIDs {
+			if place, ok := placesByID[id]; ok {
+				item := mapPlaceToListResponse(place)
+				if req.OpenAt != nil {
+					isOpen := true
+					item.IsOpen = &isOpen
+				}
+				places = append(places, item)
+			}
+		}
+	}
+
+	facets, err := getPlaceFacets(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.PlaceQueryResponse{
+		Places:     places,
+		Page:       req.Page,
+		Limit:      req.Limit,
+		Total:      total,
+		TotalPages: int((total + int64(req.Limit) - 1) / int64(req.Limit)),
+		Facets:     *facets,
+	}, nil
+}
+
+// filteredPlacesQuery builds the WHERE clauses shared by the result and facet queries
+func filteredPlacesQuery(req dto.PlaceFilterRequest, scope placeFilterScope) *gorm.DB {
+	// The handler clears is_active for callers who aren't admins
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	query := config.DB.Model(&domain.Place{}).
+		Where("places.is_active = ?", isActive).
+		Scopes(publishedPlacesOnly)
+
+	if !scope.skipCategories && len(req.CategoryIDs) > 0 {
+		if req.CategoryMatch == "all" {
+			query = query.Where(`places.id IN (
+				SELECT place_id FROM place_categories
+				WHERE category_id IN ? AND deleted_at IS NULL
+				GROUP BY place_id HAVING COUNT(DISTINCT category_id) = ?
+			)`, req.CategoryIDs, len(uniqueUUIDs(req.CategoryIDs)))
+		} else {
+			query = query.Where(`places.id IN (
+				SELECT place_id FROM place_categories
+				WHERE category_id IN ? AND deleted_at IS NULL
+			)`, req.CategoryIDs)
+		}
+	}
+
+	if !scope.skipProperties && len(req.PropertyIDs) > 0 {
+		query = query.Where(`places.id IN (
+			SELECT place_id FROM place_properties
+			WHERE property_id IN ?
+			GROUP BY place_id HAVING COUNT(DISTINCT property_id) = ?
+		)`, req.PropertyIDs, len(uniqueUUIDs(req.PropertyIDs)))
+	}
+
+	if !scope.skipLocation {
+		if len(req.GovernateIDs) > 0 {
+			query = query.Where("places.governate_id IN ?", req.GovernateIDs)
+		}
+		if len(req.WilayahIDs) > 0 {
+			query = query.Where("places.wilayah_id IN ?", req.WilayahIDs)
+		}
+	}
+
+	if req.OpenAt != nil {
+		query = query.Scopes(placesOpenAt(*req.OpenAt))
+	}
+
+	if req.MinRating > 0 {
+		query = query.Where("places.rating_average >= ?", req.MinRating)
+	}
+	if req.MinReviews > 0 {
+		query = query.Where("places.review_count >= ?", req.MinReviews)
+	}
+
+	for _, term := range utils.SearchTerms(req.Search) {
+		query = query.Where("places.id IN (SELECT d.place_id FROM place_search_documents d WHERE "+
+			searchDocumentText+" LIKE ?)", "%"+escapeLike(term)+"%")
+	}
+
+	return query
+}
+
+func getPlaceFacets(req dto.PlaceFilterRequest) (*dto.PlaceFacets, error) {
+	facets := &dto.PlaceFacets{
+		Categories: []dto.FacetCount{},
+		Properties: []dto.FacetCount{},
+		Governates: []dto.FacetCount{},
+	}
+
+	categoryPlaces := filteredPlacesQuery(req, placeFilterScope{skipCategories: true}).Select("places.id")
+	err := config.DB.Table("place_categories").
+		Select("categories.id, categories.name_ar, categories.name_en, COUNT(DISTINCT place_categories.place_id) AS count").
+		Joins("JOIN categories ON categories.id = place_categories.category_id").
+		Where("place_categories.deleted_at IS NULL AND categories.is_active = ?", true).
+		Where("place_categories.place_id IN (?)", categoryPlaces).
+		Group("categories.id, categories.name_ar, categories.name_en").
+		Order("count DESC, categories.name_en ASC").
+		Scan(&facets.Categories).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count category facets: %w", err)
+	}
+
+	propertyPlaces := filteredPlacesQuery(req, placeFilterScope{skipProperties: true}).Select("places.id")
+	err = config.DB.Table("place_properties").
+		Select("properties.id, properties.name_ar, properties.name_en, COUNT(DISTINCT place_properties.place_id) AS count").
+		Joins("JOIN properties ON properties.id = place_properties.property_id").
+		Where("place_properties.place_id IN (?)", propertyPlaces).
+		Group("properties.id, properties.name_ar, properties.name_en").
+		Order("count DESC, properties.name_en ASC").
+		Scan(&facets.Properties).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count property facets: %w", err)
+	}
+
+	governatePlaces := filteredPlacesQuery(req, placeFilterScope{skipLocation: true}).Select("places.id")
+	err = config.DB.Table("places").
+		Select("governates.id, governates.name_ar, governates.name_en, COUNT(DISTINCT places.id) AS count").
+		Joins("JOIN governates ON governates.id = places.governate_id").
+		Where("governates.deleted_at IS NULL").
+		Where("places.id IN (?)", governatePlaces).
+		Group("governates.id, governates.name_ar, governates.name_en").
+		Order("count DESC, governates.name_en ASC").
+		Scan(&facets.Governates).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count governate facets: %w", err)
+	}
+
+	return facets, nil
+}
+
+func uniqueUUIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	var unique []uuid.UUID
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
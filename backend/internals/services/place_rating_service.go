@@ -0,0 +1,92 @@
+// services/place_rating_service.go
+package services
+
+import (
+	"almlah/config"
+	"almlah/internals/domain"
+	"almlah/internals/dto"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// lockPlaceForRating locks a place before its reviews change. Writers of the same place's
+// reviews then run one after the other, so each recount sees the previous one's review.
+func lockPlaceForRating(tx *gorm.DB, placeID uuid.UUID) error {
+	var place domain.Place
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&place, "id = ?", placeID).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.New("place not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// RefreshPlaceRatingStats recounts the review aggregates of a place. Call it in the
+// transaction that changed the reviews, after lockPlaceForRating.
+func RefreshPlaceRatingStats(tx *gorm.DB, placeID uuid.UUID) error {
+	err := tx.Exec(`UPDATE places SET `+domain.PlaceRatingAssignments+`
+		FROM (SELECT `+domain.PlaceRatingAggregates+` FROM reviews WHERE place_id = ? AND `+domain.PlaceCountedReviews+`) AS stats
+		WHERE places.id = ?`, placeID, placeID).Error
+	if err != nil {
+		return fmt.Errorf("failed to update rating of place %s: %w", placeID, err)
+	}
+	return nil
+}
+
+// RecomputePlaceRatings rebuilds the review aggregates of every place from its reviews
+func RecomputePlaceRatings() (int64, error) {
+	result := config.DB.Exec(`UPDATE places SET ` + domain.PlaceRatingAssignments + `
+		FROM places AS p
+		LEFT JOIN (
+			SELECT place_id, ` + domain.PlaceRatingAggregates + `
+			FROM reviews WHERE ` + domain.PlaceCountedReviews + ` GROUP BY place_id
+		) AS stats ON stats.place_id = p.id
+		WHERE places.id = p.id`)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to recompute place ratings: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// GetPlaceRatingSummary returns the average rating and star distribution of a published place
+func GetPlaceRatingSummary(placeID uuid.UUID) (*dto.PlaceRatingSummary, error) {
+	var place domain.Place
+	err := config.DB.Select(append([]string{"id"}, domain.PlaceRatingColumns...)).
+		Scopes(publishedPlacesOnly).
+		First(&place, "id = ?", placeID).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("place not found")
+		}
+		return nil, err
+	}
+
+	return &dto.PlaceRatingSummary{
+		PlaceID:      place.ID,
+		Average:      place.RatingAverage,
+		ReviewCount:  place.ReviewCount,
+		Distribution: placeRatingDistribution(place),
+		LastReviewAt: place.LastReviewAt,
+	}, nil
+}
+
+// placeRatingDistribution lists the review count of each star rating, 5 stars first
+func placeRatingDistribution(place domain.Place) []dto.RatingBucket {
+	counts := []int{place.Rating5Count, place.Rating4Count, place.Rating3Count, place.Rating2Count, place.Rating1Count}
+
+	buckets := make([]dto.RatingBucket, 0, len(counts))
+	for i, count := range counts {
+		bucket := dto.RatingBucket{Stars: 5 - i, Count: count}
+		if place.ReviewCount > 0 {
+			bucket.Percent = float64(count) * 100 / float64(place.ReviewCount)
+		}
+		buckets = append(buckets, bucket)
+	}
+	return buckets
+}
@@ -0,0 +1,520 @@
+// services/place_revision_service.go
+package services
+
+import (
+	"almlah/config"
+	"almlah/internals/domain"
+	"almlah/internals/dto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RecordPlaceRevision stores a snapshot of the current state of a place. Nothing is stored
+// when the place is unchanged since its latest revision.
+func RecordPlaceRevision(placeID uuid.UUID, userID uuid.UUID, changeType string, summary string) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		return recordPlaceRevision(tx, placeID, userID, changeType, summary)
+	})
+}
+
+// recordPlaceEdit runs an edit of a place in one transaction with the revision it creates,
+// so an edit is never saved without its revision. A place without revisions first gets a
+// baseline snapshot of its state before the edit. Edits by contributors take a published
+// place back to review.
+func recordPlaceEdit(placeID uuid.UUID, userID uuid.UUID, changeType string, summary string, edit func(tx *gorm.DB) error) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := ensureBaselineRevision(tx, placeID); err != nil {
+			return fmt.Errorf("failed to record baseline revision: %w", err)
+		}
+		if err := edit(tx); err != nil {
+			return err
+		}
+		if err := sendEditedPlaceToReview(tx, placeID, userID, summary); err != nil {
+			return err
+		}
+		return recordPlaceRevision(tx, placeID, userID, changeType, summary)
+	})
+}
+
+// ensureBaselineRevision snapshots places edited for the first time since revisions were
+// introduced, so the state before the edit can still be restored
+func ensureBaselineRevision(tx *gorm.DB, placeID uuid.UUID) error {
+	var count int64
+	if err := tx.Model(&domain.PlaceRevision{}).Where("place_id = ?", placeID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	var place domain.Place
+	if err := tx.Select("id", "created_by").First(&place, "id = ?", placeID).Error; err != nil {
+		return err
+	}
+	return recordPlaceRevision(tx, placeID, place.CreatedBy, domain.RevisionChangeBaseline, "State before revision history")
+}
+
+func recordPlaceRevision(tx *gorm.DB, placeID uuid.UUID, userID uuid.UUID, changeType string, summary string) error {
+	// Lock the place so concurrent edits get consecutive revision numbers
+	var place domain.Place
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&place, "id = ?", placeID).Error; err != nil {
+		return err
+	}
+
+	snapshot, err := buildPlaceSnapshot(tx, place)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	var latest domain.PlaceRevision
+	err = tx.Where("place_id = ?", placeID).Order("revision_number DESC").First(&latest).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return err
+	}
+
+	if latest.ID != uuid.Nil {
+		// Re-encode the stored snapshot since jsonb does not keep the original formatting
+		if previous, err := decodePlaceSnapshot(latest); err == nil {
+			if encoded, err := json.Marshal(previous); err == nil && string(encoded) == string(data) {
+				return nil
+			}
+		}
+	}
+
+	revision := domain.PlaceRevision{
+		PlaceID:        placeID,
+		RevisionNumber: latest.RevisionNumber + 1,
+		ChangeType:     changeType,
+		Summary:        summary,
+		Snapshot:       string(data),
+		CreatedBy:      userID,
+	}
+	if err := tx.Omit(clause.Associations).Create(&revision).Error; err != nil {
+		return fmt.Errorf("failed to record place revision: %w", err)
+	}
+	return nil
+}
+
+func buildPlaceSnapshot(tx *gorm.DB, place domain.Place) (domain.PlaceSnapshot, error) {
+	snapshot := domain.PlaceSnapshot{
+		NameAr:          place.NameAr,
+		NameEn:          place.NameEn,
+		DescriptionAr:   place.DescriptionAr,
+		DescriptionEn:   place.DescriptionEn,
+		SubtitleAr:      place.SubtitleAr,
+		SubtitleEn:      place.SubtitleEn,
+		GovernateID:     place.GovernateID,
+		WilayahID:       place.WilayahID,
+		Latitude:        place.Latitude,
+		Longitude:       place.Longitude,
+		Phone:           place.Phone,
+		Email:           place.Email,
+		Website:         place.Website,
+		IsActive:        place.IsActive,
+		CategoryIDs:     []uuid.UUID{},
+		PropertyIDs:     []uuid.UUID{},
+		ContentSections: []domain.ContentSectionSnapshot{},
+	}
+
+	err := tx.Table("place_categories").
+		Where("place_id = ? AND deleted_at IS NULL", place.ID).
+		Order("category_id").
+		Pluck("category_id", &snapshot.CategoryIDs).Error
+	if err != nil {
+		return snapshot, fmt.Errorf("failed to load place categories: %w", err)
+	}
+
+	err = tx.Model(&domain.PlaceProperty{}).
+		Where("place_id = ?", place.ID).
+		Order("property_id").
+		Pluck("property_id", &snapshot.PropertyIDs).Error
+	if err != nil {
+		return snapshot, fmt.Errorf("failed to load place properties: %w", err)
+	}
+
+	var sections []domain.PlaceContentSection
+	if err := tx.Where("place_id = ?", place.ID).Order("sort_order ASC, id ASC").Find(&sections).Error; err != nil {
+		return snapshot, fmt.Errorf("failed to load content sections: %w", err)
+	}
+	for _, section := range sections {
+		snapshot.ContentSections = append(snapshot.ContentSections, domain.ContentSectionSnapshot{
+			ID:          section.ID,
+			SectionType: section.SectionType,
+			TitleAr:     section.TitleAr,
+			TitleEn:     section.TitleEn,
+			ContentAr:   section.ContentAr,
+			ContentEn:   section.ContentEn,
+			SortOrder:   section.SortOrder,
+			IsActive:    section.IsActive,
+		})
+	}
+
+	return snapshot, nil
+}
+
+func decodePlaceSnapshot(revision domain.PlaceRevision) (domain.PlaceSnapshot, error) {
+	var snapshot domain.PlaceSnapshot
+	if err := json.Unmarshal([]byte(revision.Snapshot), &snapshot); err != nil {
+		return snapshot, fmt.Errorf("failed to decode revision %d: %w", revision.RevisionNumber, err)
+	}
+	return snapshot, nil
+}
+
+// GetPlaceRevisions lists the revisions of a place, newest first
+func GetPlaceRevisions(placeID uuid.UUID, userID uuid.UUID) ([]dto.PlaceRevisionSummary, error) {
+	if err := checkPlaceHistoryAccess(placeID, userID, "revisions"); err != nil {
+		return nil, err
+	}
+
+	var revisions []domain.PlaceRevision
+	err := config.DB.Preload("Creator").
+		Omit("snapshot").
+		Where("place_id = ?", placeID).
+		Order("revision_number DESC").
+		Find(&revisions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load place revisions: %w", err)
+	}
+
+	response := make([]dto.PlaceRevisionSummary, 0, len(revisions))
+	for _, revision := range revisions {
+		response = append(response, mapPlaceRevisionToSummary(revision))
+	}
+	return response, nil
+}
+
+// GetPlaceRevision returns a single revision with its full snapshot
+func GetPlaceRevision(placeID uuid.UUID, revisionNumber int, userID uuid.UUID) (*dto.PlaceRevisionResponse, error) {
+	if err := checkPlaceHistoryAccess(placeID, userID, "revisions"); err != nil {
+		return nil, err
+	}
+
+	revision, err := findPlaceRevision(placeID, revisionNumber)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := decodePlaceSnapshot(*revision)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.PlaceRevisionResponse{
+		PlaceRevisionSummary: mapPlaceRevisionToSummary(*revision),
+		Snapshot:             snapshot,
+	}, nil
+}
+
+// DiffPlaceRevisions lists the fields that changed from one revision to another
+func DiffPlaceRevisions(placeID uuid.UUID, fromNumber, toNumber int, userID uuid.UUID) (*dto.PlaceRevisionDiffResponse, error) {
+	if err := checkPlaceHistoryAccess(placeID, userID, "revisions"); err != nil {
+		return nil, err
+	}
+
+	from, err := findPlaceRevision(placeID, fromNumber)
+	if err != nil {
+		return nil, err
+	}
+	to, err := findPlaceRevision(placeID, toNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	fromSnapshot, err := decodePlaceSnapshot(*from)
+	if err != nil {
+		return nil, err
+	}
+	toSnapshot, err := decodePlaceSnapshot(*to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.PlaceRevisionDiffResponse{
+		PlaceID:      placeID,
+		FromRevision: fromNumber,
+		ToRevision:   toNumber,
+		Changes:      diffPlaceSnapshots(fromSnapshot, toSnapshot),
+	}, nil
+}
+
+// RestorePlaceRevision puts a place and its content sections back to the state of a revision.
+// The restore itself is recorded as a new revision, so it can be undone the same way, and
+// goes through review like any other edit.
+func RestorePlaceRevision(placeID uuid.UUID, revisionNumber int, userID uuid.UUID) (*dto.PlaceResponse, error) {
+	canModify, err := canUserModifyPlace(placeID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canModify {
+		return nil, errors.New("unauthorized: you don't have permission to restore this place")
+	}
+
+	revision, err := findPlaceRevision(placeID, revisionNumber)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := decodePlaceSnapshot(*revision)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := fmt.Sprintf("Restored revision %d", revisionNumber)
+	err = recordPlaceEdit(placeID, userID, domain.RevisionChangeRestore, summary, func(tx *gorm.DB) error {
+		var place domain.Place
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&place, "id = ?", placeID).Error; err != nil {
+			return err
+		}
+
+		// A map is used so empty strings and false are written as well
+		err := tx.Model(&place).Updates(map[string]interface{}{
+			"name_ar":        snapshot.NameAr,
+			"name_en":        snapshot.NameEn,
+			"description_ar": snapshot.DescriptionAr,
+			"description_en": snapshot.DescriptionEn,
+			"subtitle_ar":    snapshot.SubtitleAr,
+			"subtitle_en":    snapshot.SubtitleEn,
+			"governate_id":   snapshot.GovernateID,
+			"wilayah_id":     snapshot.WilayahID,
+			"latitude":       snapshot.Latitude,
+			"longitude":      snapshot.Longitude,
+			"phone":          snapshot.Phone,
+			"email":          snapshot.Email,
+			"website":        snapshot.Website,
+			"is_active":      snapshot.IsActive,
+		}).Error
+		if err != nil {
+			return fmt.Errorf("failed to restore place fields: %w", err)
+		}
+
+		// Categories and properties deleted since the revision are skipped
+		var categories []domain.Category
+		if len(snapshot.CategoryIDs) > 0 {
+			if err := tx.Where("id IN ?", snapshot.CategoryIDs).Find(&categories).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Model(&place).Association("Categories").Replace(&categories); err != nil {
+			return fmt.Errorf("failed to restore categories: %w", err)
+		}
+
+		if err := tx.Where("place_id = ?", placeID).Delete(&domain.PlaceProperty{}).Error; err != nil {
+			return fmt.Errorf("failed to restore properties: %w", err)
+		}
+		if len(snapshot.PropertyIDs) > 0 {
+			var propertyIDs []uuid.UUID
+			if err := tx.Model(&domain.Property{}).Where("id IN ?", snapshot.PropertyIDs).Pluck("id", &propertyIDs).Error; err != nil {
+				return err
+			}
+			for _, propertyID := range propertyIDs {
+				placeProperty := domain.PlaceProperty{PlaceID: placeID, PropertyID: propertyID}
+				if err := tx.Omit(clause.Associations).Create(&placeProperty).Error; err != nil {
+					return fmt.Errorf("failed to restore properties: %w", err)
+				}
+			}
+		}
+
+		if err := restoreContentSections(tx, placeID, snapshot.ContentSections); err != nil {
+			return err
+		}
+		return refreshPlaceSlugs(tx, placeID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := RefreshPlaceSearchDocument(placeID); err != nil {
+		fmt.Printf("⚠️ Failed to index place %s for search: %v\n", placeID, err)
+	}
+
+	return GetPlaceByID(placeID)
+}
+
+// restoreContentSections brings back sections of the snapshot, including soft-deleted ones,
+// and soft-deletes sections created after it. Section images are left untouched.
+func restoreContentSections(tx *gorm.DB, placeID uuid.UUID, sections []domain.ContentSectionSnapshot) error {
+	keep := make([]uuid.UUID, 0, len(sections))
+	for _, s := range sections {
+		keep = append(keep, s.ID)
+
+		result := tx.Unscoped().Model(&domain.PlaceContentSection{}).
+			Where("id = ? AND place_id = ?", s.ID, placeID).
+			Updates(map[string]interface{}{
+				"section_type": s.SectionType,
+				"title_ar":     s.TitleAr,
+				"title_en":     s.TitleEn,
+				"content_ar":   s.ContentAr,
+				"content_en":   s.ContentEn,
+				"sort_order":   s.SortOrder,
+				"is_active":    s.IsActive,
+				"deleted_at":   nil,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to restore content section: %w", result.Error)
+		}
+		if result.RowsAffected > 0 {
+			continue
+		}
+
+		// The section was removed permanently, recreate it with its original ID
+		section := domain.PlaceContentSection{
+			ID:          s.ID,
+			PlaceID:     placeID,
+			SectionType: s.SectionType,
+			TitleAr:     s.TitleAr,
+			TitleEn:     s.TitleEn,
+			ContentAr:   s.ContentAr,
+			ContentEn:   s.ContentEn,
+			SortOrder:   s.SortOrder,
+			IsActive:    s.IsActive,
+		}
+		if err := tx.Omit(clause.Associations).Create(&section).Error; err != nil {
+			return fmt.Errorf("failed to restore content section: %w", err)
+		}
+	}
+
+	query := tx.Where("place_id = ?", placeID)
+	if len(keep) > 0 {
+		query = query.Where("id NOT IN ?", keep)
+	}
+	if err := query.Delete(&domain.PlaceContentSection{}).Error; err != nil {
+		return fmt.Errorf("failed to remove content sections: %w", err)
+	}
+	return nil
+}
+
+// diffPlaceSnapshots compares two snapshots field by field
+func diffPlaceSnapshots(from, to domain.PlaceSnapshot) []dto.FieldChange {
+	changes := []dto.FieldChange{}
+	add := func(field string, oldValue, newValue interface{}) {
+		if !reflect.DeepEqual(oldValue, newValue) {
+			changes = append(changes, dto.FieldChange{Field: field, OldValue: oldValue, NewValue: newValue})
+		}
+	}
+
+	add("name_ar", from.NameAr, to.NameAr)
+	add("name_en", from.NameEn, to.NameEn)
+	add("description_ar", from.DescriptionAr, to.DescriptionAr)
+	add("description_en", from.DescriptionEn, to.DescriptionEn)
+	add("subtitle_ar", from.SubtitleAr, to.SubtitleAr)
+	add("subtitle_en", from.SubtitleEn, to.SubtitleEn)
+	add("governate_id", uuidValue(from.GovernateID), uuidValue(to.GovernateID))
+	add("wilayah_id", uuidValue(from.WilayahID), uuidValue(to.WilayahID))
+	add("latitude", from.Latitude, to.Latitude)
+	add("longitude", from.Longitude, to.Longitude)
+	add("phone", from.Phone, to.Phone)
+	add("email", from.Email, to.Email)
+	add("website", from.Website, to.Website)
+	add("is_active", from.IsActive, to.IsActive)
+	add("category_ids", sortedUUIDs(from.CategoryIDs), sortedUUIDs(to.CategoryIDs))
+	add("property_ids", sortedUUIDs(from.PropertyIDs), sortedUUIDs(to.PropertyIDs))
+
+	fromSections := make(map[uuid.UUID]domain.ContentSectionSnapshot)
+	for _, s := range from.ContentSections {
+		fromSections[s.ID] = s
+	}
+	toSections := make(map[uuid.UUID]domain.ContentSectionSnapshot)
+	for _, s := range to.ContentSections {
+		toSections[s.ID] = s
+	}
+
+	for _, old := range from.ContentSections {
+		prefix := "content_sections." + old.ID.String()
+		current, exists := toSections[old.ID]
+		if !exists {
+			changes = append(changes, dto.FieldChange{Field: prefix, OldValue: old, NewValue: nil})
+			continue
+		}
+		add(prefix+".section_type", old.SectionType, current.SectionType)
+		add(prefix+".title_ar", old.TitleAr, current.TitleAr)
+		add(prefix+".title_en", old.TitleEn, current.TitleEn)
+		add(prefix+".content_ar", old.ContentAr, current.ContentAr)
+		add(prefix+".content_en", old.ContentEn, current.ContentEn)
+		add(prefix+".sort_order", old.SortOrder, current.SortOrder)
+		add(prefix+".is_active", old.IsActive, current.IsActive)
+	}
+	for _, current := range to.ContentSections {
+		if _, exists := fromSections[current.ID]; !exists {
+			changes = append(changes, dto.FieldChange{Field: "content_sections." + current.ID.String(), OldValue: nil, NewValue: current})
+		}
+	}
+
+	return changes
+}
+
+// checkPlaceHistoryAccess allows the place's editors and moderators to read its history
+func checkPlaceHistoryAccess(placeID uuid.UUID, userID uuid.UUID, history string) error {
+	canModify, err := canUserModifyPlace(placeID, userID)
+	if err != nil {
+		return err
+	}
+	if canModify {
+		return nil
+	}
+
+	var user domain.User
+	if err := config.DB.Preload("Roles.Permissions").First(&user, "id = ?", userID).Error; err != nil {
+		return errors.New("user not found")
+	}
+	if !user.HasPermission("can_moderate_place") {
+		return fmt.Errorf("unauthorized: you don't have permission to view this place's %s", history)
+	}
+	return nil
+}
+
+func findPlaceRevision(placeID uuid.UUID, revisionNumber int) (*domain.PlaceRevision, error) {
+	var revision domain.PlaceRevision
+	err := config.DB.Preload("Creator").
+		Where("place_id = ? AND revision_number = ?", placeID, revisionNumber).
+		First(&revision).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("revision %d not found", revisionNumber)
+		}
+		return nil, err
+	}
+	return &revision, nil
+}
+
+func mapPlaceRevisionToSummary(revision domain.PlaceRevision) dto.PlaceRevisionSummary {
+	summary := dto.PlaceRevisionSummary{
+		ID:             revision.ID,
+		RevisionNumber: revision.RevisionNumber,
+		ChangeType:     revision.ChangeType,
+		Summary:        revision.Summary,
+		CreatedAt:      revision.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if revision.Creator.ID != uuid.Nil {
+		summary.Author = &dto.UserResponse{
+			ID:    revision.Creator.ID,
+			Name:  revision.Creator.GetFullName(),
+			Email: revision.Creator.Email,
+		}
+	}
+	return summary
+}
+
+func uuidValue(id *uuid.UUID) interface{} {
+	if id == nil {
+		return nil
+	}
+	return id.String()
+}
+
+func sortedUUIDs(ids []uuid.UUID) []string {
+	values := make([]string, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, id.String())
+	}
+	sort.Strings(values)
+	return values
+}
@@ -0,0 +1,274 @@
+// services/place_search_service.go
+package services
+
+import (
+	"almlah/config"
+	"almlah/internals/domain"
+	"almlah/internals/dto"
+	"almlah/internals/utils"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Relevance weights per matched field; name matches always outrank description matches
+const (
+	searchWeightName        = 10.0
+	searchWeightSubtitle    = 4.0
+	searchWeightCategory    = 3.0
+	searchWeightLocation    = 2.0
+	searchWeightDescription = 1.0
+
+	// Extra score when a whole query word appears as a word in the name
+	searchWeightNameWord = 5.0
+
+	searchSnippetLength = 160
+)
+
+const searchDocumentText = `(d.name_text || ' ' || d.subtitle_text || ' ' || d.category_text || ' ' ||
+	d.location_text || ' ' || d.description_text)`
+
+type placeScore struct {
+	ID    uuid.UUID
+	Score float64
+}
+
+// RefreshPlaceSearchDocument rebuilds the normalized search document of a single place
+func RefreshPlaceSearchDocument(placeID uuid.UUID) error {
+	var place domain.Place
+	err := config.DB.Preload("Categories").
+		Preload("Governate").
+		Preload("Wilayah").
+		First(&place, "id = ?", placeID).Error
+	if err != nil {
+		return err
+	}
+
+	doc := buildPlaceSearchDocument(place)
+
+	return config.DB.Omit(clause.Associations).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "place_id"}},
+			UpdateAll: true,
+		}).
+		Create(&doc).Error
+}
+
+// RebuildPlaceSearchDocuments reindexes every place, e.g. after data was changed outside the API
+func RebuildPlaceSearchDocuments() (int, error) {
+	var placeIDs []uuid.UUID
+	if err := config.DB.Model(&domain.Place{}).Pluck("id", &placeIDs).Error; err != nil {
+		return 0, fmt.Errorf("failed to list places: %w", err)
+	}
+	return refreshPlaceSearchDocuments(placeIDs)
+}
+
+// EnsurePlaceSearchDocuments indexes places that don't have a search document yet
+func EnsurePlaceSearchDocuments() (int, error) {
+	var placeIDs []uuid.UUID
+	err := config.DB.Model(&domain.Place{}).
+		Where("id NOT IN (SELECT place_id FROM place_search_documents)").
+		Pluck("id", &placeIDs).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to list unindexed places: %w", err)
+	}
+	return refreshPlaceSearchDocuments(placeIDs)
+}
+
+// RefreshCategorySearchDocuments reindexes the places of a category after it's renamed
+func RefreshCategorySearchDocuments(categoryID uuid.UUID) (int, error) {
+	return refreshPlaceSearchDocumentsWhere("id IN (SELECT place_id FROM place_categories WHERE category_id = ?)", categoryID)
+}
+
+// RefreshGovernateSearchDocuments reindexes the places of a governate after it's renamed
+func RefreshGovernateSearchDocuments(governateID uuid.UUID) (int, error) {
+	return refreshPlaceSearchDocumentsWhere("governate_id = ?", governateID)
+}
+
+// RefreshWilayahSearchDocuments reindexes the places of a wilayah after it's renamed
+func RefreshWilayahSearchDocuments(wilayahID uuid.UUID) (int, error) {
+	return refreshPlaceSearchDocumentsWhere("wilayah_id = ?", wilayahID)
+}
+
+func refreshPlaceSearchDocumentsWhere(query string, args ...interface{}) (int, error) {
+	var placeIDs []uuid.UUID
+	if err := config.DB.Model(&domain.Place{}).Where(query, args...).Pluck("id", &placeIDs).Error; err != nil {
+		return 0, fmt.Errorf("failed to list places: %w", err)
+	}
+	return refreshPlaceSearchDocuments(placeIDs)
+}
+
+func refreshPlaceSearchDocuments(placeIDs []uuid.UUID) (int, error) {
+	indexed := 0
+	for _, placeID := range placeIDs {
+		if err := RefreshPlaceSearchDocument(placeID); err != nil {
+			return indexed, fmt.Errorf("failed to index place %s: %w", placeID, err)
+		}
+		indexed++
+	}
+	return indexed, nil
+}
+
+// SearchPlacesRanked finds active places matching every word of the query, ordered by relevance
+func SearchPlacesRanked(req dto.PlaceSearchRequest) (*dto.PaginationResponse, error) {
+	req.SetDefaults()
+
+	terms := utils.SearchTerms(req.Query)
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("search query is required")
+	}
+
+	matching := func() *gorm.DB {
+		query := config.DB.Table("places").
+			Joins("JOIN place_search_documents d ON d.place_id = places.id").
+			Where("places.is_active = ? AND places.deleted_at IS NULL", true).
+			Scopes(publishedPlacesOnly)
+		for _, term := range terms {
+			query = query.Where(searchDocumentText+" LIKE ?", "%"+escapeLike(term)+"%")
+		}
+		if req.OpenAt != nil {
+			query = query.Scopes(placesOpenAt(*req.OpenAt))
+		}
+		return query
+	}
+
+	var total int64
+	if err := matching().Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count search results: %w", err)
+	}
+
+	scoreSQL, scoreArgs := searchScoreExpression(terms)
+
+	var scores []placeScore
+	err := matching().
+		Select("places.id, ("+scoreSQL+") AS score", scoreArgs...).
+		Order("score DESC").
+		Order("places.name_en ASC").
+		Offset((req.Page - 1) * req.Limit).
+		Limit(req.Limit).
+		Scan(&scores).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to search places: %w", err)
+	}
+
+	results := make([]dto.PlaceSearchResult, 0, len(scores))
+	if len(scores) > 0 {
+		placeIDs := make([]uuid.UUID, len(scores))
+		for i, s := range scores {
+			placeIDs[i] = s.ID
+		}
+
+		placesByID, err := loadListPlacesByIDs(placeIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load search results: %w", err)
+		}
+
+		for _, s := range scores {
+			place, ok := placesByID[s.ID]
+			if !ok {
+				continue
+			}
+			item := mapPlaceToListResponse(place)
+			if req.OpenAt != nil {
+				isOpen := true
+				item.IsOpen = &isOpen
+			}
+			results = append(results, dto.PlaceSearchResult{
+				PlaceListResponse: item,
+				Score:             s.Score,
+				Highlights:        highlightPlace(place, terms),
+			})
+		}
+	}
+
+	totalPages := int((total + int64(req.Limit) - 1) / int64(req.Limit))
+
+	return &dto.PaginationResponse{
+		Page:       req.Page,
+		Limit:      req.Limit,
+		Total:      total,
+		TotalPages: totalPages,
+		Data:       results,
+	}, nil
+}
+
+func buildPlaceSearchDocument(place domain.Place) domain.PlaceSearchDocument {
+	var categories []string
+	for _, category := range place.Categories {
+		categories = append(categories, category.NameAr, category.NameEn)
+	}
+
+	var locations []string
+	if place.Wilayah != nil {
+		locations = append(locations, place.Wilayah.NameAr, place.Wilayah.NameEn)
+	}
+	if place.Governate != nil {
+		locations = append(locations, place.Governate.NameAr, place.Governate.NameEn)
+	}
+
+	return domain.PlaceSearchDocument{
+		PlaceID:         place.ID,
+		NameText:        normalizeJoined(place.NameAr, place.NameEn),
+		SubtitleText:    normalizeJoined(place.SubtitleAr, place.SubtitleEn),
+		CategoryText:    normalizeJoined(categories...),
+		LocationText:    normalizeJoined(locations...),
+		DescriptionText: normalizeJoined(place.DescriptionAr, place.DescriptionEn),
+	}
+}
+
+func normalizeJoined(parts ...string) string {
+	return utils.NormalizeSearchText(strings.Join(parts, " "))
+}
+
+// searchScoreExpression builds the SQL relevance score and its bind arguments
+func searchScoreExpression(terms []string) (string, []interface{}) {
+	fields := []struct {
+		column string
+		weight float64
+	}{
+		{"d.name_text", searchWeightName},
+		{"d.subtitle_text", searchWeightSubtitle},
+		{"d.category_text", searchWeightCategory},
+		{"d.location_text", searchWeightLocation},
+		{"d.description_text", searchWeightDescription},
+	}
+
+	var parts []string
+	var args []interface{}
+	for _, term := range terms {
+		pattern := "%" + escapeLike(term) + "%"
+		for _, field := range fields {
+			parts = append(parts, fmt.Sprintf("CASE WHEN %s LIKE ? THEN %g ELSE 0 END", field.column, field.weight))
+			args = append(args, pattern)
+		}
+		parts = append(parts, fmt.Sprintf("CASE WHEN ' ' || d.name_text || ' ' LIKE ? THEN %g ELSE 0 END", searchWeightNameWord))
+		args = append(args, "% "+escapeLike(term)+" %")
+	}
+
+	return strings.Join(parts, " + "), args
+}
+
+func highlightPlace(place domain.Place, terms []string) dto.PlaceSearchHighlights {
+	highlights := dto.PlaceSearchHighlights{
+		NameAr: utils.HighlightSnippet(place.NameAr, terms, 0),
+		NameEn: utils.HighlightSnippet(place.NameEn, terms, 0),
+	}
+
+	for _, text := range []string{place.SubtitleAr, place.SubtitleEn, place.DescriptionAr, place.DescriptionEn} {
+		if snippet := utils.HighlightSnippet(text, terms, searchSnippetLength); snippet != "" {
+			highlights.Snippet = snippet
+			break
+		}
+	}
+
+	return highlights
+}
+
+// escapeLike escapes LIKE wildcards so user input is matched literally
+func escapeLike(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+	return replacer.Replace(s)
+}
@@ -62,6 +62,20 @@ func CreatePlace(req dto.CreatePlaceRequest, userID uuid.UUID) (*dto.PlaceRespon
 		}
 	}
 
+	externalRef := strings.TrimSpace(req.ExternalRef)
+	if externalRef != "" {
+		var count int64
+		config.DB.Unscoped().Model(&domain.Place{}).Where("external_ref = ?", externalRef).Count(&count)
+		if count > 0 {
+			return nil, fmt.Errorf("a place with external reference %s already exists", externalRef)
+		}
+	}
+
+	duplicates, err := checkDuplicatesBeforeCreate(req, userID)
+	if err != nil {
+		return nil, err
+	}
+
 	place := domain.Place{
 		NameAr:        req.NameAr,
 		NameEn:        req.NameEn,
@@ -76,11 +90,20 @@ func CreatePlace(req dto.CreatePlaceRequest, userID uuid.UUID) (*dto.PlaceRespon
 		Phone:         req.Phone,
 		Email:         req.Email,
 		Website:       req.Website,
+		ExternalRef:   externalRef,
 		CreatedBy:     userID,
 		IsActive:      true,
+		Status:        domain.PlaceStatusDraft, // Goes live only after a moderator approves it
 	}
 
-	if err := config.DB.Create(&place).Error; err != nil {
+	// The slugs are generated with the place so it never exists without them
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&place).Error; err != nil {
+			return err
+		}
+		return refreshPlaceSlugs(tx, place.ID)
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -165,7 +188,20 @@ func CreatePlace(req dto.CreatePlaceRequest, userID uuid.UUID) (*dto.PlaceRespon
 		}
 	}
 
-	return GetPlaceByID(place.ID)
+	if err := RefreshPlaceSearchDocument(place.ID); err != nil {
+		fmt.Printf("⚠️ Failed to index place %s for search: %v\n", place.ID, err)
+	}
+
+	if err := RecordPlaceRevision(place.ID, userID, domain.RevisionChangeCreate, "Created place"); err != nil {
+		fmt.Printf("⚠️ Failed to record revision for place %s: %v\n", place.ID, err)
+	}
+
+	response, err := GetPlaceByID(place.ID)
+	if err != nil {
+		return nil, err
+	}
+	response.DuplicateCandidates = duplicates
+	return response, nil
 }
 
 
@@ -177,6 +213,7 @@ func GetPlaces() ([]dto.PlaceListResponse, error) {
 		Preload("Governate").
 		Preload("Wilayah").
 		Where("is_active = ?", true).
+		Scopes(publishedPlacesOnly).
 		Find(&places).Error; err != nil {
 		return nil, err
 	}
@@ -196,7 +233,6 @@ func GetPlaceByID(id uuid.UUID) (*dto.PlaceResponse, error) {
 	err := config.DB.Preload("Categories").
 		Preload("Properties.Property").
 		Preload("Images").
-		Preload("Reviews").
 		Preload("Creator").
 		Preload("Governate").
 		Preload("Wilayah").
@@ -214,16 +250,6 @@ func GetPlaceByID(id uuid.UUID) (*dto.PlaceResponse, error) {
 
 	response := mapPlaceToResponse(place)
 
-	// Calculate rating and review count
-	if len(place.Reviews) > 0 {
-		var totalRating int
-		for _, review := range place.Reviews {
-			totalRating += review.Rating
-		}
-		response.Rating = float64(totalRating) / float64(len(place.Reviews))
-		response.ReviewCount = len(place.Reviews)
-	}
-
 	return &response, nil
 }
 
@@ -311,40 +337,60 @@ func UpdatePlace(id uuid.UUID, req dto.UpdatePlaceRequest, userID uuid.UUID) (*d
 		place.IsActive = *req.IsActive
 	}
 
-	err = config.DB.Save(&place).Error
-	if err != nil {
-		return nil, err
-	}
-
-	// Update categories if provided
-	if len(req.CategoryIDs) > 0 {
-		// Ensure parent categories are included
-		allCategoryIDs, err := ensureParentCategoriesIncluded(req.CategoryIDs)
-		if err != nil {
-			return nil, fmt.Errorf("failed to process categories: %v", err)
+	err = recordPlaceEdit(place.ID, userID, domain.RevisionChangeUpdate, "Updated place", func(tx *gorm.DB) error {
+		// The status only changes through the moderation workflow
+		omit := append([]string{"status"}, domain.PlaceRatingColumns...)
+		if err := tx.Omit(omit...).Save(&place).Error; err != nil {
+			return err
+		}
+		if err := refreshPlaceSlugs(tx, place.ID); err != nil {
+			return fmt.Errorf("failed to generate slugs: %v", err)
 		}
 
-		var categories []domain.Category
-		config.DB.Where("id IN ?", allCategoryIDs).Find(&categories)
+		// Update categories if provided
+		if len(req.CategoryIDs) > 0 {
+			// Ensure parent categories are included
+			allCategoryIDs, err := ensureParentCategoriesIncluded(req.CategoryIDs)
+			if err != nil {
+				return fmt.Errorf("failed to process categories: %v", err)
+			}
+
+			var categories []domain.Category
+			if err := tx.Where("id IN ?", allCategoryIDs).Find(&categories).Error; err != nil {
+				return fmt.Errorf("failed to update categories: %v", err)
+			}
 
-		if err := config.DB.Model(&place).Association("Categories").Replace(&categories); err != nil {
-			return nil, fmt.Errorf("failed to update categories: %v", err)
+			if err := tx.Model(&place).Association("Categories").Replace(&categories); err != nil {
+				return fmt.Errorf("failed to update categories: %v", err)
+			}
 		}
-	}
 
-	// Update properties if provided
-	if len(req.PropertyIDs) > 0 {
-		// Remove existing properties
-		config.DB.Where("place_id = ?", place.ID).Delete(&domain.PlaceProperty{})
+		// Update properties if provided
+		if len(req.PropertyIDs) > 0 {
+			// Remove existing properties
+			if err := tx.Where("place_id = ?", place.ID).Delete(&domain.PlaceProperty{}).Error; err != nil {
+				return fmt.Errorf("failed to update properties: %v", err)
+			}
 
-		// Add new properties
-		for _, propertyID := range req.PropertyIDs {
-			placeProperty := domain.PlaceProperty{
-				PlaceID:    place.ID,
-				PropertyID: propertyID,
+			// Add new properties
+			for _, propertyID := range req.PropertyIDs {
+				placeProperty := domain.PlaceProperty{
+					PlaceID:    place.ID,
+					PropertyID: propertyID,
+				}
+				if err := tx.Create(&placeProperty).Error; err != nil {
+					return fmt.Errorf("failed to update properties: %v", err)
+				}
 			}
-			config.DB.Create(&placeProperty)
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := RefreshPlaceSearchDocument(place.ID); err != nil {
+		fmt.Printf("⚠️ Failed to index place %s for search: %v\n", place.ID, err)
 	}
 
 	return GetPlaceByID(place.ID)
@@ -378,6 +424,7 @@ func GetPlacesByCategory(categoryID uuid.UUID) ([]dto.PlaceListResponse, error)
 
 	err := config.DB.Joins("JOIN place_categories ON places.id = place_categories.place_id").
 		Where("place_categories.category_id = ? AND places.is_active = ?", categoryID, true).
+		Scopes(publishedPlacesOnly).
 		Preload("Categories").
 		Preload("Images").
 		Preload("Governate").
@@ -400,6 +447,7 @@ func GetPlacesByGovernate(governateID uuid.UUID) ([]dto.PlaceListResponse, error
 	var places []domain.Place
 
 	err := config.DB.Where("governate_id = ? AND is_active = ?", governateID, true).
+		Scopes(publishedPlacesOnly).
 		Preload("Categories").
 		Preload("Images").
 		Preload("Governate").
@@ -422,29 +470,7 @@ func GetPlacesByWilayah(wilayahID uuid.UUID) ([]dto.PlaceListResponse, error) {
 	var places []domain.Place
 
 	err := config.DB.Where("wilayah_id = ? AND is_active = ?", wilayahID, true).
-		Preload("Categories").
-		Preload("Images").
-		Preload("Governate").
-		Preload("Wilayah").
-		Find(&places).Error
-
-	if err != nil {
-		return nil, err
-	}
-
-	var response []dto.PlaceListResponse
-	for _, place := range places {
-		response = append(response, mapPlaceToListResponse(place))
-	}
-
-	return response, nil
-}
-
-func SearchPlaces(query string) ([]dto.PlaceListResponse, error) {
-	var places []domain.Place
-
-	err := config.DB.Where("(name_ar ILIKE ? OR name_en ILIKE ? OR description_ar ILIKE ? OR description_en ILIKE ?) AND is_active = ?",
-		"%"+query+"%", "%"+query+"%", "%"+query+"%", "%"+query+"%", true).
+		Scopes(publishedPlacesOnly).
 		Preload("Categories").
 		Preload("Images").
 		Preload("Governate").
@@ -472,6 +498,11 @@ func GetPlacesByFilters(categoryID uuid.UUID, governateID uuid.UUID) ([]dto.Plac
         "places.id",
         "places.name_ar",
         "places.name_en",
+        "places.slug",
+        "places.slug_ar",
+        "places.rating_average",
+        "places.review_count",
+        "places.last_review_at",
         "places.description_ar",
         "places.description_en", 
         "places.subtitle_ar",
@@ -480,12 +511,13 @@ func GetPlacesByFilters(categoryID uuid.UUID, governateID uuid.UUID) ([]dto.Plac
         "places.longitude",     // FIXED: Include coordinates
         "places.governate_id",
         "places.wilayah_id",
+        "places.status",
         "places.created_at",
         "places.updated_at",
     )
     
     // Add is_active filter
-    query = query.Where("places.is_active = ?", true)
+    query = query.Where("places.is_active = ?", true).Scopes(publishedPlacesOnly)
     
     if categoryID != uuid.Nil {
         query = query.Joins("JOIN place_categories ON places.id = place_categories.place_id").
@@ -554,24 +586,33 @@ func CreatePlaceContentSection(placeID uuid.UUID, req dto.CreateContentSectionRe
 		IsActive:    true,
 	}
 
-	if err := config.DB.Create(&section).Error; err != nil {
-		return nil, err
-	}
+	summary := "Added content section: " + section.TitleEn
+	err = recordPlaceEdit(placeID, userID, domain.RevisionChangeContentSection, summary, func(tx *gorm.DB) error {
+		if err := tx.Create(&section).Error; err != nil {
+			return err
+		}
 
-	// Create section images if provided and valid
-	for _, imgReq := range req.Images {
-		if imgReq.ImageURL != "" {
-			sectionImage := domain.PlaceContentSectionImage{
-				SectionID: section.ID,
-				ImageURL:  imgReq.ImageURL,
-				AltTextAr: imgReq.AltTextAr,
-				AltTextEn: imgReq.AltTextEn,
-				CaptionAr: imgReq.CaptionAr,
-				CaptionEn: imgReq.CaptionEn,
-				SortOrder: imgReq.SortOrder,
+		// Create section images if provided and valid
+		for _, imgReq := range req.Images {
+			if imgReq.ImageURL != "" {
+				sectionImage := domain.PlaceContentSectionImage{
+					SectionID: section.ID,
+					ImageURL:  imgReq.ImageURL,
+					AltTextAr: imgReq.AltTextAr,
+					AltTextEn: imgReq.AltTextEn,
+					CaptionAr: imgReq.CaptionAr,
+					CaptionEn: imgReq.CaptionEn,
+					SortOrder: imgReq.SortOrder,
+				}
+				if err := tx.Create(&sectionImage).Error; err != nil {
+					return err
+				}
 			}
-			config.DB.Create(&sectionImage)
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return GetContentSectionByID(section.ID)
@@ -616,7 +657,10 @@ func UpdatePlaceContentSection(id uuid.UUID, req dto.UpdateContentSectionRequest
 		section.IsActive = *req.IsActive
 	}
 
-	err = config.DB.Save(&section).Error
+	summary := "Updated content section: " + section.TitleEn
+	err = recordPlaceEdit(section.PlaceID, userID, domain.RevisionChangeContentSection, summary, func(tx *gorm.DB) error {
+		return tx.Omit("Place").Save(&section).Error
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -640,7 +684,10 @@ func DeletePlaceContentSection(id uuid.UUID, userID uuid.UUID) error {
 		return errors.New("insufficient permissions to delete this content section")
 	}
 
-	return config.DB.Delete(&section).Error
+	summary := "Removed content section: " + section.TitleEn
+	return recordPlaceEdit(section.PlaceID, userID, domain.RevisionChangeContentSection, summary, func(tx *gorm.DB) error {
+		return tx.Delete(&section).Error
+	})
 }
 
 
@@ -789,6 +836,8 @@ func mapPlaceToResponse(place domain.Place) dto.PlaceResponse {
 		ID:              place.ID,
 		NameAr:          place.NameAr,
 		NameEn:          place.NameEn,
+		Slug:            place.Slug,
+		SlugAr:          place.SlugAr,
 		DescriptionAr:   place.DescriptionAr,
 		DescriptionEn:   place.DescriptionEn,
 		SubtitleAr:      place.SubtitleAr,
@@ -800,7 +849,12 @@ func mapPlaceToResponse(place domain.Place) dto.PlaceResponse {
 		Phone:           place.Phone,
 		Email:           place.Email,
 		Website:         place.Website,
+		Rating:          place.RatingAverage,
+		ReviewCount:     place.ReviewCount,
+		RatingDistribution: placeRatingDistribution(place),
+		LastReviewAt:    place.LastReviewAt,
 		IsActive:        place.IsActive,
+		Status:          place.Status,
 		Categories:      categories,
 		Properties:      properties,
 		Images:          images,
@@ -860,22 +914,12 @@ func mapPlaceToListResponse(place domain.Place) dto.PlaceListResponse {
 		}
 	}
 
-	// Calculate rating and review count (simplified for list view)
-	var rating float64
-	var reviewCount int
-	if len(place.Reviews) > 0 {
-		var totalRating int
-		for _, review := range place.Reviews {
-			totalRating += review.Rating
-		}
-		rating = float64(totalRating) / float64(len(place.Reviews))
-		reviewCount = len(place.Reviews)
-	}
-
 	return dto.PlaceListResponse{
 		ID:            place.ID,
 		NameAr:        place.NameAr,
 		NameEn:        place.NameEn,
+		Slug:          place.Slug,
+		SlugAr:        place.SlugAr,
 		DescriptionAr: place.DescriptionAr,
 		DescriptionEn: place.DescriptionEn,
 		SubtitleAr:    place.SubtitleAr,
@@ -884,10 +928,12 @@ func mapPlaceToListResponse(place domain.Place) dto.PlaceListResponse {
 		Wilayah:       wilayah,
 		Latitude:      place.Latitude,    // FIXED: Include coordinates
 		Longitude:     place.Longitude,   // FIXED: Include coordinates
-		Rating:        rating,
-		ReviewCount:   reviewCount,
+		Rating:        place.RatingAverage,
+		ReviewCount:   place.ReviewCount,
+		LastReviewAt:  place.LastReviewAt,
 		Categories:    categories,
 		PrimaryImage:  primaryImage,
+		Status:        place.Status,
 	}
 }
 
@@ -925,6 +971,15 @@ func mapContentSectionToResponse(section domain.PlaceContentSection) dto.Content
 
 // New function with language parameter support
 func GetPlaceByIDWithLanguage(id uuid.UUID, lang string) (*dto.PlaceResponseLocalized, error) {
+	return getPlaceByIDWithLanguage(id, lang, publishedPlacesOnly)
+}
+
+// GetPlacePreviewWithLanguage returns a place whatever its status, for preview links
+func GetPlacePreviewWithLanguage(id uuid.UUID, lang string) (*dto.PlaceResponseLocalized, error) {
+	return getPlaceByIDWithLanguage(id, lang, func(db *gorm.DB) *gorm.DB { return db })
+}
+
+func getPlaceByIDWithLanguage(id uuid.UUID, lang string, visibility func(*gorm.DB) *gorm.DB) (*dto.PlaceResponseLocalized, error) {
 	var place domain.Place
 
 	// FIXED: Make sure to preload ALL necessary relationships including Images
@@ -933,7 +988,6 @@ func GetPlaceByIDWithLanguage(id uuid.UUID, lang string) (*dto.PlaceResponseLoca
 		Preload("Images", func(db *gorm.DB) *gorm.DB {
 			return db.Order("is_primary DESC, display_order ASC") // Primary image first, then by display order
 		}).
-		Preload("Reviews").
 		Preload("Creator").
 		Preload("Governate").
 		Preload("Wilayah").
@@ -943,6 +997,7 @@ func GetPlaceByIDWithLanguage(id uuid.UUID, lang string) (*dto.PlaceResponseLoca
 		Preload("ContentSections.Images", func(db *gorm.DB) *gorm.DB {
 			return db.Order("sort_order ASC")
 		}).
+		Scopes(visibility).
 		First(&place, id).Error
 
 	if err != nil {
@@ -952,16 +1007,6 @@ func GetPlaceByIDWithLanguage(id uuid.UUID, lang string) (*dto.PlaceResponseLoca
 	// FIXED: Use the correct mapper function for localized response
 	response := mapPlaceToLocalizedResponse(place, lang)
 
-	// Calculate rating and review count
-	if len(place.Reviews) > 0 {
-		var totalRating int
-		for _, review := range place.Reviews {
-			totalRating += review.Rating
-		}
-		response.Rating = float64(totalRating) / float64(len(place.Reviews))
-		response.ReviewCount = len(place.Reviews)
-	}
-
 	return &response, nil
 }
 
@@ -1029,6 +1074,8 @@ func mapPlaceToLocalizedResponse(place domain.Place, lang string) dto.PlaceRespo
 	return dto.PlaceResponseLocalized{
 		ID:              place.ID,
 		Name:            place.GetName(lang),
+		Slug:            place.GetSlug(lang),
+		AlternateSlug:   place.GetSlug(otherLanguage(lang)),
 		Description:     place.GetDescription(lang),
 		Subtitle:        place.GetSubtitle(lang),
 		Governate:       governate,
@@ -1038,7 +1085,12 @@ func mapPlaceToLocalizedResponse(place domain.Place, lang string) dto.PlaceRespo
 		Phone:           place.Phone,
 		Email:           place.Email,
 		Website:         place.Website,
+		Rating:          place.RatingAverage,
+		ReviewCount:     place.ReviewCount,
+		RatingDistribution: placeRatingDistribution(place),
+		LastReviewAt:    place.LastReviewAt,
 		IsActive:        place.IsActive,
+		Status:          place.Status,
 		Categories:      categories,
 		Properties:      properties,
 		Images:          images, // FIXED: All images properly mapped
@@ -1089,7 +1141,6 @@ func GetPlaceCompleteByID(placeID uuid.UUID) (*dto.PlaceResponse, error) {
 		Preload("Images", func(db *gorm.DB) *gorm.DB {
 			return db.Order("is_primary DESC, display_order ASC")
 		}).
-		Preload("Reviews").
 		Preload("Creator").
 		Preload("Governate").
 		Preload("Wilayah").
@@ -1100,6 +1151,7 @@ func GetPlaceCompleteByID(placeID uuid.UUID) (*dto.PlaceResponse, error) {
 			return db.Order("sort_order ASC")
 		}).
 		Where("is_active = ?", true).
+		Scopes(publishedPlacesOnly).
 		First(&place, placeID).Error
 	
 	if err != nil {
@@ -1109,16 +1161,6 @@ func GetPlaceCompleteByID(placeID uuid.UUID) (*dto.PlaceResponse, error) {
 	// Use your existing mapper function
 	response := mapPlaceToResponse(place)
 
-	// Calculate rating and review count using your existing pattern
-	if len(place.Reviews) > 0 {
-		var totalRating int
-		for _, review := range place.Reviews {
-			totalRating += review.Rating
-		}
-		response.Rating = float64(totalRating) / float64(len(place.Reviews))
-		response.ReviewCount = len(place.Reviews)
-	}
-
 	return &response, nil
 }
 
@@ -1160,3 +1202,24 @@ func ensureParentCategoriesIncluded(categoryIDs []uuid.UUID) ([]uuid.UUID, error
 
 	return result, nil
 }
+
+// loadListPlacesByIDs loads places with the relations needed by mapPlaceToListResponse,
+// keyed by ID so callers can restore their own ordering
+func loadListPlacesByIDs(placeIDs []uuid.UUID) (map[uuid.UUID]domain.Place, error) {
+	var places []domain.Place
+	err := config.DB.Preload("Categories").
+		Preload("Images").
+		Preload("Governate").
+		Preload("Wilayah").
+		Where("id IN ?", placeIDs).
+		Find(&places).Error
+	if err != nil {
+		return nil, err
+	}
+
+	placesByID := make(map[uuid.UUID]domain.Place, len(places))
+	for _, place := range places {
+		placesByID[place.ID] = place
+	}
+	return placesByID, nil
+}
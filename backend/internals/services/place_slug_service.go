@@ -0,0 +1,229 @@
+// services/place_slug_service.go
+package services
+
+import (
+	"almlah/config"
+	"almlah/internals/domain"
+	"almlah/internals/dto"
+	"almlah/internals/utils"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Used when a name has no letters or digits to build a slug from
+const fallbackPlaceSlug = "place"
+
+// RefreshPlaceSlugs regenerates the slugs of a place from its names. Slugs that change are
+// kept in the slug history so links to the old URL keep working.
+func RefreshPlaceSlugs(placeID uuid.UUID) error {
+	return config.DB.Transaction(func(tx *gorm.DB) error {
+		return refreshPlaceSlugs(tx, placeID)
+	})
+}
+
+// Attempts at saving new slugs before giving up when concurrent saves keep taking them
+const placeSlugAttempts = 5
+
+// refreshPlaceSlugs does the work of RefreshPlaceSlugs inside the caller's transaction. Each
+// attempt runs in a savepoint, so a slug taken by a concurrent save between the check and the
+// write is retried with the next free suffix instead of failing the whole transaction.
+func refreshPlaceSlugs(tx *gorm.DB, placeID uuid.UUID) error {
+	for attempt := 1; ; attempt++ {
+		err := tx.Transaction(func(tx *gorm.DB) error {
+			var place domain.Place
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&place, "id = ?", placeID).Error; err != nil {
+				return err
+			}
+
+			slug, err := nextPlaceSlug(tx, place.ID, place.Slug, place.NameEn, "en")
+			if err != nil {
+				return err
+			}
+			slugAr, err := nextPlaceSlug(tx, place.ID, place.SlugAr, place.NameAr, "ar")
+			if err != nil {
+				return err
+			}
+			if slug == place.Slug && slugAr == place.SlugAr {
+				return nil
+			}
+
+			// UpdateColumns leaves updated_at alone, the place itself did not change
+			return tx.Model(&place).UpdateColumns(map[string]interface{}{
+				"slug":    slug,
+				"slug_ar": slugAr,
+			}).Error
+		})
+		if err == nil || !isUniqueViolation(err) || attempt == placeSlugAttempts {
+			return err
+		}
+	}
+}
+
+// isUniqueViolation reports whether err is Postgres rejecting a duplicate key
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+// EnsurePlaceSlugs generates slugs for places created before slugs existed
+func EnsurePlaceSlugs() (int, error) {
+	var placeIDs []uuid.UUID
+	err := config.DB.Model(&domain.Place{}).
+		Where("slug IS NULL OR slug = '' OR slug_ar IS NULL OR slug_ar = ''").
+		Order("created_at ASC").
+		Pluck("id", &placeIDs).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to list places without slugs: %w", err)
+	}
+
+	updated := 0
+	for _, placeID := range placeIDs {
+		if err := RefreshPlaceSlugs(placeID); err != nil {
+			return updated, fmt.Errorf("failed to generate slugs for place %s: %w", placeID, err)
+		}
+		updated++
+	}
+	return updated, nil
+}
+
+// nextPlaceSlug returns the slug a place should have for the given name. The current slug is
+// kept while it still matches the name, so saving a place doesn't change its URL.
+func nextPlaceSlug(tx *gorm.DB, placeID uuid.UUID, current string, name string, language string) (string, error) {
+	base := utils.Slugify(name)
+	if base == "" {
+		base = fallbackPlaceSlug
+	}
+	if slugMatchesBase(current, base) {
+		return current, nil
+	}
+
+	slug, err := uniquePlaceSlug(tx, placeID, base)
+	if err != nil {
+		return "", err
+	}
+
+	if current != "" {
+		retired := domain.PlaceSlugHistory{PlaceID: placeID, Slug: current, Language: language}
+		if err := tx.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(&retired).Error; err != nil {
+			return "", fmt.Errorf("failed to keep old slug: %w", err)
+		}
+	}
+
+	// A place renamed back to an earlier name takes its old slug back
+	if err := tx.Where("place_id = ? AND slug = ?", placeID, slug).Delete(&domain.PlaceSlugHistory{}).Error; err != nil {
+		return "", err
+	}
+
+	return slug, nil
+}
+
+// uniquePlaceSlug appends -2, -3... to base until no other place uses it, now or in its history.
+// English and Arabic slugs share one namespace since the lookup accepts either.
+func uniquePlaceSlug(tx *gorm.DB, placeID uuid.UUID, base string) (string, error) {
+	for n := 1; ; n++ {
+		candidate := base
+		if n > 1 {
+			candidate = fmt.Sprintf("%s-%d", base, n)
+		}
+
+		var taken int64
+		err := tx.Unscoped().Model(&domain.Place{}).
+			Where("(slug = ? OR slug_ar = ?) AND id <> ?", candidate, candidate, placeID).
+			Count(&taken).Error
+		if err != nil {
+			return "", fmt.Errorf("failed to check slug: %w", err)
+		}
+		if taken > 0 {
+			continue
+		}
+
+		err = tx.Model(&domain.PlaceSlugHistory{}).
+			Where("slug = ? AND place_id <> ?", candidate, placeID).
+			Count(&taken).Error
+		if err != nil {
+			return "", fmt.Errorf("failed to check slug history: %w", err)
+		}
+		if taken == 0 {
+			return candidate, nil
+		}
+	}
+}
+
+// slugMatchesBase reports whether slug is base itself or base with a collision suffix
+func slugMatchesBase(slug, base string) bool {
+	if slug == base {
+		return true
+	}
+	suffix, found := strings.CutPrefix(slug, base+"-")
+	if !found {
+		return false
+	}
+	n, err := strconv.Atoi(suffix)
+	return err == nil && n > 1
+}
+
+// GetPlaceBySlug finds a published place by its English or Arabic slug. When the slug is an
+// old one, the place is still returned along with its current slug as a redirect hint.
+func GetPlaceBySlug(slug string, lang string) (*dto.PlaceSlugResponse, error) {
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	if slug == "" {
+		return nil, errors.New("place not found")
+	}
+
+	var place domain.Place
+	err := config.DB.Select("id", "slug", "slug_ar").
+		Where("slug = ? OR slug_ar = ?", slug, slug).
+		Scopes(publishedPlacesOnly).
+		First(&place).Error
+	if err == nil {
+		return placeSlugResponse(place.ID, lang, slug, "")
+	}
+	if err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+
+	var history domain.PlaceSlugHistory
+	if err := config.DB.Where("slug = ?", slug).First(&history).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("place not found")
+		}
+		return nil, err
+	}
+
+	if err := config.DB.Select("id", "slug", "slug_ar").Scopes(publishedPlacesOnly).First(&place, "id = ?", history.PlaceID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("place not found")
+		}
+		return nil, err
+	}
+
+	return placeSlugResponse(place.ID, lang, place.GetSlug(history.Language), slug)
+}
+
+func placeSlugResponse(placeID uuid.UUID, lang string, slug string, redirectFrom string) (*dto.PlaceSlugResponse, error) {
+	place, err := GetPlaceByIDWithLanguage(placeID, lang)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.PlaceSlugResponse{
+		Place:        place,
+		Slug:         slug,
+		Redirect:     redirectFrom != "",
+		RedirectFrom: redirectFrom,
+	}, nil
+}
+
+func otherLanguage(lang string) string {
+	if lang == "ar" {
+		return "en"
+	}
+	return "ar"
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"almlah/config"
+	"almlah/internals/domain"
+	"almlah/internals/dto"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultPreviewTokenHours = 72
+	maxPreviewTokensListed   = 100
+)
+
+// CreatePreviewToken mints a signed preview link for a list or place the user can edit
+func CreatePreviewToken(req dto.CreatePreviewTokenRequest, userID uuid.UUID) (*dto.PreviewTokenResponse, error) {
+	if err := ensureCanPreview(req.EntityType, req.EntityID, userID); err != nil {
+		return nil, err
+	}
+
+	hours := req.ExpiresInHours
+	if hours == 0 {
+		hours = defaultPreviewTokenHours
+	}
+
+	token := domain.PreviewToken{
+		EntityType: req.EntityType,
+		EntityID:   req.EntityID,
+		ExpiresAt:  time.Now().Add(time.Duration(hours) * time.Hour).Truncate(time.Second),
+		CreatedBy:  userID,
+	}
+	if err := config.DB.Create(&token).Error; err != nil {
+		return nil, fmt.Errorf("failed to create preview token: %w", err)
+	}
+
+	response := mapPreviewTokenToResponse(token)
+	return &response, nil
+}
+
+// GetPreviewTokens lists the preview links of a list or place, newest first
+func GetPreviewTokens(entityType string, entityID uuid.UUID, userID uuid.UUID) ([]dto.PreviewTokenResponse, error) {
+	if err := ensureCanPreview(entityType, entityID, userID); err != nil {
+		return nil, err
+	}
+
+	var tokens []domain.PreviewToken
+	err := config.DB.Where("entity_type = ? AND entity_id = ?", entityType, entityID).
+		Order("created_at DESC").
+		Limit(maxPreviewTokensListed).
+		Find(&tokens).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch preview tokens: %w", err)
+	}
+
+	response := make([]dto.PreviewTokenResponse, len(tokens))
+	for i, token := range tokens {
+		response[i] = mapPreviewTokenToResponse(token)
+	}
+	return response, nil
+}
+
+// RevokePreviewToken stops a preview link from working before it expires
+func RevokePreviewToken(id uuid.UUID, userID uuid.UUID) error {
+	var token domain.PreviewToken
+	if err := config.DB.First(&token, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("preview token not found")
+		}
+		return fmt.Errorf("failed to fetch preview token: %w", err)
+	}
+
+	if err := ensureCanPreview(token.EntityType, token.EntityID, userID); err != nil {
+		return err
+	}
+
+	if token.RevokedAt != nil {
+		return nil
+	}
+	return config.DB.Model(&token).Update("revoked_at", time.Now()).Error
+}
+
+// IsValidPreviewToken reports whether value is an active preview token for the given
+// entity. A token for another entity never matches.
+func IsValidPreviewToken(value string, entityType string, entityID uuid.UUID) bool {
+	if value == "" {
+		return false
+	}
+
+	payloadPart, signaturePart, found := strings.Cut(value, ".")
+	if !found {
+		return false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(payloadPart)
+	if err != nil {
+		return false
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(signaturePart)
+	if err != nil || !hmac.Equal(signature, signPreviewPayload(string(payload))) {
+		return false
+	}
+
+	// Payload is "<token id>:<entity type>:<entity id>:<expiry unix>"
+	fields := strings.Split(string(payload), ":")
+	if len(fields) != 4 || fields[1] != entityType || fields[2] != entityID.String() {
+		return false
+	}
+	expiresAt, err := strconv.ParseInt(fields[3], 10, 64)
+	if err != nil || time.Now().Unix() >= expiresAt {
+		return false
+	}
+	tokenID, err := uuid.Parse(fields[0])
+	if err != nil {
+		return false
+	}
+
+	// The signature proves the payload, the record tells whether it was revoked
+	var token domain.PreviewToken
+	if err := config.DB.First(&token, "id = ?", tokenID).Error; err != nil {
+		return false
+	}
+	return token.EntityType == entityType && token.EntityID == entityID && token.IsActive(time.Now())
+}
+
+// ensureCanPreview checks the entity exists and the user may edit it
+func ensureCanPreview(entityType string, entityID uuid.UUID, userID uuid.UUID) error {
+	switch entityType {
+	case domain.PreviewEntityPlace:
+		canModify, err := canUserModifyPlace(entityID, userID)
+		if err != nil {
+			return err
+		}
+		if !canModify {
+			return errors.New("unauthorized: you don't have permission to preview this place")
+		}
+		return nil
+
+	case domain.PreviewEntityList:
+		var count int64
+		if err := config.DB.Model(&domain.List{}).Where("id = ?", entityID).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to fetch list: %w", err)
+		}
+		if count == 0 {
+			return errors.New("list not found")
+		}
+
+		var user domain.User
+		if err := config.DB.Preload("Roles.Permissions").First(&user, "id = ?", userID).Error; err != nil {
+			return errors.New("user not found")
+		}
+		if !CanUserEditLists(&user) {
+			return errors.New("unauthorized: you don't have permission to preview this list")
+		}
+		return nil
+
+	default:
+		return errors.New("invalid entity type")
+	}
+}
+
+// CanUserEditLists reports whether the user may see and edit lists that aren't published
+func CanUserEditLists(user *domain.User) bool {
+	return user.IsAdmin() || user.HasPermission("can_update_list")
+}
+
+func mapPreviewTokenToResponse(token domain.PreviewToken) dto.PreviewTokenResponse {
+	payload := fmt.Sprintf("%s:%s:%s:%d", token.ID, token.EntityType, token.EntityID, token.ExpiresAt.Unix())
+
+	return dto.PreviewTokenResponse{
+		ID:         token.ID,
+		EntityType: token.EntityType,
+		EntityID:   token.EntityID,
+		Token: base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." +
+			base64.RawURLEncoding.EncodeToString(signPreviewPayload(payload)),
+		ExpiresAt: token.ExpiresAt,
+		RevokedAt: token.RevokedAt,
+		IsActive:  token.IsActive(time.Now()),
+		CreatedBy: token.CreatedBy,
+		CreatedAt: token.CreatedAt,
+	}
+}
+
+// signPreviewPayload signs with PREVIEW_TOKEN_SECRET, or JWT_SECRET when it isn't set
+func signPreviewPayload(payload string) []byte {
+	secret := getEnvWithDefault("PREVIEW_TOKEN_SECRET", os.Getenv("JWT_SECRET"))
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
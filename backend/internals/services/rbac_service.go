@@ -689,6 +689,7 @@ func initializeDefaultPermissions() error {
 		{Name: "can_edit_review", DisplayName: "Edit Review", Description: "Edit reviews", Resource: domain.ResourceReview, Action: domain.ActionUpdate, IsActive: true},
 		{Name: "can_delete_review", DisplayName: "Delete Review", Description: "Delete reviews", Resource: domain.ResourceReview, Action: domain.ActionDelete, IsActive: true},
 		{Name: "can_moderate_review", DisplayName: "Moderate Reviews", Description: "Moderate review content", Resource: domain.ResourceReview, Action: domain.ActionModerate, IsActive: true},
+		{Name: "can_respond_review", DisplayName: "Respond to Reviews", Description: "Post the official reply to reviews of a place", Resource: domain.ResourceReview, Action: domain.ActionRespond, IsActive: true},
 
 		// Role and Permission management
 		{Name: "can_manage_role", DisplayName: "Manage Roles", Description: "Full control over roles", Resource: domain.ResourceRole, Action: domain.ActionManage, IsActive: true},
@@ -704,6 +705,24 @@ func initializeDefaultPermissions() error {
 		{Name: "can_view_property", DisplayName: "View Property", Description: "View properties", Resource: domain.ResourceProperty, Action: domain.ActionView, IsActive: true},
 		{Name: "can_manage_property", DisplayName: "Manage Properties", Description: "Full control over properties", Resource: domain.ResourceProperty, Action: domain.ActionManage, IsActive: true},
 
+		// Recipe permissions
+		{Name: "can_create_recipe", DisplayName: "Create Recipe", Description: "Create recipes", Resource: domain.ResourceRecipe, Action: domain.ActionCreate, IsActive: true},
+		{Name: "can_edit_recipe", DisplayName: "Edit Recipe", Description: "Edit other users' recipes", Resource: domain.ResourceRecipe, Action: domain.ActionUpdate, IsActive: true},
+		{Name: "can_delete_recipe", DisplayName: "Delete Recipe", Description: "Delete other users' recipes", Resource: domain.ResourceRecipe, Action: domain.ActionDelete, IsActive: true},
+
+		// Dish permissions
+		{Name: "can_create_dish", DisplayName: "Create Dish", Description: "Create traditional dishes", Resource: domain.ResourceDish, Action: domain.ActionCreate, IsActive: true},
+		{Name: "can_update_dish", DisplayName: "Update Dish", Description: "Edit dishes, their images, tags and places", Resource: domain.ResourceDish, Action: domain.ActionUpdate, IsActive: true},
+		{Name: "can_delete_dish", DisplayName: "Delete Dish", Description: "Delete dishes", Resource: domain.ResourceDish, Action: domain.ActionDelete, IsActive: true},
+		{Name: "can_manage_dish", DisplayName: "Manage Dishes", Description: "Manage the dietary tag, allergen and ingredient vocabulary", Resource: domain.ResourceDish, Action: domain.ActionManage, IsActive: true},
+
+		// Advice permissions
+		{Name: "can_create_advice", DisplayName: "Create Advice", Description: "Create travel advice", Resource: domain.ResourceAdvice, Action: domain.ActionCreate, IsActive: true},
+		{Name: "can_edit_advice", DisplayName: "Edit Advice", Description: "Edit travel advice", Resource: domain.ResourceAdvice, Action: domain.ActionUpdate, IsActive: true},
+		{Name: "can_delete_advice", DisplayName: "Delete Advice", Description: "Delete travel advice", Resource: domain.ResourceAdvice, Action: domain.ActionDelete, IsActive: true},
+		{Name: "can_view_advice", DisplayName: "View Advice", Description: "View unpublished travel advice", Resource: domain.ResourceAdvice, Action: domain.ActionView, IsActive: true},
+		{Name: "can_manage_advice", DisplayName: "Manage Advice", Description: "Publish and feature travel advice", Resource: domain.ResourceAdvice, Action: domain.ActionManage, IsActive: true},
+
 	}
 
 	for _, permission := range permissions {
@@ -735,6 +754,10 @@ func assignDefaultPermissions() error {
 	adminPermissions := []string{
 		"can_manage_place", "can_manage_user", "can_manage_category",
 		"can_moderate_review", "can_moderate_place","can_manage_property",
+		"can_edit_review", "can_delete_review", "can_respond_review",
+		"can_create_advice", "can_edit_advice", "can_delete_advice", "can_view_advice", "can_manage_advice",
+		"can_create_recipe", "can_edit_recipe", "can_delete_recipe",
+		"can_create_dish", "can_update_dish", "can_delete_dish", "can_manage_dish",
 	}
 	if err := assignPermissionsToRole(adminRole.ID, adminPermissions); err != nil {
 		return err
@@ -744,6 +767,10 @@ func assignDefaultPermissions() error {
 	moderatorPermissions := []string{
 		"can_create_place", "can_edit_place", "can_view_place", "can_moderate_place",
 		"can_moderate_review", "can_view_user","can_create_property", "can_edit_property", "can_view_property",
+		"can_edit_review", "can_delete_review",
+		"can_create_advice", "can_edit_advice", "can_view_advice",
+		"can_create_recipe", "can_edit_recipe", "can_delete_recipe",
+		"can_create_dish", "can_update_dish",
 	}
 	if err := assignPermissionsToRole(moderatorRole.ID, moderatorPermissions); err != nil {
 		return err
@@ -753,6 +780,7 @@ func assignDefaultPermissions() error {
 	userPermissions := []string{
 		"can_create_place", "can_edit_place", "can_view_place",
 		"can_create_review", "can_edit_review","can_create_property", "can_edit_property", "can_view_property",
+		"can_create_recipe",
 	}
 	if err := assignPermissionsToRole(userRole.ID, userPermissions); err != nil {
 		return err
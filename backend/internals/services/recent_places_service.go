@@ -19,17 +19,24 @@ func GetRecentPlaces(limit int) ([]dto.PlaceListResponse, error) {
 	err := config.DB.Select(
 		"places.id",
 		"places.name_ar",
-		"places.name_en", 
+		"places.name_en",
+		"places.slug",
+		"places.slug_ar",
+		"places.rating_average",
+		"places.review_count",
+		"places.last_review_at",
 		"places.subtitle_ar",
 		"places.subtitle_en",
 		"places.latitude",
 		"places.longitude",
 		"places.governate_id",
 		"places.wilayah_id",
+		"places.status",
 		"places.created_at",
 		"places.updated_at",
 	).
 		Where("places.is_active = ?", true).
+		Scopes(publishedPlacesOnly).
 		Order("places.created_at DESC").
 		Limit(limit).
 		Preload("Categories", func(db *gorm.DB) *gorm.DB {
@@ -71,16 +78,23 @@ func GetRecentPlacesWithNewStatus(limit int) ([]dto.PlaceWithNewStatusResponse,
 		"places.id",
 		"places.name_ar", 
 		"places.name_en",
+		"places.slug",
+		"places.slug_ar",
+		"places.rating_average",
+		"places.review_count",
+		"places.last_review_at",
 		"places.subtitle_ar",
 		"places.subtitle_en",
 		"places.latitude",
 		"places.longitude",
 		"places.governate_id",
 		"places.wilayah_id",
+		"places.status",
 		"places.created_at",
 		"places.updated_at",
 	).
 		Where("places.is_active = ?", true).
+		Scopes(publishedPlacesOnly).
 		Order("places.created_at DESC").
 		Limit(limit).
 		Preload("Categories", func(db *gorm.DB) *gorm.DB {
@@ -128,17 +142,24 @@ func GetRecentPlacesSmartFallback(limit int, minCount int) ([]dto.PlaceWithNewSt
 	err := config.DB.Select(
 		"places.id",
 		"places.name_ar",
-		"places.name_en", 
+		"places.name_en",
+		"places.slug",
+		"places.slug_ar",
+		"places.rating_average",
+		"places.review_count",
+		"places.last_review_at",
 		"places.subtitle_ar",
 		"places.subtitle_en",
 		"places.latitude",
 		"places.longitude",
 		"places.governate_id",
 		"places.wilayah_id",
+		"places.status",
 		"places.created_at",
 		"places.updated_at",
 	).
 		Where("places.is_active = ? AND places.created_at > ?", true, oneWeekAgo).
+		Scopes(publishedPlacesOnly).
 		Order("places.created_at DESC").
 		Preload("Categories", func(db *gorm.DB) *gorm.DB {
 			return db.Select("id", "name_ar", "name_en", "slug", "icon", "type")
@@ -186,16 +207,23 @@ func GetRecentPlacesSmartFallback(limit int, minCount int) ([]dto.PlaceWithNewSt
 				"places.id",
 				"places.name_ar",
 				"places.name_en",
+				"places.slug",
+				"places.slug_ar",
+				"places.rating_average",
+				"places.review_count",
+				"places.last_review_at",
 				"places.subtitle_ar",
 				"places.subtitle_en",
 				"places.latitude",
 				"places.longitude",
 				"places.governate_id",
 				"places.wilayah_id",
+				"places.status",
 				"places.created_at",
 				"places.updated_at",
 			).
 				Where("places.is_active = ? AND places.created_at <= ?", true, oneWeekAgo).
+				Scopes(publishedPlacesOnly).
 				Order("places.created_at DESC").
 				Limit(remaining).
 				Preload("Categories", func(db *gorm.DB) *gorm.DB {
@@ -248,6 +276,7 @@ func GetNewPlacesCount() (int64, error) {
 	var count int64
 	err := config.DB.Model(&domain.Place{}).
 		Where("is_active = ? AND created_at > ?", true, oneWeekAgo).
+		Scopes(publishedPlacesOnly).
 		Count(&count).Error
 	
 	return count, err
@@ -263,6 +292,7 @@ func GetPlacesStats() (*dto.PlacesStatsResponse, error) {
 	// Total active places
 	err := config.DB.Model(&domain.Place{}).
 		Where("is_active = ?", true).
+		Scopes(publishedPlacesOnly).
 		Count(&totalCount).Error
 	if err != nil {
 		return nil, err
@@ -271,6 +301,7 @@ func GetPlacesStats() (*dto.PlacesStatsResponse, error) {
 	// New places this week
 	err = config.DB.Model(&domain.Place{}).
 		Where("is_active = ? AND created_at > ?", true, oneWeekAgo).
+		Scopes(publishedPlacesOnly).
 		Count(&newThisWeek).Error
 	if err != nil {
 		return nil, err
@@ -279,6 +310,7 @@ func GetPlacesStats() (*dto.PlacesStatsResponse, error) {
 	// New places this month
 	err = config.DB.Model(&domain.Place{}).
 		Where("is_active = ? AND created_at > ?", true, oneMonthAgo).
+		Scopes(publishedPlacesOnly).
 		Count(&newThisMonth).Error
 	if err != nil {
 		return nil, err
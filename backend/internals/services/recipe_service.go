@@ -4,37 +4,55 @@ import (
 	"almlah/config"
 	"almlah/internals/dto"
 	"almlah/internals/domain"
+	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 func CreateRecipe(req dto.CreateRecipeRequest, userID uuid.UUID) (*dto.RecipeResponse, error) {
+	if err := validateRecipeDish(config.DB, req.DishID); err != nil {
+		return nil, err
+	}
+	ingredients, err := buildRecipeIngredients(req.Ingredients)
+	if err != nil {
+		return nil, err
+	}
+	steps, err := buildRecipeSteps(req.Steps)
+	if err != nil {
+		return nil, err
+	}
+
 	recipe := domain.Recipe{
-		Title:        req.Title,
-		Description:  req.Description,
-		CuisineType:  req.CuisineType,
-		Difficulty:   req.Difficulty,
-		PrepTime:     req.PrepTime,
-		CookTime:     req.CookTime,
-		Servings:     req.Servings,
-		Ingredients:  req.Ingredients,
-		Instructions: req.Instructions,
-		CreatedBy:    userID,
-		IsPublished:  true,
-		ViewCount:    0,
-	}
-
-	if err := config.DB.Create(&recipe).Error; err != nil {
+		Title:       req.Title,
+		Description: req.Description,
+		CuisineType: req.CuisineType,
+		Difficulty:  req.Difficulty,
+		PrepTime:    req.PrepTime,
+		CookTime:    req.CookTime,
+		Servings:    req.Servings,
+		DishID:      req.DishID,
+		CreatedBy:   userID,
+		IsPublished: true,
+		ViewCount:   0,
+		Ingredients: ingredients,
+		Steps:       steps,
+	}
+
+	if err := config.DB.Omit("Creator", "Dish").Create(&recipe).Error; err != nil {
 		return nil, err
 	}
 
-	return GetRecipeByID(recipe.ID)
+	return loadRecipeResponse(recipe.ID)
 }
 
 func GetRecipes() ([]dto.RecipeResponse, error) {
 	var recipes []domain.Recipe
 
-	if err := config.DB.Where("is_published = ?", true).Preload("Creator").Preload("Images").Find(&recipes).Error; err != nil {
+	if err := recipeWithRelations(config.DB).Where("is_published = ?", true).Find(&recipes).Error; err != nil {
 		return nil, err
 	}
 
@@ -46,44 +64,451 @@ func GetRecipes() ([]dto.RecipeResponse, error) {
 	return response, nil
 }
 
-func GetRecipeByID(id uuid.UUID) (*dto.RecipeResponse, error) {
+// GetDishRecipes lists the published recipes of a dish
+func GetDishRecipes(dishID uuid.UUID) ([]dto.RecipeResponse, error) {
+	if err := validateRecipeDish(config.DB, &dishID); err != nil {
+		return nil, errors.New("dish not found")
+	}
+
+	var recipes []domain.Recipe
+	err := recipeWithRelations(config.DB).
+		Where("dish_id = ? AND is_published = ?", dishID, true).
+		Order("view_count DESC, created_at ASC").
+		Find(&recipes).Error
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.RecipeResponse, 0, len(recipes))
+	for _, recipe := range recipes {
+		response = append(response, mapRecipeToResponse(recipe))
+	}
+
+	return response, nil
+}
+
+// GetRecipeByID returns a recipe. Unpublished recipes are only shown to their author and
+// editors, viewerID is nil for anonymous callers. Only views of published recipes count.
+func GetRecipeByID(id uuid.UUID, viewerID *uuid.UUID) (*dto.RecipeResponse, error) {
 	var recipe domain.Recipe
 
-	if err := config.DB.Preload("Creator").Preload("Images").First(&recipe, id).Error; err != nil {
+	if err := recipeWithRelations(config.DB).First(&recipe, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("recipe not found")
+		}
 		return nil, err
 	}
 
-	// Increment view count
-	config.DB.Model(&recipe).Update("view_count", recipe.ViewCount+1)
+	if !recipe.IsPublished {
+		if viewerID == nil {
+			return nil, errors.New("recipe not found")
+		}
+		canView, err := canUserModifyRecipe(recipe, *viewerID, "can_edit_recipe")
+		if err != nil {
+			return nil, err
+		}
+		if !canView {
+			return nil, errors.New("recipe not found")
+		}
+	} else {
+		// Increment view count
+		if err := config.DB.Model(&recipe).UpdateColumn("view_count", gorm.Expr("view_count + 1")).Error; err != nil {
+			fmt.Printf("⚠️ Failed to count view of recipe %s: %v\n", id, err)
+		}
+	}
 
 	response := mapRecipeToResponse(recipe)
 	return &response, nil
 }
 
+// UpdateRecipe changes a recipe. Only its author, admins and moderators with
+// can_edit_recipe can edit it.
+func UpdateRecipe(recipeID uuid.UUID, req dto.UpdateRecipeRequest, userID uuid.UUID) (*dto.RecipeResponse, error) {
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		var recipe domain.Recipe
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&recipe, "id = ?", recipeID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("recipe not found")
+			}
+			return err
+		}
+
+		canModify, err := canUserModifyRecipe(recipe, userID, "can_edit_recipe")
+		if err != nil {
+			return err
+		}
+		if !canModify {
+			return errors.New("unauthorized: you can only edit your own recipes")
+		}
+
+		updates := map[string]interface{}{}
+		if req.Title != nil {
+			title := strings.TrimSpace(*req.Title)
+			if title == "" {
+				return errors.New("invalid recipe: title is required")
+			}
+			updates["title"] = title
+		}
+		if req.Description != nil {
+			updates["description"] = *req.Description
+		}
+		if req.CuisineType != nil {
+			updates["cuisine_type"] = *req.CuisineType
+		}
+		if req.Difficulty != nil {
+			updates["difficulty"] = *req.Difficulty
+		}
+		if req.PrepTime != nil {
+			updates["prep_time"] = *req.PrepTime
+		}
+		if req.CookTime != nil {
+			updates["cook_time"] = *req.CookTime
+		}
+		if req.Servings != nil {
+			updates["servings"] = *req.Servings
+		}
+		if req.IsPublished != nil {
+			updates["is_published"] = *req.IsPublished
+		}
+		if req.DishID != nil {
+			dishID := nilIfZeroUUID(*req.DishID)
+			if err := validateRecipeDish(tx, dishID); err != nil {
+				return err
+			}
+			updates["dish_id"] = dishID
+		}
+
+		if req.Ingredients != nil {
+			ingredients, err := buildRecipeIngredients(*req.Ingredients)
+			if err != nil {
+				return err
+			}
+			if err := tx.Where("recipe_id = ?", recipeID).Delete(&domain.RecipeIngredient{}).Error; err != nil {
+				return fmt.Errorf("failed to replace ingredients: %w", err)
+			}
+			for i := range ingredients {
+				ingredients[i].RecipeID = recipeID
+			}
+			if err := tx.Create(&ingredients).Error; err != nil {
+				return fmt.Errorf("failed to replace ingredients: %w", err)
+			}
+		}
+
+		if req.Steps != nil {
+			steps, err := buildRecipeSteps(*req.Steps)
+			if err != nil {
+				return err
+			}
+			if err := tx.Where("recipe_id = ?", recipeID).Delete(&domain.RecipeStep{}).Error; err != nil {
+				return fmt.Errorf("failed to replace steps: %w", err)
+			}
+			for i := range steps {
+				steps[i].RecipeID = recipeID
+			}
+			if err := tx.Create(&steps).Error; err != nil {
+				return fmt.Errorf("failed to replace steps: %w", err)
+			}
+
+			// Images of steps that no longer exist become images of the whole recipe
+			err = tx.Model(&domain.RecipeImage{}).
+				Where("recipe_id = ? AND step_number > ?", recipeID, len(steps)).
+				Update("step_number", 0).Error
+			if err != nil {
+				return fmt.Errorf("failed to update step images: %w", err)
+			}
+		}
+
+		if len(updates) == 0 && req.Ingredients == nil && req.Steps == nil {
+			return nil
+		}
+		if len(updates) == 0 {
+			return tx.Model(&recipe).Update("updated_at", gorm.Expr("NOW()")).Error
+		}
+		return tx.Model(&recipe).Updates(updates).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return loadRecipeResponse(recipeID)
+}
+
+// DeleteRecipe soft deletes a recipe. Only its author, admins and moderators with
+// can_delete_recipe can delete it.
+func DeleteRecipe(recipeID uuid.UUID, userID uuid.UUID) error {
+	var recipe domain.Recipe
+	if err := config.DB.First(&recipe, "id = ?", recipeID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("recipe not found")
+		}
+		return err
+	}
+
+	canModify, err := canUserModifyRecipe(recipe, userID, "can_delete_recipe")
+	if err != nil {
+		return err
+	}
+	if !canModify {
+		return errors.New("unauthorized: you can only delete your own recipes")
+	}
+
+	if err := config.DB.Delete(&recipe).Error; err != nil {
+		return fmt.Errorf("failed to delete recipe: %w", err)
+	}
+	return nil
+}
+
+// Recipe Image Management
+
+// AddRecipeImage adds an image to a recipe, or to one of its steps
+func AddRecipeImage(recipeID uuid.UUID, req dto.CreateRecipeImageRequest, userID uuid.UUID) (*dto.RecipeResponse, error) {
+	var recipe domain.Recipe
+	if err := config.DB.First(&recipe, "id = ?", recipeID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("recipe not found")
+		}
+		return nil, err
+	}
+
+	canModify, err := canUserModifyRecipe(recipe, userID, "can_edit_recipe")
+	if err != nil {
+		return nil, err
+	}
+	if !canModify {
+		return nil, errors.New("unauthorized: you can only edit your own recipes")
+	}
+
+	image := domain.RecipeImage{
+		RecipeID:     recipeID,
+		ImageURL:     req.ImageURL,
+		AltText:      req.AltText,
+		ImageType:    "cover",
+		DisplayOrder: req.DisplayOrder,
+		StepNumber:   req.StepNumber,
+	}
+	if req.StepNumber > 0 {
+		var step domain.RecipeStep
+		if err := config.DB.Select("id").Where("recipe_id = ? AND step_number = ?", recipeID, req.StepNumber).First(&step).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, errors.New("invalid image: the recipe has no such step")
+			}
+			return nil, err
+		}
+		image.ImageType = "step"
+	}
+	if image.DisplayOrder == 0 {
+		var count int64
+		if err := config.DB.Model(&domain.RecipeImage{}).Where("recipe_id = ? AND step_number = ?", recipeID, req.StepNumber).Count(&count).Error; err != nil {
+			return nil, err
+		}
+		image.DisplayOrder = int(count) + 1
+	}
+
+	if err := config.DB.Omit("Recipe").Create(&image).Error; err != nil {
+		return nil, fmt.Errorf("failed to add recipe image: %w", err)
+	}
+
+	return loadRecipeResponse(recipeID)
+}
+
+func DeleteRecipeImage(recipeID uuid.UUID, imageID uuid.UUID, userID uuid.UUID) (*dto.RecipeResponse, error) {
+	var recipe domain.Recipe
+	if err := config.DB.First(&recipe, "id = ?", recipeID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("recipe not found")
+		}
+		return nil, err
+	}
+
+	canModify, err := canUserModifyRecipe(recipe, userID, "can_edit_recipe")
+	if err != nil {
+		return nil, err
+	}
+	if !canModify {
+		return nil, errors.New("unauthorized: you can only edit your own recipes")
+	}
+
+	result := config.DB.Where("id = ? AND recipe_id = ?", imageID, recipeID).Delete(&domain.RecipeImage{})
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to delete recipe image: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, errors.New("image not found")
+	}
+
+	return loadRecipeResponse(recipeID)
+}
+
+// Helper functions
+
+func recipeWithRelations(query *gorm.DB) *gorm.DB {
+	return query.
+		Preload("Creator").
+		Preload("Dish").
+		Preload("Ingredients", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sort_order ASC")
+		}).
+		Preload("Steps", func(db *gorm.DB) *gorm.DB {
+			return db.Order("step_number ASC")
+		}).
+		Preload("Images", func(db *gorm.DB) *gorm.DB {
+			return db.Order("display_order ASC, upload_date ASC")
+		})
+}
+
+// loadRecipeResponse returns a recipe without counting a view
+func loadRecipeResponse(recipeID uuid.UUID) (*dto.RecipeResponse, error) {
+	var recipe domain.Recipe
+	if err := recipeWithRelations(config.DB).First(&recipe, "id = ?", recipeID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("recipe not found")
+		}
+		return nil, err
+	}
+
+	response := mapRecipeToResponse(recipe)
+	return &response, nil
+}
+
+func canUserModifyRecipe(recipe domain.Recipe, userID uuid.UUID, permission string) (bool, error) {
+	if recipe.CreatedBy == userID {
+		return true, nil
+	}
+
+	var user domain.User
+	if err := config.DB.Preload("Roles.Permissions").Where("id = ?", userID).First(&user).Error; err != nil {
+		return false, fmt.Errorf("user not found")
+	}
+
+	if user.IsAdmin() || user.IsSuperAdmin() {
+		return true, nil
+	}
+
+	return user.IsModerator() && user.HasPermission(permission), nil
+}
+
+func validateRecipeDish(tx *gorm.DB, dishID *uuid.UUID) error {
+	if dishID == nil {
+		return nil
+	}
+	var dish domain.Dish
+	if err := tx.Select("id").First(&dish, "id = ?", *dishID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("invalid recipe: dish not found")
+		}
+		return err
+	}
+	return nil
+}
+
+func buildRecipeIngredients(requests []dto.RecipeIngredientRequest) ([]domain.RecipeIngredient, error) {
+	ingredients := make([]domain.RecipeIngredient, 0, len(requests))
+	for i, req := range requests {
+		nameAr, nameEn := strings.TrimSpace(req.NameAr), strings.TrimSpace(req.NameEn)
+		if nameAr == "" || nameEn == "" {
+			return nil, fmt.Errorf("invalid ingredient %d: name_ar and name_en are required", i+1)
+		}
+		if req.Unit == domain.UnitToTaste && req.Quantity != nil {
+			return nil, fmt.Errorf("invalid ingredient %d: to_taste takes no quantity", i+1)
+		}
+		ingredients = append(ingredients, domain.RecipeIngredient{
+			NameAr:    nameAr,
+			NameEn:    nameEn,
+			Quantity:  req.Quantity,
+			Unit:      req.Unit,
+			Note:      strings.TrimSpace(req.Note),
+			SortOrder: i + 1,
+		})
+	}
+	return ingredients, nil
+}
+
+func buildRecipeSteps(requests []dto.RecipeStepRequest) ([]domain.RecipeStep, error) {
+	steps := make([]domain.RecipeStep, 0, len(requests))
+	for i, req := range requests {
+		instruction := strings.TrimSpace(req.Instruction)
+		if instruction == "" {
+			return nil, fmt.Errorf("invalid step %d: instruction is required", i+1)
+		}
+		steps = append(steps, domain.RecipeStep{
+			StepNumber:      i + 1,
+			Instruction:     instruction,
+			DurationMinutes: req.DurationMinutes,
+		})
+	}
+	return steps, nil
+}
+
 func mapRecipeToResponse(recipe domain.Recipe) dto.RecipeResponse {
-	var images []dto.ImageResponse
+	images := []dto.ImageResponse{}
+	stepImages := make(map[int][]dto.ImageResponse)
 	for _, img := range recipe.Images {
-		images = append(images, dto.ImageResponse{
+		image := dto.ImageResponse{
 			ID:           img.ID,
 			URL:          img.ImageURL,
 			AltText:      img.AltText,
 			DisplayOrder: img.DisplayOrder,
+		}
+		if img.StepNumber > 0 {
+			stepImages[img.StepNumber] = append(stepImages[img.StepNumber], image)
+		} else {
+			images = append(images, image)
+		}
+	}
+
+	ingredients := make([]dto.RecipeIngredientResponse, 0, len(recipe.Ingredients))
+	for _, ingredient := range recipe.Ingredients {
+		ingredients = append(ingredients, dto.RecipeIngredientResponse{
+			ID:       ingredient.ID,
+			NameAr:   ingredient.NameAr,
+			NameEn:   ingredient.NameEn,
+			Quantity: ingredient.Quantity,
+			Unit:     ingredient.Unit,
+			Note:     ingredient.Note,
 		})
 	}
 
+	steps := make([]dto.RecipeStepResponse, 0, len(recipe.Steps))
+	for _, step := range recipe.Steps {
+		stepResponse := dto.RecipeStepResponse{
+			ID:              step.ID,
+			StepNumber:      step.StepNumber,
+			Instruction:     step.Instruction,
+			DurationMinutes: step.DurationMinutes,
+			Images:          stepImages[step.StepNumber],
+		}
+		if stepResponse.Images == nil {
+			stepResponse.Images = []dto.ImageResponse{}
+		}
+		steps = append(steps, stepResponse)
+	}
+
+	var dish *dto.SimpleDishResponse
+	if recipe.Dish != nil {
+		dish = &dto.SimpleDishResponse{
+			ID:     recipe.Dish.ID,
+			NameAr: recipe.Dish.NameAr,
+			NameEn: recipe.Dish.NameEn,
+			Slug:   recipe.Dish.Slug,
+		}
+	}
+
 	return dto.RecipeResponse{
-		ID:           recipe.ID,
-		Title:        recipe.Title,
-		Description:  recipe.Description,
-		CuisineType:  recipe.CuisineType,
-		Difficulty:   recipe.Difficulty,
-		PrepTime:     recipe.PrepTime,
-		CookTime:     recipe.CookTime,
-		Servings:     recipe.Servings,
-		Ingredients:  recipe.Ingredients,
-		Instructions: recipe.Instructions,
-		ViewCount:    recipe.ViewCount,
-		CreatedAt:    recipe.CreatedAt,
+		ID:          recipe.ID,
+		Title:       recipe.Title,
+		Description: recipe.Description,
+		CuisineType: recipe.CuisineType,
+		Difficulty:  recipe.Difficulty,
+		PrepTime:    recipe.PrepTime,
+		CookTime:    recipe.CookTime,
+		Servings:    recipe.Servings,
+		Dish:        dish,
+		Ingredients: ingredients,
+		Steps:       steps,
+		IsPublished: recipe.IsPublished,
+		ViewCount:   recipe.ViewCount,
+		CreatedAt:   recipe.CreatedAt,
+		UpdatedAt:   recipe.UpdatedAt,
 		Author: dto.UserInfo{
 			ID:        recipe.Creator.ID,
 			Username:  recipe.Creator.Username,
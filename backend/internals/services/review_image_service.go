@@ -0,0 +1,241 @@
+// services/review_image_service.go
+package services
+
+import (
+	"almlah/config"
+	"almlah/internals/domain"
+	"almlah/internals/dto"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Limits on the photos attached to a review
+const (
+	maxReviewImages       = 5
+	maxReviewImageSize    = 10 * 1024 * 1024 // 10MB
+	maxReviewImageCaption = 500
+)
+
+// reviewImageTypes maps the accepted photo extensions to their content types
+var reviewImageTypes = map[string][]string{
+	".jpg":  {"image/jpeg", "image/jpg"},
+	".jpeg": {"image/jpeg", "image/jpg"},
+	".png":  {"image/png"},
+	".webp": {"image/webp"},
+}
+
+// validateReviewImages checks new photos before anything is uploaded. existing is the number
+// of photos the review already has.
+func validateReviewImages(uploads []dto.ReviewImageUpload, existing int) error {
+	if existing+len(uploads) > maxReviewImages {
+		return fmt.Errorf("too many photos: a review can have at most %d", maxReviewImages)
+	}
+
+	for _, upload := range uploads {
+		if upload.File == nil {
+			return errors.New("invalid photo: no file provided")
+		}
+		name := upload.File.Filename
+		if upload.File.Size > maxReviewImageSize {
+			return fmt.Errorf("invalid photo %s: exceeds 10MB limit", name)
+		}
+
+		contentTypes, ok := reviewImageTypes[strings.ToLower(filepath.Ext(name))]
+		if !ok || !containsString(contentTypes, upload.File.Header.Get("Content-Type")) {
+			return fmt.Errorf("invalid photo %s: only JPEG, PNG and WebP images are allowed", name)
+		}
+		if len([]rune(upload.Caption)) > maxReviewImageCaption {
+			return fmt.Errorf("invalid photo %s: caption is longer than %d characters", name, maxReviewImageCaption)
+		}
+	}
+	return nil
+}
+
+// uploadReviewImages stores the photos of a review and returns the rows to insert. If one
+// upload fails the files stored before it are removed again.
+func uploadReviewImages(reviewID uuid.UUID, uploads []dto.ReviewImageUpload) ([]domain.ReviewImage, error) {
+	images := make([]domain.ReviewImage, 0, len(uploads))
+	for _, upload := range uploads {
+		filename := strings.NewReplacer(" ", "_", "(", "", ")", "").Replace(filepath.Base(upload.File.Filename))
+		filePath := fmt.Sprintf("reviews/%s/%s_%s_%s", reviewID, time.Now().Format("20060102-150405"),
+			uuid.New().String()[:8], filename)
+
+		src, err := upload.File.Open()
+		if err != nil {
+			deleteReviewImageFiles(reviewImageURLs(images))
+			return nil, fmt.Errorf("failed to open photo %s: %w", upload.File.Filename, err)
+		}
+		url, err := supabaseService.UploadFile(src, filePath, upload.File.Header.Get("Content-Type"))
+		src.Close()
+		if err != nil {
+			deleteReviewImageFiles(reviewImageURLs(images))
+			return nil, fmt.Errorf("failed to upload photo %s: %w", upload.File.Filename, err)
+		}
+
+		images = append(images, domain.ReviewImage{
+			ID:         uuid.New(),
+			ReviewID:   reviewID,
+			ImageURL:   url,
+			Caption:    strings.TrimSpace(upload.Caption),
+			UploadDate: time.Now(),
+		})
+	}
+	return images, nil
+}
+
+// saveReviewImages inserts uploaded photos; the review's place must be locked so concurrent
+// edits can't go past maxReviewImages
+func saveReviewImages(tx *gorm.DB, reviewID uuid.UUID, images []domain.ReviewImage) error {
+	if len(images) == 0 {
+		return nil
+	}
+
+	var existing int64
+	if err := tx.Model(&domain.ReviewImage{}).Where("review_id = ?", reviewID).Count(&existing).Error; err != nil {
+		return err
+	}
+	if int(existing)+len(images) > maxReviewImages {
+		return fmt.Errorf("too many photos: a review can have at most %d", maxReviewImages)
+	}
+
+	if err := tx.Omit(clause.Associations).Create(&images).Error; err != nil {
+		return fmt.Errorf("failed to save photos: %w", err)
+	}
+	return nil
+}
+
+// DeleteReviewImage removes one photo from a review. It returns the ID of the reviewed place.
+func DeleteReviewImage(reviewID uuid.UUID, imageID uuid.UUID, userID uuid.UUID) (uuid.UUID, error) {
+	review, err := findReview(reviewID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	canModify, err := canUserModifyReview(review, userID, "can_edit_review")
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if !canModify {
+		return uuid.Nil, errors.New("unauthorized: you can only edit your own reviews")
+	}
+
+	var image domain.ReviewImage
+	if err := config.DB.Where("id = ? AND review_id = ?", imageID, reviewID).First(&image).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return uuid.Nil, errors.New("photo not found")
+		}
+		return uuid.Nil, err
+	}
+
+	if err := config.DB.Delete(&image).Error; err != nil {
+		return uuid.Nil, fmt.Errorf("failed to delete photo: %w", err)
+	}
+	go deleteReviewImageFiles([]string{image.ImageURL})
+
+	return review.PlaceID, nil
+}
+
+// deleteReviewImageRows removes the photo rows of reviews and returns their URLs, so the files
+// can be removed from storage once the transaction has committed
+func deleteReviewImageRows(tx *gorm.DB, reviewIDs []uuid.UUID) ([]string, error) {
+	if len(reviewIDs) == 0 {
+		return nil, nil
+	}
+
+	var urls []string
+	if err := tx.Model(&domain.ReviewImage{}).Where("review_id IN ?", reviewIDs).Pluck("image_url", &urls).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Where("review_id IN ?", reviewIDs).Delete(&domain.ReviewImage{}).Error; err != nil {
+		return nil, fmt.Errorf("failed to delete review photos: %w", err)
+	}
+	return urls, nil
+}
+
+// deleteReviewImageFiles removes photos from storage. Failures are only logged, the rows
+// are already gone.
+func deleteReviewImageFiles(urls []string) {
+	for _, url := range urls {
+		if supabaseService.IsSupabaseURL(url) {
+			if err := supabaseService.DeleteFile(url); err != nil {
+				fmt.Printf("⚠️ Failed to delete review photo from Supabase %s: %v\n", url, err)
+			}
+		} else if err := deleteImageFromStorage(url); err != nil {
+			fmt.Printf("⚠️ Failed to delete review photo from local storage %s: %v\n", url, err)
+		}
+	}
+}
+
+func reviewImageURLs(images []domain.ReviewImage) []string {
+	urls := make([]string, 0, len(images))
+	for _, image := range images {
+		urls = append(urls, image.ImageURL)
+	}
+	return urls
+}
+
+// GetPlacePhotoGallery returns the photos visitors attached to their reviews of a published
+// place, newest first. Photos of hidden reviews are left out.
+func GetPlacePhotoGallery(placeID uuid.UUID, page, limit int) (*dto.PaginationResponse, error) {
+	var place domain.Place
+	if err := config.DB.Select("id").Scopes(publishedPlacesOnly).First(&place, "id = ?", placeID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("place not found")
+		}
+		return nil, err
+	}
+
+	gallery := func() *gorm.DB {
+		return config.DB.Model(&domain.ReviewImage{}).
+			Joins("JOIN reviews ON reviews.id = review_images.review_id").
+			Where("reviews.place_id = ? AND reviews.deleted_at IS NULL AND reviews.is_hidden = ?", placeID, false)
+	}
+
+	var total int64
+	if err := gallery().Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count photos: %w", err)
+	}
+
+	var images []domain.ReviewImage
+	err := gallery().Preload("Review.User").
+		Order("review_images.upload_date DESC, review_images.id").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&images).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load photos: %w", err)
+	}
+
+	photos := make([]dto.ReviewPhotoResponse, 0, len(images))
+	for _, image := range images {
+		photos = append(photos, dto.ReviewPhotoResponse{
+			ID:         image.ID,
+			ReviewID:   image.ReviewID,
+			ImageURL:   image.ImageURL,
+			Caption:    image.Caption,
+			Rating:     image.Review.Rating,
+			UploadDate: image.UploadDate,
+			Author: dto.UserInfo{
+				ID:        image.Review.User.ID,
+				Username:  image.Review.User.Username,
+				FirstName: image.Review.User.FirstName,
+				LastName:  image.Review.User.LastName,
+			},
+		})
+	}
+
+	return &dto.PaginationResponse{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+		Data:       photos,
+	}, nil
+}
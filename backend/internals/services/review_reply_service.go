@@ -0,0 +1,220 @@
+// services/review_reply_service.go
+package services
+
+import (
+	"almlah/config"
+	"almlah/internals/domain"
+	"almlah/internals/dto"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SaveReviewReply posts the official reply to a review, or edits it when the review already
+// has one. The previous text of an edited reply is kept as a revision. The reviewer is
+// notified when a reply is first posted.
+func SaveReviewReply(reviewID uuid.UUID, userID uuid.UUID, req dto.ReviewReplyRequest) (*dto.ReviewReplyResponse, error) {
+	review, err := findReview(reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	canReply, err := canUserManageReviewReply(review.PlaceID, userID, false)
+	if err != nil {
+		return nil, err
+	}
+	if !canReply {
+		return nil, errors.New("unauthorized: only the place owner can reply to its reviews")
+	}
+
+	text := strings.TrimSpace(req.ReplyText)
+	if text == "" {
+		return nil, errors.New("invalid reply: reply text is required")
+	}
+
+	var reply domain.ReviewReply
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		// Lock the review so two replies posted at once can't both be created
+		var locked domain.Review
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&locked, "id = ?", reviewID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.New("review not found")
+			}
+			return err
+		}
+
+		err := tx.Where("review_id = ?", reviewID).First(&reply).Error
+		if err == gorm.ErrRecordNotFound {
+			reply = domain.ReviewReply{ReviewID: reviewID, AuthorID: userID, ReplyText: text}
+			if err := tx.Omit(clause.Associations).Create(&reply).Error; err != nil {
+				return err
+			}
+			return notifyReviewerOfReply(tx, review)
+		}
+		if err != nil {
+			return err
+		}
+
+		if reply.ReplyText == text {
+			return nil
+		}
+
+		revision := domain.ReviewReplyRevision{
+			ReplyID:   reply.ID,
+			EditedBy:  userID,
+			ReplyText: reply.ReplyText,
+		}
+		if err := tx.Omit(clause.Associations).Create(&revision).Error; err != nil {
+			return fmt.Errorf("failed to keep reply history: %w", err)
+		}
+
+		return tx.Model(&reply).Updates(map[string]interface{}{
+			"reply_text": text,
+			"edited_at":  time.Now(),
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := config.DB.Preload("Author").First(&reply, "id = ?", reply.ID).Error; err != nil {
+		return nil, err
+	}
+	response := mapReviewReplyToResponse(reply)
+	return &response, nil
+}
+
+// DeleteReviewReply removes the official reply of a review. Its history is kept.
+func DeleteReviewReply(reviewID uuid.UUID, userID uuid.UUID) error {
+	review, err := findReview(reviewID)
+	if err != nil {
+		return err
+	}
+
+	canDelete, err := canUserManageReviewReply(review.PlaceID, userID, true)
+	if err != nil {
+		return err
+	}
+	if !canDelete {
+		return errors.New("unauthorized: only the place owner can delete its replies")
+	}
+
+	result := config.DB.Where("review_id = ?", reviewID).Delete(&domain.ReviewReply{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete reply: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("reply not found")
+	}
+	return nil
+}
+
+// GetReviewReplyHistory lists the earlier texts of a review's reply, oldest first
+func GetReviewReplyHistory(reviewID uuid.UUID, userID uuid.UUID) ([]dto.ReviewReplyRevisionResponse, error) {
+	review, err := findReview(reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	canRead, err := canUserManageReviewReply(review.PlaceID, userID, true)
+	if err != nil {
+		return nil, err
+	}
+	if !canRead {
+		return nil, errors.New("unauthorized: you can't view the history of this reply")
+	}
+
+	var reply domain.ReviewReply
+	if err := config.DB.Unscoped().Where("review_id = ?", reviewID).Order("created_at DESC").First(&reply).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("reply not found")
+		}
+		return nil, err
+	}
+
+	var revisions []domain.ReviewReplyRevision
+	err = config.DB.Preload("Editor").
+		Where("reply_id = ?", reply.ID).
+		Order("created_at ASC").
+		Find(&revisions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.ReviewReplyRevisionResponse, 0, len(revisions))
+	for _, revision := range revisions {
+		response = append(response, dto.ReviewReplyRevisionResponse{
+			ID:        revision.ID,
+			ReplyText: revision.ReplyText,
+			EditedBy: dto.UserInfo{
+				ID:        revision.Editor.ID,
+				Username:  revision.Editor.Username,
+				FirstName: revision.Editor.FirstName,
+				LastName:  revision.Editor.LastName,
+			},
+			ReplacedAt: revision.CreatedAt,
+		})
+	}
+	return response, nil
+}
+
+// canUserManageReviewReply reports whether a user speaks for a place: its creator, an admin,
+// a holder of can_respond_review or a user granted can_respond_review on that place.
+// With moderators set, review moderators may also remove replies and read their history.
+func canUserManageReviewReply(placeID uuid.UUID, userID uuid.UUID, moderators bool) (bool, error) {
+	var place domain.Place
+	if err := config.DB.Select("id", "created_by").First(&place, "id = ?", placeID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return false, errors.New("place not found")
+		}
+		return false, err
+	}
+	if place.CreatedBy == userID {
+		return true, nil
+	}
+
+	var user domain.User
+	if err := config.DB.Preload("Roles.Permissions").Where("id = ?", userID).First(&user).Error; err != nil {
+		return false, fmt.Errorf("user not found")
+	}
+	if user.IsAdmin() || user.HasPermission("can_respond_review") {
+		return true, nil
+	}
+	if moderators && user.IsModerator() && user.HasPermission("can_moderate_review") {
+		return true, nil
+	}
+
+	return hasPlacePermission(placeID, userID, "can_respond_review")
+}
+
+// notifyReviewerOfReply leaves the author of a review a notification that the place replied to it
+func notifyReviewerOfReply(tx *gorm.DB, review domain.Review) error {
+	return createNotification(tx, domain.Notification{
+		UserID:     review.UserID,
+		Type:       domain.NotificationReviewReply,
+		EntityType: "review",
+		EntityID:   &review.ID,
+		PlaceID:    &review.PlaceID,
+	})
+}
+
+func mapReviewReplyToResponse(reply domain.ReviewReply) dto.ReviewReplyResponse {
+	return dto.ReviewReplyResponse{
+		ID:        reply.ID,
+		ReviewID:  reply.ReviewID,
+		ReplyText: reply.ReplyText,
+		Author: dto.UserInfo{
+			ID:        reply.Author.ID,
+			Username:  reply.Author.Username,
+			FirstName: reply.Author.FirstName,
+			LastName:  reply.Author.LastName,
+		},
+		CreatedAt: reply.CreatedAt,
+		EditedAt:  reply.EditedAt,
+	}
+}
@@ -0,0 +1,438 @@
+// services/review_report_service.go
+package services
+
+import (
+	"almlah/config"
+	"almlah/internals/domain"
+	"almlah/internals/dto"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// defaultReviewAutoHideReports is used when REVIEW_AUTO_HIDE_REPORTS is not set
+const defaultReviewAutoHideReports = 3
+
+// reviewAutoHideThreshold is the number of open reports that hides a review until a
+// moderator looks at it
+func reviewAutoHideThreshold() int {
+	threshold, err := strconv.Atoi(getEnvWithDefault("REVIEW_AUTO_HIDE_REPORTS", strconv.Itoa(defaultReviewAutoHideReports)))
+	if err != nil || threshold < 1 {
+		return defaultReviewAutoHideReports
+	}
+	return threshold
+}
+
+// ReportReview flags a review. Once it has enough open reports the review is hidden and
+// stops counting towards the place rating.
+func ReportReview(reviewID uuid.UUID, reporterID uuid.UUID, req dto.ReportReviewRequest) (*dto.ReviewReportResponse, error) {
+	review, err := findReview(reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review.IsHidden {
+		return nil, errors.New("review not found")
+	}
+	if review.UserID == reporterID {
+		return nil, errors.New("unauthorized: you cannot report your own review")
+	}
+
+	report := domain.ReviewReport{
+		ReviewID:   reviewID,
+		ReporterID: reporterID,
+		Reason:     req.Reason,
+		Comment:    strings.TrimSpace(req.Comment),
+		Status:     domain.ReviewReportOpen,
+	}
+
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		review, err = lockReviewForModeration(tx, review)
+		if err != nil {
+			return err
+		}
+
+		var existing int64
+		if err := tx.Model(&domain.ReviewReport{}).Where("review_id = ? AND reporter_id = ?", reviewID, reporterID).Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			return errors.New("you have already reported this review")
+		}
+
+		if err := tx.Omit(clause.Associations).Create(&report).Error; err != nil {
+			return fmt.Errorf("failed to report review: %w", err)
+		}
+
+		openReports, err := recountReviewReports(tx, reviewID)
+		if err != nil {
+			return err
+		}
+		if review.IsHidden || openReports < reviewAutoHideThreshold() {
+			return nil
+		}
+
+		if err := setReviewHidden(tx, review, true); err != nil {
+			return err
+		}
+		action := domain.ReviewModerationAction{
+			ReviewID: reviewID,
+			Action:   domain.ReviewActionAutoHide,
+			Comment:  fmt.Sprintf("Hidden automatically after %d reports", openReports),
+		}
+		if err := tx.Omit(clause.Associations).Create(&action).Error; err != nil {
+			return fmt.Errorf("failed to record moderation action: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.ReviewReportResponse{
+		ID:        report.ID,
+		ReviewID:  report.ReviewID,
+		Reason:    report.Reason,
+		Comment:   report.Comment,
+		Status:    report.Status,
+		CreatedAt: report.CreatedAt,
+	}, nil
+}
+
+// ModerateReview applies a moderator's decision to a review and closes its open reports:
+// dismiss rejects the reports and shows the review again, hide keeps it off the site,
+// delete removes it and warn leaves the comment as a notification for the author.
+func ModerateReview(reviewID uuid.UUID, moderatorID uuid.UUID, req dto.ReviewModerationRequest) (*dto.ReviewModerationActionResponse, error) {
+	req.Comment = strings.TrimSpace(req.Comment)
+	switch req.Action {
+	case domain.ReviewActionDismiss, domain.ReviewActionHide, domain.ReviewActionDelete:
+	case domain.ReviewActionWarn:
+		if req.Comment == "" {
+			return nil, errors.New("a comment is required when warning the author")
+		}
+	default:
+		return nil, fmt.Errorf("invalid action: %s", req.Action)
+	}
+
+	review, err := findReview(reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	action := domain.ReviewModerationAction{
+		ReviewID: reviewID,
+		ActorID:  &moderatorID,
+		Action:   req.Action,
+		Comment:  req.Comment,
+	}
+
+	var imageURLs []string
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		review, err = lockReviewForModeration(tx, review)
+		if err != nil {
+			return err
+		}
+
+		reportStatus := domain.ReviewReportResolved
+		if req.Action == domain.ReviewActionDismiss {
+			reportStatus = domain.ReviewReportDismissed
+		}
+		result := tx.Model(&domain.ReviewReport{}).
+			Where("review_id = ? AND status = ?", reviewID, domain.ReviewReportOpen).
+			Updates(map[string]interface{}{"status": reportStatus, "resolved_at": time.Now()})
+		if result.Error != nil {
+			return fmt.Errorf("failed to close reports: %w", result.Error)
+		}
+		action.ReportsResolved = int(result.RowsAffected)
+		if _, err := recountReviewReports(tx, reviewID); err != nil {
+			return err
+		}
+
+		switch req.Action {
+		case domain.ReviewActionDismiss:
+			if review.IsHidden {
+				if err := setReviewHidden(tx, review, false); err != nil {
+					return err
+				}
+			}
+		case domain.ReviewActionHide:
+			if !review.IsHidden {
+				if err := setReviewHidden(tx, review, true); err != nil {
+					return err
+				}
+			}
+		case domain.ReviewActionDelete:
+			if err := tx.Delete(&domain.Review{}, "id = ?", reviewID).Error; err != nil {
+				return err
+			}
+			if imageURLs, err = deleteReviewImageRows(tx, []uuid.UUID{reviewID}); err != nil {
+				return err
+			}
+			if err := RefreshPlaceRatingStats(tx, review.PlaceID); err != nil {
+				return err
+			}
+		case domain.ReviewActionWarn:
+			err := createNotification(tx, domain.Notification{
+				UserID:     review.UserID,
+				Type:       domain.NotificationReviewWarning,
+				EntityType: "review",
+				EntityID:   &reviewID,
+				PlaceID:    &review.PlaceID,
+				Message:    req.Comment,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Omit(clause.Associations).Create(&action).Error; err != nil {
+			return fmt.Errorf("failed to record moderation action: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	go deleteReviewImageFiles(imageURLs)
+
+	if err := config.DB.Preload("Actor").First(&action, "id = ?", action.ID).Error; err != nil {
+		return nil, err
+	}
+	response := mapReviewModerationActionToResponse(action)
+	response.PlaceID = review.PlaceID
+	return &response, nil
+}
+
+// GetReviewModerationQueue lists reviews with open reports, most reported first and then by
+// their oldest report. hidden limits the queue to hidden or visible reviews; reason to
+// reviews reported for that reason.
+func GetReviewModerationQueue(hidden *bool, reason string, page, limit int) (*dto.PaginationResponse, error) {
+	switch reason {
+	case "", domain.ReviewReportSpam, domain.ReviewReportOffensive, domain.ReviewReportOffTopic, domain.ReviewReportFake:
+	default:
+		return nil, fmt.Errorf("invalid reason: %s", reason)
+	}
+
+	queue := func() *gorm.DB {
+		query := config.DB.Model(&domain.Review{}).Where("report_count > 0")
+		if hidden != nil {
+			query = query.Where("is_hidden = ?", *hidden)
+		}
+		if reason != "" {
+			query = query.Where("EXISTS (SELECT 1 FROM review_reports WHERE review_reports.review_id = reviews.id AND status = ? AND reason = ?)",
+				domain.ReviewReportOpen, reason)
+		}
+		return query
+	}
+
+	var total int64
+	if err := queue().Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count moderation queue: %w", err)
+	}
+
+	var reviews []domain.Review
+	err := queue().Preload("User").
+		Preload("Images").
+		Order("report_count DESC").
+		Order(clause.Expr{SQL: "(SELECT MIN(created_at) FROM review_reports WHERE review_reports.review_id = reviews.id AND status = ?) ASC",
+			Vars: []interface{}{domain.ReviewReportOpen}}).
+		Order("id").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&reviews).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load moderation queue: %w", err)
+	}
+
+	reviewIDs := make([]uuid.UUID, len(reviews))
+	for i, review := range reviews {
+		reviewIDs[i] = review.ID
+	}
+	var reports []domain.ReviewReport
+	if len(reviewIDs) > 0 {
+		err = config.DB.Preload("Reporter").
+			Where("review_id IN ? AND status = ?", reviewIDs, domain.ReviewReportOpen).
+			Order("created_at ASC").
+			Find(&reports).Error
+		if err != nil {
+			return nil, fmt.Errorf("failed to load reports: %w", err)
+		}
+	}
+	reportsByReview := make(map[uuid.UUID][]domain.ReviewReport)
+	for _, report := range reports {
+		reportsByReview[report.ReviewID] = append(reportsByReview[report.ReviewID], report)
+	}
+
+	items := make([]dto.ReviewModerationQueueItem, 0, len(reviews))
+	for _, review := range reviews {
+		item := dto.ReviewModerationQueueItem{
+			Review:      mapReviewToResponse(review),
+			IsHidden:    review.IsHidden,
+			ReportCount: review.ReportCount,
+			Reasons:     make(map[string]int),
+			Reports:     make([]dto.ReviewReportResponse, 0, len(reportsByReview[review.ID])),
+		}
+		for _, report := range reportsByReview[review.ID] {
+			item.Reasons[report.Reason]++
+			item.Reports = append(item.Reports, mapReviewReportToResponse(report))
+		}
+		items = append(items, item)
+	}
+
+	return &dto.PaginationResponse{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+		Data:       items,
+	}, nil
+}
+
+// GetReviewModerationLog lists moderation actions, newest first, optionally for one review
+// or one kind of action. Actions on deleted reviews stay in the log.
+func GetReviewModerationLog(reviewID *uuid.UUID, action string, page, limit int) (*dto.PaginationResponse, error) {
+	switch action {
+	case "", domain.ReviewActionAutoHide, domain.ReviewActionDismiss, domain.ReviewActionHide,
+		domain.ReviewActionDelete, domain.ReviewActionWarn:
+	default:
+		return nil, fmt.Errorf("invalid action: %s", action)
+	}
+
+	logQuery := func() *gorm.DB {
+		query := config.DB.Model(&domain.ReviewModerationAction{})
+		if reviewID != nil {
+			query = query.Where("review_id = ?", *reviewID)
+		}
+		if action != "" {
+			query = query.Where("action = ?", action)
+		}
+		return query
+	}
+
+	var total int64
+	if err := logQuery().Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count moderation log: %w", err)
+	}
+
+	var actions []domain.ReviewModerationAction
+	err := logQuery().Preload("Actor").
+		Preload("Review", func(db *gorm.DB) *gorm.DB {
+			return db.Unscoped().Select("id", "place_id")
+		}).
+		Order("created_at DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&actions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load moderation log: %w", err)
+	}
+
+	items := make([]dto.ReviewModerationActionResponse, 0, len(actions))
+	for _, action := range actions {
+		items = append(items, mapReviewModerationActionToResponse(action))
+	}
+
+	return &dto.PaginationResponse{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+		Data:       items,
+	}, nil
+}
+
+// lockReviewForModeration locks the review's place and then the review, the same order
+// review edits use, and returns the review as stored.
+func lockReviewForModeration(tx *gorm.DB, review domain.Review) (domain.Review, error) {
+	if err := lockPlaceForRating(tx, review.PlaceID); err != nil {
+		return review, err
+	}
+	var locked domain.Review
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&locked, "id = ?", review.ID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return review, errors.New("review not found")
+		}
+		return review, err
+	}
+	return locked, nil
+}
+
+// recountReviewReports stores the number of open reports on the review and returns it
+func recountReviewReports(tx *gorm.DB, reviewID uuid.UUID) (int, error) {
+	var openReports int64
+	err := tx.Model(&domain.ReviewReport{}).
+		Where("review_id = ? AND status = ?", reviewID, domain.ReviewReportOpen).
+		Count(&openReports).Error
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Model(&domain.Review{}).Where("id = ?", reviewID).Update("report_count", openReports).Error; err != nil {
+		return 0, fmt.Errorf("failed to update report count: %w", err)
+	}
+	return int(openReports), nil
+}
+
+// setReviewHidden hides or shows a review and recounts its place's rating. The place must be
+// locked with lockPlaceForRating.
+func setReviewHidden(tx *gorm.DB, review domain.Review, hidden bool) error {
+	var hiddenAt *time.Time
+	if hidden {
+		now := time.Now()
+		hiddenAt = &now
+	}
+	err := tx.Model(&domain.Review{}).Where("id = ?", review.ID).Updates(map[string]interface{}{
+		"is_hidden": hidden,
+		"hidden_at": hiddenAt,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("failed to change review visibility: %w", err)
+	}
+	return RefreshPlaceRatingStats(tx, review.PlaceID)
+}
+
+func mapReviewReportToResponse(report domain.ReviewReport) dto.ReviewReportResponse {
+	response := dto.ReviewReportResponse{
+		ID:         report.ID,
+		ReviewID:   report.ReviewID,
+		Reason:     report.Reason,
+		Comment:    report.Comment,
+		Status:     report.Status,
+		CreatedAt:  report.CreatedAt,
+		ResolvedAt: report.ResolvedAt,
+	}
+	if report.Reporter.ID != uuid.Nil {
+		response.Reporter = &dto.UserInfo{
+			ID:        report.Reporter.ID,
+			Username:  report.Reporter.Username,
+			FirstName: report.Reporter.FirstName,
+			LastName:  report.Reporter.LastName,
+		}
+	}
+	return response
+}
+
+func mapReviewModerationActionToResponse(action domain.ReviewModerationAction) dto.ReviewModerationActionResponse {
+	response := dto.ReviewModerationActionResponse{
+		ID:              action.ID,
+		ReviewID:        action.ReviewID,
+		PlaceID:         action.Review.PlaceID,
+		Action:          action.Action,
+		Comment:         action.Comment,
+		ReportsResolved: action.ReportsResolved,
+		CreatedAt:       action.CreatedAt,
+	}
+	if action.Actor != nil {
+		response.Actor = &dto.UserInfo{
+			ID:        action.Actor.ID,
+			Username:  action.Actor.Username,
+			FirstName: action.Actor.FirstName,
+			LastName:  action.Actor.LastName,
+		}
+	}
+	return response
+}
@@ -4,12 +4,22 @@ import (
 	"almlah/config"
 	"almlah/internals/dto"
 	"almlah/internals/domain"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 func CreateReview(req dto.CreateReviewRequest, userID uuid.UUID) (*dto.ReviewResponse, error) {
+	if err := validateReviewImages(req.Images, 0); err != nil {
+		return nil, err
+	}
+
 	review := domain.Review{
+		ID:           uuid.New(),
 		PlaceID:      req.PlaceID,
 		UserID:       userID,
 		Rating:       req.Rating,
@@ -20,32 +30,331 @@ func CreateReview(req dto.CreateReviewRequest, userID uuid.UUID) (*dto.ReviewRes
 		HelpfulCount: 0,
 	}
 
-	if err := config.DB.Create(&review).Error; err != nil {
+	images, err := uploadReviewImages(review.ID, req.Images)
+	if err != nil {
+		return nil, err
+	}
+
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := lockPlaceForRating(tx, req.PlaceID); err != nil {
+			return err
+		}
+
+		// The place lock also serializes this check against the same user's concurrent posts
+		var existing int64
+		if err := tx.Model(&domain.Review{}).Where("place_id = ? AND user_id = ?", req.PlaceID, userID).Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			return errors.New("you have already reviewed this place, edit your review instead")
+		}
+
+		if err := tx.Create(&review).Error; err != nil {
+			return err
+		}
+		if err := saveReviewImages(tx, review.ID, images); err != nil {
+			return err
+		}
+		return RefreshPlaceRatingStats(tx, req.PlaceID)
+	})
+	if err != nil {
+		go deleteReviewImageFiles(reviewImageURLs(images))
 		return nil, err
 	}
 
 	return GetReviewByID(review.ID)
 }
 
-func GetReviewsByPlaceID(placeID uuid.UUID) ([]dto.ReviewResponse, error) {
-	var reviews []domain.Review
+// UpdateReview edits a review. The previous content is kept as a revision.
+func UpdateReview(reviewID uuid.UUID, req dto.UpdateReviewRequest, userID uuid.UUID) (*dto.ReviewResponse, error) {
+	review, err := findReview(reviewID)
+	if err != nil {
+		return nil, err
+	}
 
-	if err := config.DB.Where("place_id = ?", placeID).Preload("User").Preload("Images").Find(&reviews).Error; err != nil {
+	canModify, err := canUserModifyReview(review, userID, "can_edit_review")
+	if err != nil {
 		return nil, err
 	}
+	if !canModify {
+		return nil, errors.New("unauthorized: you can only edit your own reviews")
+	}
 
-	var response []dto.ReviewResponse
+	var images []domain.ReviewImage
+	if len(req.Images) > 0 {
+		var existingImages int64
+		if err := config.DB.Model(&domain.ReviewImage{}).Where("review_id = ?", reviewID).Count(&existingImages).Error; err != nil {
+			return nil, err
+		}
+		if err := validateReviewImages(req.Images, int(existingImages)); err != nil {
+			return nil, err
+		}
+		if images, err = uploadReviewImages(reviewID, req.Images); err != nil {
+			return nil, err
+		}
+	}
+
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := lockPlaceForRating(tx, review.PlaceID); err != nil {
+			return err
+		}
+		// Re-read under the lock so the revision holds the content this edit replaces
+		if err := tx.First(&review, "id = ?", reviewID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.New("review not found")
+			}
+			return err
+		}
+
+		if err := saveReviewImages(tx, reviewID, images); err != nil {
+			return err
+		}
+
+		revision := domain.ReviewRevision{
+			ReviewID:   review.ID,
+			EditedBy:   userID,
+			Rating:     review.Rating,
+			Title:      review.Title,
+			ReviewText: review.ReviewText,
+			VisitDate:  review.VisitDate,
+		}
+
+		if req.Rating != nil {
+			review.Rating = *req.Rating
+		}
+		if req.Title != nil {
+			review.Title = *req.Title
+		}
+		if req.ReviewText != nil {
+			review.ReviewText = *req.ReviewText
+		}
+		if req.VisitDate != nil {
+			review.VisitDate = req.VisitDate
+		}
+
+		unchanged := review.Rating == revision.Rating && review.Title == revision.Title &&
+			review.ReviewText == revision.ReviewText && sameTime(review.VisitDate, revision.VisitDate)
+		if unchanged {
+			return nil
+		}
+
+		if err := tx.Omit(clause.Associations).Create(&revision).Error; err != nil {
+			return fmt.Errorf("failed to keep review history: %w", err)
+		}
+
+		now := time.Now()
+		err := tx.Model(&review).Updates(map[string]interface{}{
+			"rating":      review.Rating,
+			"title":       review.Title,
+			"review_text": review.ReviewText,
+			"visit_date":  review.VisitDate,
+			"edited_at":   now,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		return RefreshPlaceRatingStats(tx, review.PlaceID)
+	})
+	if err != nil {
+		go deleteReviewImageFiles(reviewImageURLs(images))
+		return nil, err
+	}
+
+	return GetReviewByID(reviewID)
+}
+
+// DeleteReview withdraws a review. It returns the ID of the reviewed place.
+func DeleteReview(reviewID uuid.UUID, userID uuid.UUID) (uuid.UUID, error) {
+	review, err := findReview(reviewID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	canModify, err := canUserModifyReview(review, userID, "can_delete_review")
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if !canModify {
+		return uuid.Nil, errors.New("unauthorized: you can only delete your own reviews")
+	}
+
+	var imageURLs []string
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := lockPlaceForRating(tx, review.PlaceID); err != nil {
+			return err
+		}
+		if err := tx.Delete(&domain.Review{}, "id = ?", reviewID).Error; err != nil {
+			return err
+		}
+		if imageURLs, err = deleteReviewImageRows(tx, []uuid.UUID{reviewID}); err != nil {
+			return err
+		}
+		return RefreshPlaceRatingStats(tx, review.PlaceID)
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	go deleteReviewImageFiles(imageURLs)
+
+	return review.PlaceID, nil
+}
+
+// GetReviewHistory lists the earlier versions of a review, oldest first
+func GetReviewHistory(reviewID uuid.UUID) ([]dto.ReviewRevisionResponse, error) {
+	if _, err := findReview(reviewID); err != nil {
+		return nil, err
+	}
+
+	var revisions []domain.ReviewRevision
+	err := config.DB.Preload("Editor").
+		Where("review_id = ?", reviewID).
+		Order("created_at ASC").
+		Find(&revisions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.ReviewRevisionResponse, 0, len(revisions))
+	for _, revision := range revisions {
+		response = append(response, dto.ReviewRevisionResponse{
+			ID:         revision.ID,
+			Rating:     revision.Rating,
+			Title:      revision.Title,
+			ReviewText: revision.ReviewText,
+			VisitDate:  revision.VisitDate,
+			EditedBy: dto.UserInfo{
+				ID:        revision.Editor.ID,
+				Username:  revision.Editor.Username,
+				FirstName: revision.Editor.FirstName,
+				LastName:  revision.Editor.LastName,
+			},
+			ReplacedAt: revision.CreatedAt,
+		})
+	}
+	return response, nil
+}
+
+func findReview(reviewID uuid.UUID) (domain.Review, error) {
+	var review domain.Review
+	if err := config.DB.First(&review, "id = ?", reviewID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return review, errors.New("review not found")
+		}
+		return review, err
+	}
+	return review, nil
+}
+
+// canUserModifyReview reports whether a user may change a review. Authors can change their
+// own reviews; other reviews need a moderator holding the given permission, since regular
+// users are granted can_edit_review for their own reviews only.
+func canUserModifyReview(review domain.Review, userID uuid.UUID, permission string) (bool, error) {
+	if review.UserID == userID {
+		return true, nil
+	}
+
+	var user domain.User
+	if err := config.DB.Preload("Roles.Permissions").Where("id = ?", userID).First(&user).Error; err != nil {
+		return false, fmt.Errorf("user not found")
+	}
+
+	if user.IsAdmin() || user.IsSuperAdmin() {
+		return true, nil
+	}
+
+	return user.IsModerator() && user.HasPermission(permission), nil
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// reviewSortOrders maps accepted sort_by values to ORDER BY clauses
+var reviewSortOrders = map[string]string{
+	"newest":       "created_at DESC, id",
+	"oldest":       "created_at ASC, id",
+	"most_helpful": "helpful_count DESC, unhelpful_count ASC, created_at DESC, id",
+	"highest":      "rating DESC, created_at DESC, id",
+	"lowest":       "rating ASC, created_at DESC, id",
+}
+
+// GetReviewsByPlaceID returns a page of a place's reviews, optionally limited to some star ratings
+func GetReviewsByPlaceID(placeID uuid.UUID, filters dto.ReviewFilters) (*dto.ReviewListResponse, error) {
+	if filters.SortBy == "" {
+		filters.SortBy = "newest"
+	}
+	order, ok := reviewSortOrders[filters.SortBy]
+	if !ok {
+		return nil, fmt.Errorf("invalid sort_by: %s", filters.SortBy)
+	}
+	if filters.Page < 1 {
+		filters.Page = 1
+	}
+	if filters.PageSize < 1 || filters.PageSize > 100 {
+		filters.PageSize = 20
+	}
+	for _, rating := range filters.Ratings {
+		if rating < 1 || rating > 5 {
+			return nil, fmt.Errorf("invalid rating filter: %d", rating)
+		}
+	}
+
+	query := config.DB.Model(&domain.Review{}).Where("place_id = ? AND is_hidden = ?", placeID, false)
+	if len(filters.Ratings) > 0 {
+		query = query.Where("rating IN ?", filters.Ratings)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	var reviews []domain.Review
+	err := query.Preload("User").
+		Preload("Images").
+		Preload("Reply.Author").
+		Order(order).
+		Offset((filters.Page - 1) * filters.PageSize).
+		Limit(filters.PageSize).
+		Find(&reviews).Error
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.ReviewResponse, 0, len(reviews))
 	for _, review := range reviews {
 		response = append(response, mapReviewToResponse(review))
 	}
 
-	return response, nil
+	return &dto.ReviewListResponse{
+		Reviews:    response,
+		Total:      total,
+		Page:       filters.Page,
+		PageSize:   filters.PageSize,
+		TotalPages: int((total + int64(filters.PageSize) - 1) / int64(filters.PageSize)),
+	}, nil
 }
 
 func GetReviewByID(id uuid.UUID) (*dto.ReviewResponse, error) {
 	var review domain.Review
 
-	if err := config.DB.Preload("User").Preload("Images").First(&review, id).Error; err != nil {
+	if err := config.DB.Preload("User").Preload("Images").Preload("Reply.Author").First(&review, id).Error; err != nil {
+		return nil, err
+	}
+
+	response := mapReviewToResponse(review)
+	return &response, nil
+}
+
+// GetVisibleReviewByID returns a review unless moderation hid it
+func GetVisibleReviewByID(id uuid.UUID) (*dto.ReviewResponse, error) {
+	var review domain.Review
+
+	if err := config.DB.Preload("User").Preload("Images").Preload("Reply.Author").Where("is_hidden = ?", false).First(&review, id).Error; err != nil {
 		return nil, err
 	}
 
@@ -57,21 +366,24 @@ func mapReviewToResponse(review domain.Review) dto.ReviewResponse {
 	var images []dto.ImageResponse
 	for _, img := range review.Images {
 		images = append(images, dto.ImageResponse{
-			ID:  img.ID,
-			URL: img.ImageURL,
+			ID:      img.ID,
+			URL:     img.ImageURL,
+			AltText: img.Caption,
 		})
 	}
 
-	return dto.ReviewResponse{
-		ID:           review.ID,
-		PlaceID:      review.PlaceID,
-		Rating:       review.Rating,
-		Title:        review.Title,
-		ReviewText:   review.ReviewText,
-		VisitDate:    review.VisitDate,
-		IsVerified:   review.IsVerified,
-		HelpfulCount: review.HelpfulCount,
-		CreatedAt:    review.CreatedAt,
+	response := dto.ReviewResponse{
+		ID:             review.ID,
+		PlaceID:        review.PlaceID,
+		Rating:         review.Rating,
+		Title:          review.Title,
+		ReviewText:     review.ReviewText,
+		VisitDate:      review.VisitDate,
+		IsVerified:     review.IsVerified,
+		HelpfulCount:   review.HelpfulCount,
+		UnhelpfulCount: review.UnhelpfulCount,
+		CreatedAt:      review.CreatedAt,
+		EditedAt:       review.EditedAt,
 		Author: dto.UserInfo{
 			ID:        review.User.ID,
 			Username:  review.User.Username,
@@ -80,4 +392,9 @@ func mapReviewToResponse(review domain.Review) dto.ReviewResponse {
 		},
 		Images: images,
 	}
+	if review.Reply != nil {
+		reply := mapReviewReplyToResponse(*review.Reply)
+		response.Reply = &reply
+	}
+	return response
 }
\ No newline at end of file
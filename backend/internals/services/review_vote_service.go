@@ -0,0 +1,107 @@
+// services/review_vote_service.go
+package services
+
+import (
+	"almlah/config"
+	"almlah/internals/domain"
+	"almlah/internals/dto"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// VoteOnReview records a user's helpful or unhelpful vote, replacing any earlier vote of theirs
+func VoteOnReview(reviewID uuid.UUID, userID uuid.UUID, vote string) (*dto.ReviewVoteResponse, error) {
+	if vote != domain.ReviewVoteHelpful && vote != domain.ReviewVoteUnhelpful {
+		return nil, errors.New("invalid vote")
+	}
+
+	return changeReviewVote(reviewID, userID, func(tx *gorm.DB, current *domain.ReviewVote) error {
+		if current == nil {
+			return tx.Omit(clause.Associations).Create(&domain.ReviewVote{ReviewID: reviewID, UserID: userID, Vote: vote}).Error
+		}
+		if current.Vote == vote {
+			return nil
+		}
+		return tx.Model(current).Update("vote", vote).Error
+	})
+}
+
+// RemoveReviewVote takes back a user's vote if it is the given one
+func RemoveReviewVote(reviewID uuid.UUID, userID uuid.UUID, vote string) (*dto.ReviewVoteResponse, error) {
+	return changeReviewVote(reviewID, userID, func(tx *gorm.DB, current *domain.ReviewVote) error {
+		if current == nil || current.Vote != vote {
+			return nil
+		}
+		return tx.Delete(current).Error
+	})
+}
+
+// changeReviewVote applies a vote change and recounts the review's votes. The review row is
+// locked for the whole change, so concurrent votes on one review are counted one at a time.
+func changeReviewVote(reviewID uuid.UUID, userID uuid.UUID, change func(tx *gorm.DB, current *domain.ReviewVote) error) (*dto.ReviewVoteResponse, error) {
+	response := &dto.ReviewVoteResponse{ReviewID: reviewID}
+
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		var review domain.Review
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&review, "id = ?", reviewID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errors.New("review not found")
+			}
+			return err
+		}
+		if review.IsHidden {
+			return errors.New("review not found")
+		}
+		if review.UserID == userID {
+			return errors.New("unauthorized: you cannot vote on your own review")
+		}
+
+		var current *domain.ReviewVote
+		var vote domain.ReviewVote
+		err := tx.Where("review_id = ? AND user_id = ?", reviewID, userID).First(&vote).Error
+		if err == nil {
+			current = &vote
+		} else if err != gorm.ErrRecordNotFound {
+			return err
+		}
+
+		if err := change(tx, current); err != nil {
+			return err
+		}
+
+		err = tx.Exec(`UPDATE reviews SET
+			helpful_count = (SELECT COUNT(*) FROM review_votes WHERE review_id = ? AND vote = ?),
+			unhelpful_count = (SELECT COUNT(*) FROM review_votes WHERE review_id = ? AND vote = ?)
+			WHERE id = ?`,
+			reviewID, domain.ReviewVoteHelpful, reviewID, domain.ReviewVoteUnhelpful, reviewID).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Select("helpful_count", "unhelpful_count").First(&review, "id = ?", reviewID).Error; err != nil {
+			return err
+		}
+		response.HelpfulCount = review.HelpfulCount
+		response.UnhelpfulCount = review.UnhelpfulCount
+
+		var votes []string
+		err = tx.Model(&domain.ReviewVote{}).
+			Where("review_id = ? AND user_id = ?", reviewID, userID).
+			Pluck("vote", &votes).Error
+		if err != nil {
+			return err
+		}
+		if len(votes) > 0 {
+			response.UserVote = votes[0]
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
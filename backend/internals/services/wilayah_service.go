@@ -5,6 +5,7 @@ import (
 	"almlah/internals/domain"
 	"almlah/internals/dto"
 	"errors"
+	"fmt"
 
 	"github.com/google/uuid"
 )
@@ -88,6 +89,9 @@ func UpdateWilayah(id uuid.UUID, req dto.UpdateWilayahRequest, userID uuid.UUID)
 		return nil, errors.New("wilayah not found")
 	}
 
+	// Search documents copy the names, the places are reindexed when they change
+	renamed := (req.NameAr != "" && req.NameAr != wilayah.NameAr) || (req.NameEn != "" && req.NameEn != wilayah.NameEn)
+
 	// Update fields if provided
 	if req.NameAr != "" {
 		wilayah.NameAr = req.NameAr
@@ -134,6 +138,12 @@ func UpdateWilayah(id uuid.UUID, req dto.UpdateWilayahRequest, userID uuid.UUID)
 		return nil, err
 	}
 
+	if renamed {
+		if _, err := RefreshWilayahSearchDocuments(wilayah.ID); err != nil {
+			fmt.Printf("⚠️ Failed to reindex places of wilayah %s: %v\n", id, err)
+		}
+	}
+
 	return GetWilayahByID(wilayah.ID)
 }
 
@@ -225,6 +235,6 @@ func mapWilayahToResponse(wilayah domain.Wilayah) dto.WilayahResponse {
 
 func getPlaceCountForWilayah(wilayahID uuid.UUID) int {
 	var count int64
-	config.DB.Model(&domain.Place{}).Where("wilayah_id = ?", wilayahID).Count(&count)
+	config.DB.Model(&domain.Place{}).Where("wilayah_id = ?", wilayahID).Scopes(publishedPlacesOnly).Count(&count)
 	return int(count)
 }
\ No newline at end of file
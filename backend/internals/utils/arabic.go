@@ -0,0 +1,184 @@
+package utils
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// arabicFolding maps Arabic letter variants that users type interchangeably to one form
+var arabicFolding = map[rune]rune{
+	'أ': 'ا',
+	'إ': 'ا',
+	'آ': 'ا',
+	'ٱ': 'ا',
+	'ى': 'ي',
+	'ئ': 'ي',
+	'ؤ': 'و',
+	'ة': 'ه',
+}
+
+const arabicTatweel = 'ـ'
+
+// isArabicDiacritic reports whether r is a tashkeel mark (fatha, damma, kasra, shadda, sukun...)
+func isArabicDiacritic(r rune) bool {
+	return (r >= 0x064B && r <= 0x065F) || r == 0x0670
+}
+
+// normalizeSearchRune folds a single rune for search. ok is false when the rune should be dropped.
+func normalizeSearchRune(r rune) (rune, bool) {
+	if r == arabicTatweel || isArabicDiacritic(r) {
+		return 0, false
+	}
+	if folded, exists := arabicFolding[r]; exists {
+		return folded, true
+	}
+	if unicode.IsSpace(r) {
+		return ' ', true
+	}
+	return unicode.ToLower(r), true
+}
+
+// NormalizeSearchText folds Arabic spelling variants, strips tashkeel and tatweel,
+// lowercases Latin text and collapses whitespace so that "نزوى" and "نزوي" compare equal.
+func NormalizeSearchText(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+
+	lastSpace := true
+	for _, r := range s {
+		n, ok := normalizeSearchRune(r)
+		if !ok {
+			continue
+		}
+		if n == ' ' {
+			if lastSpace {
+				continue
+			}
+			lastSpace = true
+		} else {
+			lastSpace = false
+		}
+		b.WriteRune(n)
+	}
+
+	return strings.TrimSpace(b.String())
+}
+
+// SearchTerms returns the distinct normalized words of a search query
+func SearchTerms(query string) []string {
+	seen := make(map[string]bool)
+	var terms []string
+	for _, term := range strings.Fields(NormalizeSearchText(query)) {
+		if !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// HighlightSnippet returns a window of at most maxRunes runes from text around the first
+// occurrence of any term, wrapping every occurrence in <mark></mark>. Matching is done on the
+// normalized form so variant spellings and diacritics still highlight the original text.
+// The text is HTML-escaped, so the result is safe to render as HTML.
+// An empty string is returned when none of the terms occur in text.
+func HighlightSnippet(text string, terms []string, maxRunes int) string {
+	original := []rune(text)
+
+	// Build the normalized text together with a mapping back to original rune positions
+	normalized := make([]rune, 0, len(original))
+	positions := make([]int, 0, len(original))
+	for i, r := range original {
+		n, ok := normalizeSearchRune(r)
+		if !ok {
+			continue
+		}
+		normalized = append(normalized, n)
+		positions = append(positions, i)
+	}
+
+	// Collect matched spans in original rune positions
+	type span struct{ start, end int }
+	var spans []span
+	marked := make([]bool, len(original))
+	for _, term := range terms {
+		termRunes := []rune(term)
+		if len(termRunes) == 0 {
+			continue
+		}
+		for i := 0; i+len(termRunes) <= len(normalized); i++ {
+			if string(normalized[i:i+len(termRunes)]) != term {
+				continue
+			}
+			start := positions[i]
+			end := positions[i+len(termRunes)-1] + 1
+			// Keep trailing diacritics attached to the highlighted word
+			for end < len(original) && (original[end] == arabicTatweel || isArabicDiacritic(original[end])) {
+				end++
+			}
+			overlaps := false
+			for j := start; j < end; j++ {
+				if marked[j] {
+					overlaps = true
+					break
+				}
+			}
+			if overlaps {
+				continue
+			}
+			for j := start; j < end; j++ {
+				marked[j] = true
+			}
+			spans = append(spans, span{start, end})
+		}
+	}
+
+	if len(spans) == 0 {
+		return ""
+	}
+
+	// Center the window on the earliest match
+	first := spans[0].start
+	for _, s := range spans {
+		if s.start < first {
+			first = s.start
+		}
+	}
+	windowStart, windowEnd := 0, len(original)
+	if maxRunes > 0 && len(original) > maxRunes {
+		windowStart = first - maxRunes/4
+		if windowStart < 0 {
+			windowStart = 0
+		}
+		windowEnd = windowStart + maxRunes
+		if windowEnd > len(original) {
+			windowEnd = len(original)
+			windowStart = windowEnd - maxRunes
+		}
+	}
+
+	var b strings.Builder
+	if windowStart > 0 {
+		b.WriteString("…")
+	}
+	// The text is user content, every segment is escaped and only the mark tags are HTML
+	for i := windowStart; i < windowEnd; {
+		j := i
+		for j < windowEnd && marked[j] == marked[i] {
+			j++
+		}
+		segment := html.EscapeString(string(original[i:j]))
+		if marked[i] {
+			b.WriteString("<mark>" + segment + "</mark>")
+		} else {
+			b.WriteString(segment)
+		}
+		i = j
+	}
+	if windowEnd < len(original) {
+		b.WriteString("…")
+	}
+
+	return b.String()
+}
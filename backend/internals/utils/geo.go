@@ -0,0 +1,54 @@
+package utils
+
+import "math"
+
+// EarthRadiusKm is the mean radius of the Earth used for great-circle distances
+const EarthRadiusKm = 6371.0
+
+// kmPerDegreeLat is the (almost constant) length of one degree of latitude
+const kmPerDegreeLat = 111.045
+
+// HaversineKm returns the great-circle distance in kilometers between two coordinates
+func HaversineKm(lat1, lng1, lat2, lng2 float64) float64 {
+	dLat := (lat2 - lat1) * math.Pi / 180
+	dLng := (lng2 - lng1) * math.Pi / 180
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*math.Pi/180)*math.Cos(lat2*math.Pi/180)*
+			math.Sin(dLng/2)*math.Sin(dLng/2)
+
+	return EarthRadiusKm * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+// BoundingBox describes a lat/lng rectangle that fully contains a search circle
+type BoundingBox struct {
+	MinLat float64
+	MaxLat float64
+	MinLng float64
+	MaxLng float64
+}
+
+// BoundingBoxAround returns the rectangle enclosing a circle of radiusKm around lat/lng.
+// It is used as a cheap, index-friendly prefilter before computing exact distances.
+func BoundingBoxAround(lat, lng, radiusKm float64) BoundingBox {
+	latDelta := radiusKm / kmPerDegreeLat
+
+	// Longitude degrees shrink towards the poles; clamp to avoid dividing by ~0
+	cosLat := math.Cos(lat * math.Pi / 180)
+	if cosLat < 0.01 {
+		cosLat = 0.01
+	}
+	lngDelta := radiusKm / (kmPerDegreeLat * cosLat)
+
+	return BoundingBox{
+		MinLat: math.Max(lat-latDelta, -90),
+		MaxLat: math.Min(lat+latDelta, 90),
+		MinLng: math.Max(lng-lngDelta, -180),
+		MaxLng: math.Min(lng+lngDelta, 180),
+	}
+}
+
+// ValidCoordinates reports whether lat/lng are inside the valid WGS84 range
+func ValidCoordinates(lat, lng float64) bool {
+	return lat >= -90 && lat <= 90 && lng >= -180 && lng <= 180
+}
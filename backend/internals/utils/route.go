@@ -0,0 +1,138 @@
+package utils
+
+import "math"
+
+// GeoPoint is a latitude/longitude pair
+type GeoPoint struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// exactRouteLimit is the largest number of points OptimizeRouteOrder solves exactly. The exact
+// search grows with 2^n, above this a heuristic is used.
+const exactRouteLimit = 12
+
+// OptimizeRouteOrder returns the order (indexes into points) that visits every point with the
+// shortest great-circle distance. The route starts at points[0] and doesn't return to it.
+// Up to exactRouteLimit points the order is exact, longer routes get a nearest neighbour
+// route improved with 2-opt.
+func OptimizeRouteOrder(points []GeoPoint) []int {
+	n := len(points)
+	if n <= 2 {
+		order := make([]int, n)
+		for i := range order {
+			order[i] = i
+		}
+		return order
+	}
+
+	dist := make([][]float64, n)
+	for i := range dist {
+		dist[i] = make([]float64, n)
+		for j := range dist[i] {
+			dist[i][j] = HaversineKm(points[i].Latitude, points[i].Longitude, points[j].Latitude, points[j].Longitude)
+		}
+	}
+
+	if n <= exactRouteLimit {
+		return exactRouteOrder(dist)
+	}
+	return twoOptRouteOrder(dist, nearestNeighbourRouteOrder(dist))
+}
+
+// exactRouteOrder solves the open route from point 0 with Held-Karp dynamic programming
+func exactRouteOrder(dist [][]float64) []int {
+	n := len(dist)
+	full := 1 << n
+
+	// cost[mask][j] is the shortest route from 0 through the points in mask ending at j
+	cost := make([][]float64, full)
+	prev := make([][]int, full)
+	for mask := range cost {
+		cost[mask] = make([]float64, n)
+		prev[mask] = make([]int, n)
+		for j := range cost[mask] {
+			cost[mask][j] = math.Inf(1)
+			prev[mask][j] = -1
+		}
+	}
+	cost[1][0] = 0
+
+	for mask := 1; mask < full; mask += 2 { // Every route contains the start point
+		for j := 0; j < n; j++ {
+			if mask&(1<<j) == 0 || math.IsInf(cost[mask][j], 1) {
+				continue
+			}
+			for k := 1; k < n; k++ {
+				if mask&(1<<k) != 0 {
+					continue
+				}
+				next := mask | 1<<k
+				if c := cost[mask][j] + dist[j][k]; c < cost[next][k] {
+					cost[next][k] = c
+					prev[next][k] = j
+				}
+			}
+		}
+	}
+
+	last := 1
+	for j := 2; j < n; j++ {
+		if cost[full-1][j] < cost[full-1][last] {
+			last = j
+		}
+	}
+
+	order := make([]int, n)
+	mask := full - 1
+	for i := n - 1; i >= 0; i-- {
+		order[i] = last
+		last, mask = prev[mask][last], mask&^(1<<last)
+	}
+	return order
+}
+
+// nearestNeighbourRouteOrder starts at point 0 and always moves to the closest unvisited point
+func nearestNeighbourRouteOrder(dist [][]float64) []int {
+	n := len(dist)
+	visited := make([]bool, n)
+	order := []int{0}
+	visited[0] = true
+
+	for len(order) < n {
+		current := order[len(order)-1]
+		next := -1
+		for k := 0; k < n; k++ {
+			if !visited[k] && (next == -1 || dist[current][k] < dist[current][next]) {
+				next = k
+			}
+		}
+		visited[next] = true
+		order = append(order, next)
+	}
+	return order
+}
+
+// twoOptRouteOrder reverses segments of the route while that makes it shorter. The first
+// point stays in place.
+func twoOptRouteOrder(dist [][]float64, order []int) []int {
+	n := len(order)
+	for improved := true; improved; {
+		improved = false
+		for i := 1; i < n-1; i++ {
+			for k := i + 1; k < n; k++ {
+				delta := dist[order[i-1]][order[k]] - dist[order[i-1]][order[i]]
+				if k+1 < n {
+					delta += dist[order[i]][order[k+1]] - dist[order[k]][order[k+1]]
+				}
+				if delta < -1e-9 {
+					for a, b := i, k; a < b; a, b = a+1, b-1 {
+						order[a], order[b] = order[b], order[a]
+					}
+					improved = true
+				}
+			}
+		}
+	}
+	return order
+}
@@ -0,0 +1,95 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// genericPlaceWords are words describing the kind of place rather than naming it, so
+// "Jabrin Castle" and "Jibreen Fort" compare on "Jabrin" and "Jibreen" only.
+// Arabic entries are in NormalizeSearchText form.
+var genericPlaceWords = map[string]bool{
+	"the": true, "of": true, "al": true, "el": true,
+	"castle": true, "fort": true, "fortress": true, "tower": true,
+	"hisn": true, "husn": true, "qala": true, "qalaa": true, "qalat": true, "burj": true,
+	"قلعه": true, "حصن": true, "برج": true,
+}
+
+// NameSimilarity scores how likely two place names refer to the same place, from 0 to 1.
+// It ignores generic words, Arabic spelling variants and the vowel differences common in
+// transliterations (Jabrin / Jibreen, Bahla / Bahlaa).
+func NameSimilarity(a, b string) float64 {
+	skeletonA := []rune(nameSkeleton(a))
+	skeletonB := []rune(nameSkeleton(b))
+	if len(skeletonA) == 0 || len(skeletonB) == 0 {
+		return 0
+	}
+
+	longest := len(skeletonA)
+	if len(skeletonB) > longest {
+		longest = len(skeletonB)
+	}
+	return 1 - float64(levenshtein(skeletonA, skeletonB))/float64(longest)
+}
+
+// nameSkeleton reduces a name to the consonants of its distinctive words
+func nameSkeleton(name string) string {
+	words := strings.FieldsFunc(NormalizeSearchText(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var all, distinctive []string
+	for _, word := range words {
+		all = append(all, word)
+		if !genericPlaceWords[word] {
+			distinctive = append(distinctive, word)
+		}
+	}
+	// A name made only of generic words ("The Fort") is compared as is
+	if len(distinctive) == 0 {
+		distinctive = all
+	}
+
+	var b strings.Builder
+	for _, word := range distinctive {
+		// Drop the Arabic definite article
+		if trimmed := strings.TrimPrefix(word, "ال"); trimmed != word && len([]rune(trimmed)) >= 2 {
+			word = trimmed
+		}
+
+		var last rune
+		for i, r := range []rune(word) {
+			if i > 0 && strings.ContainsRune("aeiouy", r) {
+				continue
+			}
+			if r == last {
+				continue
+			}
+			b.WriteRune(r)
+			last = r
+		}
+	}
+	return b.String()
+}
+
+// levenshtein returns the edit distance between two rune slices
+func levenshtein(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// MaxSlugLength is the longest slug Slugify produces, in characters
+const MaxSlugLength = 80
+
+// Slugify turns a name into a URL slug: letters and digits of any script are kept
+// (so Arabic names give Arabic slugs), Latin text is lowercased, tashkeel and tatweel
+// are dropped and everything else becomes a single hyphen.
+func Slugify(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+
+	length := 0
+	pendingHyphen := false
+	for _, r := range s {
+		if r == arabicTatweel || isArabicDiacritic(r) {
+			continue
+		}
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			pendingHyphen = length > 0
+			continue
+		}
+		if length >= MaxSlugLength {
+			break
+		}
+		if pendingHyphen {
+			b.WriteRune('-')
+			length++
+			pendingHyphen = false
+		}
+		b.WriteRune(unicode.ToLower(r))
+		length++
+	}
+
+	return strings.TrimSuffix(b.String(), "-")
+}
@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"sync"
+	"time"
+)
+
+var (
+	muscatLocation     *time.Location
+	muscatLocationOnce sync.Once
+)
+
+// MuscatLocation returns the Asia/Muscat time zone. Oman has no daylight saving, so a fixed
+// UTC+4 zone is used when the tz database isn't available (e.g. minimal containers).
+func MuscatLocation() *time.Location {
+	muscatLocationOnce.Do(func() {
+		loc, err := time.LoadLocation("Asia/Muscat")
+		if err != nil {
+			loc = time.FixedZone("Asia/Muscat", 4*60*60)
+		}
+		muscatLocation = loc
+	})
+	return muscatLocation
+}
+
+// NowInMuscat returns the current time in Asia/Muscat
+func NowInMuscat() time.Time {
+	return time.Now().In(MuscatLocation())
+}